@@ -3,17 +3,26 @@ package app
 import (
 	"context"
 	"dice-game/pkg/config"
+	"dice-game/pkg/domain/dice"
+	"dice-game/pkg/domain/model"
 	"dice-game/pkg/domain/repository"
+	"dice-game/pkg/domain/rules"
 	"dice-game/pkg/domain/service"
 	"dice-game/pkg/infrastructure/db"
 	"dice-game/pkg/infrastructure/grpc"
 	"dice-game/pkg/infrastructure/random"
+	"dice-game/pkg/infrastructure/sealing"
+	"dice-game/pkg/infrastructure/signing"
 	"dice-game/pkg/usecase"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"github.com/rs/zerolog"
 	"net"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -23,17 +32,33 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	seedModeRotating = "rotating"
+	seedModeChain    = "chain"
+
+	defaultSeedChainLength = 10000
+
+	defaultMerkleSealInterval = time.Hour
+
+	healthReportInterval = 5 * time.Minute
+)
+
 type Application struct {
-	once          sync.Once
-	logger        *zerolog.Logger
-	config        *config.AppConfig
-	initialized   bool
-	configMutex   sync.Mutex
-	dataStore     repository.DataStore
-	grpcServer    *grpc.Server
-	randomService service.RandomServiceInterface
-	gameService   service.GameServiceInterface
-	gameUseCase   usecase.GameUseCaseInterface
+	once           sync.Once
+	logger         *zerolog.Logger
+	config         *config.AppConfig
+	initialized    bool
+	configMutex    sync.Mutex
+	dataStore      repository.DataStore
+	grpcServer     *grpc.Server
+	randomService  service.RandomServiceInterface
+	gameService    service.GameServiceInterface
+	ledgerService  service.LedgerServiceInterface
+	receiptService service.ReceiptServiceInterface
+	entropySealer  service.EntropySealer
+	gameUseCase    usecase.GameUseCaseInterface
+	adminUseCase   usecase.AdminUseCaseInterface
+	plugins        []*random.PluginGenerator
 }
 
 func NewApplication() *Application {
@@ -163,6 +188,7 @@ func (a *Application) LoadConfig() error {
 	}
 
 	v.BindEnv("grpc.host", "GRPC_HOST")
+	v.BindEnv("grpc.admin_token", "GRPC_ADMIN_TOKEN")
 	v.BindEnv("http.port", "HTTP_PORT")
 	v.BindEnv("http.host", "HTTP_HOST")
 	v.BindEnv("log.level", "LOG_LEVEL")
@@ -170,6 +196,25 @@ func (a *Application) LoadConfig() error {
 	v.BindEnv("environment", "ENVIRONMENT")
 	v.BindEnv("version", "VERSION")
 	v.BindEnv("game.enable_verification", "GAME_ENABLE_VERIFICATION")
+	v.BindEnv("game.algorithm_version", "GAME_ALGORITHM_VERSION")
+	v.BindEnv("game.seed_mode", "GAME_SEED_MODE")
+	v.BindEnv("game.seed_chain_length", "GAME_SEED_CHAIN_LENGTH")
+	v.BindEnv("game.signing.key_file", "GAME_SIGNING_KEY_FILE")
+	v.BindEnv("game.entropy_audit.enabled", "GAME_ENTROPY_AUDIT_ENABLED")
+	v.BindEnv("game.entropy_audit.key_file", "GAME_ENTROPY_AUDIT_KEY_FILE")
+	v.BindEnv("game.vrf.key_file", "GAME_VRF_KEY_FILE")
+	v.BindEnv("game.beacon.url", "GAME_BEACON_URL")
+	v.BindEnv("game.beacon.file", "GAME_BEACON_FILE")
+	v.BindEnv("game.generator_selection", "GAME_GENERATOR_SELECTION")
+	v.BindEnv("game.default_generator", "GAME_DEFAULT_GENERATOR")
+	v.BindEnv("game.player_generators", "GAME_PLAYER_GENERATORS")
+	v.BindEnv("game.dice_tables", "GAME_DICE_TABLES")
+	v.BindEnv("game.buffered_crypto.enabled", "GAME_BUFFERED_CRYPTO_ENABLED")
+	v.BindEnv("game.replay.enabled", "GAME_REPLAY_ENABLED")
+	v.BindEnv("game.replay.seed", "GAME_REPLAY_SEED")
+	v.BindEnv("game.replay.start_sequence", "GAME_REPLAY_START_SEQUENCE")
+	v.BindEnv("game.health.enabled", "GAME_HEALTH_ENABLED")
+	v.BindEnv("game.health.window_size", "GAME_HEALTH_WINDOW_SIZE")
 
 	if err := v.ReadInConfig(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
@@ -233,6 +278,73 @@ func (a *Application) validateConfig() error {
 		return fmt.Errorf("GRPC port is not configured")
 	}
 
+	if _, err := random.SchemeByVersion(a.algorithmVersion()); err != nil {
+		a.logger.Error().Int("algorithm_version", a.config.Game.AlgorithmVersion).Msg("Unknown provably fair algorithm version")
+		return err
+	}
+
+	switch a.config.Game.SeedMode {
+	case "", seedModeRotating, seedModeChain:
+	default:
+		a.logger.Error().Str("seed_mode", a.config.Game.SeedMode).Msg("Unknown seed mode")
+		return fmt.Errorf("unknown seed mode %q", a.config.Game.SeedMode)
+	}
+
+	if a.config.Game.SeedChainLength < 0 {
+		return fmt.Errorf("seed chain length must not be negative")
+	}
+
+	if a.beaconSource() != nil && a.config.Game.SeedMode == seedModeChain {
+		return fmt.Errorf("the beacon generator requires seed mode %q", seedModeRotating)
+	}
+
+	if err := a.validateBeaconPeriod(); err != nil {
+		a.logger.Error().Err(err).Str("url", a.config.Game.Beacon.URL).Msg("Beacon chain is too slow")
+		return err
+	}
+
+	if a.config.Game.Replay.Enabled {
+		if a.isProduction() {
+			a.logger.Error().Msg("Replay mode is enabled in production")
+			return fmt.Errorf("replay mode may not be enabled in production")
+		}
+		if a.config.Game.Replay.Seed == "" {
+			return fmt.Errorf("replay mode needs a seed")
+		}
+	}
+
+	for _, plugin := range a.config.Game.Plugins {
+		if plugin.Name == "" {
+			return fmt.Errorf("generator plugin needs a name")
+		}
+		if plugin.Command == "" && plugin.Socket == "" {
+			return fmt.Errorf("generator plugin %s needs a command or a socket", plugin.Name)
+		}
+	}
+
+	for _, table := range a.config.Game.DiceTables {
+		if _, err := dice.Parse(table); err != nil {
+			a.logger.Error().Str("dice", table).Msg("Invalid dice table")
+			return err
+		}
+	}
+
+	for variant, policy := range a.config.Game.DrawPolicies {
+		if _, err := rules.Builtin().Get(variant); err != nil {
+			a.logger.Error().Str("variant", variant).Msg("Draw policy for unknown variant")
+			return err
+		}
+		if _, err := rules.ParseDrawPolicy(policy); err != nil {
+			a.logger.Error().Str("variant", variant).Str("draw_policy", policy).Msg("Invalid draw policy")
+			return err
+		}
+	}
+
+	if _, err := a.generatorSelector(); err != nil {
+		a.logger.Error().Str("generator_selection", a.config.Game.GeneratorSelection).Msg("Invalid generator selection")
+		return err
+	}
+
 	return nil
 }
 
@@ -251,13 +363,27 @@ func (a *Application) Start(ctx context.Context) error {
 		return err
 	}
 
-	a.initRandomGenerators()
+	if err := a.initRandomGenerators(ctx); err != nil {
+		return err
+	}
+	if err := a.initGeneratorSelection(); err != nil {
+		return err
+	}
+	if err := a.initReceiptSigning(); err != nil {
+		return err
+	}
+	if err := a.initEntropySealing(); err != nil {
+		return err
+	}
 	a.initServices()
 
 	if err := a.startGRPCServer(ctx); err != nil {
 		return err
 	}
 
+	go a.runMerkleSealer(ctx)
+	go a.runHealthReporter(ctx)
+
 	<-ctx.Done()
 	return ctx.Err()
 }
@@ -297,35 +423,461 @@ func (a *Application) initDatabase(ctx context.Context) error {
 	return nil
 }
 
-func (a *Application) initRandomGenerators() {
-	randomGenerators := []random.Generator{
+func (a *Application) initRandomGenerators(ctx context.Context) error {
+	if a.config.Game.Replay.Enabled {
+		a.initReplayGenerator()
+		return nil
+	}
+
+	randomService := service.NewRandomService([]random.Generator{
 		random.NewStandardGenerator(),
 		random.NewCryptoGenerator(),
+	})
+	a.randomService = randomService
+	a.useHealthMonitor(randomService)
+
+	if cfg := a.config.Game.BufferedCrypto; cfg.Enabled {
+		a.logger.Info().
+			Dur("reseed_interval", cfg.ReseedInterval).
+			Msg("Buffered crypto generator enabled")
+
+		if err := randomService.AddGenerator(random.NewBufferedCryptoGenerator(cfg.ReseedInterval)); err != nil {
+			return errors.Wrap(err, "failed to register buffered crypto generator")
+		}
+	}
+
+	if err := a.addPluginGenerators(randomService); err != nil {
+		return err
+	}
+
+	if !a.config.Game.EnableVerification {
+		return nil
+	}
+
+	if err := a.addVRFGenerator(randomService); err != nil {
+		return err
+	}
+
+	scheme, err := random.SchemeByVersion(a.algorithmVersion())
+	if err != nil {
+		return errors.Wrap(err, "failed to select provably fair scheme")
+	}
+
+	if a.config.Game.SeedMode == seedModeChain {
+		randomService.UseSeedChains(a.dataStore.GetSeedChainRepository(), a.seedChainLength())
+
+		if err := a.ensureActiveSeedChain(ctx); err != nil {
+			return err
+		}
+
+		a.logger.Info().
+			Int("algorithm_version", scheme.Version()).
+			Str("algorithm", scheme.Name()).
+			Msg("Hash chain generator enabled")
+
+		return errors.Wrap(randomService.AddGenerator(random.NewHashChainGenerator(scheme)), "failed to register hash chain generator")
+	}
+
+	serverSeed, err := a.loadActiveServerSeed(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info().
+		Int("algorithm_version", scheme.Version()).
+		Str("algorithm", scheme.Name()).
+		Msg("Provably fair generator enabled")
+
+	provablyFair := random.NewProovablyFairGenerator(serverSeed.Seed, scheme)
+	if err := randomService.AddGenerator(provablyFair); err != nil {
+		return errors.Wrap(err, "failed to register provably fair generator")
+	}
+
+	if source := a.beaconSource(); source != nil {
+		a.logger.Info().
+			Str("url", a.config.Game.Beacon.URL).
+			Str("file", a.config.Game.Beacon.File).
+			Msg("Beacon generator enabled")
+
+		if err := randomService.AddGenerator(random.NewBeaconGenerator(provablyFair, source)); err != nil {
+			return errors.Wrap(err, "failed to register beacon generator")
+		}
+	}
+
+	return nil
+}
+
+// addPluginGenerators starts the configured generator plugins and
+// registers them. A plugin that fails to start stops the server.
+func (a *Application) addPluginGenerators(randomService *service.RandomService) error {
+	for _, cfg := range a.config.Game.Plugins {
+		logger := a.logger.With().Str("generator", cfg.Name).Logger()
+
+		plugin := random.NewPluginGenerator(random.PluginConfig{
+			Name:           cfg.Name,
+			Command:        cfg.Command,
+			Args:           cfg.Args,
+			Env:            cfg.Env,
+			Socket:         cfg.Socket,
+			Timeout:        cfg.Timeout,
+			StartTimeout:   cfg.StartTimeout,
+			HealthInterval: cfg.HealthInterval,
+			OnFailure: func(err error) {
+				logger.Error().Err(err).Msg("Generator plugin failed, restarting")
+			},
+		})
+		if err := plugin.Start(); err != nil {
+			return errors.Wrapf(err, "failed to start generator plugin %s", cfg.Name)
+		}
+		a.plugins = append(a.plugins, plugin)
+
+		logger.Info().
+			Str("command", cfg.Command).
+			Str("socket", cfg.Socket).
+			Msg("Generator plugin enabled")
+
+		if err := randomService.AddGenerator(plugin); err != nil {
+			return errors.Wrapf(err, "failed to register generator plugin %s", cfg.Name)
+		}
+	}
+
+	return nil
+}
+
+func (a *Application) useHealthMonitor(randomService *service.RandomService) {
+	if a.config.Game.Health.Enabled {
+		randomService.UseHealthMonitor(service.NewHealthMonitor(a.config.Game.Health.WindowSize, a.logQuarantine))
+	}
+}
+
+// initReplayGenerator registers the deterministic generator as the only
+// generator, so every game follows from the replay seed and its sequence
+// number.
+func (a *Application) initReplayGenerator() {
+	replay := a.config.Game.Replay
+
+	a.logger.Warn().
+		Str("seed_hash", random.HashServerSeed(replay.Seed)).
+		Int64("start_sequence", replay.StartSequence).
+		Msg("Replay mode enabled: games are reproducible and flagged as non-production")
+
+	randomService := service.NewRandomService([]random.Generator{
+		random.NewDeterministicGenerator(replay.Seed, replay.StartSequence),
+	})
+	a.randomService = randomService
+	a.useHealthMonitor(randomService)
+}
+
+func (a *Application) isProduction() bool {
+	return a.config.Environment == "production"
+}
+
+func (a *Application) generatorSelector() (service.GeneratorSelector, error) {
+	return service.NewGeneratorSelector(
+		a.config.Game.GeneratorSelection,
+		a.config.Game.DefaultGenerator,
+		a.config.Game.GeneratorWeights,
+	)
+}
+
+// initGeneratorSelection sets the configured selection strategy and the
+// generators players may request once every generator is registered, so
+// generators they name can be checked.
+func (a *Application) initGeneratorSelection() error {
+	if a.config.Game.Replay.Enabled {
+		// The deterministic generator is the only one; players may not
+		// request another.
+		return nil
+	}
+
+	selector, err := a.generatorSelector()
+	if err != nil {
+		return errors.Wrap(err, "failed to create generator selector")
+	}
+
+	var names []string
+	switch selector.Name() {
+	case service.SelectionFixed:
+		names = []string{a.config.Game.DefaultGenerator}
+	case service.SelectionWeighted:
+		for name, weight := range a.config.Game.GeneratorWeights {
+			if weight > 0 {
+				names = append(names, name)
+			}
+		}
 	}
+	for _, name := range names {
+		if _, err := a.randomService.GetGeneratorByName(name); err != nil {
+			return errors.Errorf("generator %s used by %s selection is not enabled", name, selector.Name())
+		}
+	}
+
+	a.logger.Info().
+		Str("strategy", selector.Name()).
+		Str("default_generator", a.config.Game.DefaultGenerator).
+		Interface("weights", a.config.Game.GeneratorWeights).
+		Msg("Generator selection configured")
+
+	a.randomService.UseSelector(selector)
 
-	if a.config.Game.EnableVerification {
-		clientSeedFunc := func() string {
-			return time.Now().Format(time.RFC3339)
+	for _, name := range a.config.Game.PlayerGenerators {
+		if _, err := a.randomService.GetGeneratorByName(name); err != nil {
+			a.logger.Warn().Str("generator", name).Msg("Generator players may request is not enabled")
 		}
+	}
+	a.randomService.AllowGeneratorChoice(a.config.Game.PlayerGenerators)
+
+	return nil
+}
+
+// beaconSlack is what a beacon game needs on top of waiting for its round:
+// the node's delay in serving the round, the roll and saving the game.
+const beaconSlack = time.Second
+
+// validateBeaconPeriod rejects a beacon chain whose rounds come too rarely
+// for the RPC deadlines. A beacon game waits up to one period for its
+// round, so the period must fit in a Play, and a match of the most games
+// must fit in a PlayMatch. drand's default chain, with a 30s period, does
+// not; quicknet, with 3s, does. A beacon file has no period and is not
+// checked.
+func (a *Application) validateBeaconPeriod() error {
+	if a.config.Game.Beacon.File != "" || a.config.Game.Beacon.URL == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	period, err := random.NewHTTPBeacon(a.config.Game.Beacon.URL).Period(ctx)
+	if err != nil {
+		return err
+	}
+
+	perGame := period + beaconSlack
+	maxGames := slices.Max(service.MatchLengths)
+	if perGame > grpc.PlayTimeout || time.Duration(maxGames)*perGame > grpc.MatchTimeout {
+		return fmt.Errorf("beacon chain period %s does not fit the %s Play and %s PlayMatch deadlines, use a fast chain such as quicknet", period, grpc.PlayTimeout, grpc.MatchTimeout)
+	}
+
+	return nil
+}
+
+// beaconSource returns the configured randomness beacon, or nil when the
+// beacon generator is disabled. A local file takes precedence over the URL.
+func (a *Application) beaconSource() random.BeaconSource {
+	switch {
+	case a.config.Game.Beacon.File != "":
+		return random.NewFileBeacon(a.config.Game.Beacon.File)
+	case a.config.Game.Beacon.URL != "":
+		return random.NewHTTPBeacon(a.config.Game.Beacon.URL)
+	default:
+		return nil
+	}
+}
+
+func (a *Application) addVRFGenerator(randomService *service.RandomService) error {
+	keyFile := a.config.Game.VRF.KeyFile
+	if keyFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read vrf key file")
+	}
 
-		serverSeed := fmt.Sprintf("server-seed-%d", time.Now().UnixNano())
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return errors.Wrap(err, "failed to decode vrf key file")
+	}
 
-		provablyFairGen := random.NewProovablyFairGenerator(serverSeed, clientSeedFunc)
-		randomGenerators = append(randomGenerators, provablyFairGen)
+	generator, err := random.NewVRFGenerator(seed)
+	if err != nil {
+		return errors.Wrap(err, "failed to create vrf generator")
 	}
 
-	a.randomService = service.NewRandomService(randomGenerators)
+	a.logger.Info().
+		Str("public_key", hex.EncodeToString(generator.PublicKey())).
+		Msg("VRF generator enabled")
+
+	return errors.Wrap(randomService.AddGenerator(generator), "failed to register vrf generator")
+}
+
+func (a *Application) seedChainLength() int {
+	if a.config.Game.SeedChainLength == 0 {
+		return defaultSeedChainLength
+	}
+	return a.config.Game.SeedChainLength
+}
+
+func (a *Application) ensureActiveSeedChain(ctx context.Context) error {
+	chain, err := a.randomService.GetActiveSeedChain(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to load active seed chain")
+	}
+
+	if chain == nil {
+		chain, err = a.randomService.CreateSeedChain(ctx, a.seedChainLength())
+		if err != nil {
+			return errors.Wrap(err, "failed to create seed chain")
+		}
+		a.logger.Info().
+			Int64("chain_id", chain.ID).
+			Str("terminal_hash", chain.TerminalHash).
+			Int("length", chain.Length).
+			Msg("Committed to new seed chain")
+		return nil
+	}
+
+	a.logger.Info().
+		Int64("chain_id", chain.ID).
+		Str("terminal_hash", chain.TerminalHash).
+		Int("consumed", chain.Consumed).
+		Int("length", chain.Length).
+		Msg("Loaded active seed chain")
+	return nil
+}
+
+func (a *Application) algorithmVersion() int {
+	if a.config.Game.AlgorithmVersion == 0 {
+		return random.LatestSchemeVersion
+	}
+	return a.config.Game.AlgorithmVersion
+}
+
+func (a *Application) loadActiveServerSeed(ctx context.Context) (*model.ServerSeed, error) {
+	seedRepository := a.dataStore.GetSeedRepository()
+
+	serverSeed, err := seedRepository.GetActiveServerSeed(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load active server seed")
+	}
+
+	if serverSeed != nil {
+		a.logger.Info().Str("server_seed_hash", serverSeed.Hash).Msg("Loaded active server seed")
+		return serverSeed, nil
+	}
+
+	seed, err := random.NewServerSeed()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate server seed")
+	}
+
+	serverSeed = &model.ServerSeed{
+		Seed:      seed,
+		Hash:      random.HashServerSeed(seed),
+		CreatedAt: time.Now(),
+	}
+
+	if err := seedRepository.SaveServerSeed(ctx, serverSeed); err != nil {
+		return nil, errors.Wrap(err, "failed to save server seed")
+	}
+
+	a.logger.Info().Str("server_seed_hash", serverSeed.Hash).Msg("Committed to new server seed")
+	return serverSeed, nil
+}
+
+func (a *Application) initReceiptSigning() error {
+	keyFile := a.config.Game.Signing.KeyFile
+	if keyFile == "" {
+		a.logger.Warn().Msg("No signing key file configured, game receipts will not be signed")
+		a.receiptService = service.NewReceiptService(nil)
+		return nil
+	}
+
+	keyRing, err := signing.LoadKeyFile(keyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load signing keys")
+	}
+
+	a.receiptService = service.NewReceiptService(keyRing)
+	a.logger.Info().
+		Str("key_id", keyRing.CurrentKeyID()).
+		Int("keys", len(keyRing.Keys())).
+		Msg("Loaded receipt signing keys")
+	return nil
+}
+
+func (a *Application) initEntropySealing() error {
+	cfg := a.config.Game.EntropyAudit
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.KeyFile == "" {
+		a.logger.Warn().Msg("No entropy sealing key file configured, recorded entropy will be stored unencrypted")
+		return nil
+	}
+
+	keyRing, err := sealing.LoadKeyFile(cfg.KeyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load entropy sealing keys")
+	}
+
+	a.entropySealer = keyRing
+	a.logger.Info().Str("key_id", keyRing.CurrentKeyID()).Msg("Loaded entropy sealing keys")
+	return nil
 }
 
 func (a *Application) initServices() {
 	gameRepository := a.dataStore.GetGameRepository()
-	a.gameService = service.NewGameService(a.randomService, gameRepository)
-	a.gameUseCase = usecase.NewGameUseCase(a.gameService)
+	seedRepository := a.dataStore.GetSeedRepository()
+	verificationRepository := a.dataStore.GetVerificationRepository()
+	gameService := service.NewGameService(a.randomService, gameRepository, seedRepository, verificationRepository)
+	if a.isProduction() {
+		gameService.RefuseNonProductionGames()
+	}
+	if err := gameService.OfferDice(a.config.Game.DiceTables); err != nil {
+		a.logger.Error().Err(err).Msg("Failed to offer dice tables")
+	}
+	if err := gameService.ResolveDraws(a.config.Game.DrawPolicies); err != nil {
+		a.logger.Error().Err(err).Msg("Failed to set draw policies")
+	}
+	if a.config.Game.EntropyAudit.Enabled {
+		gameService.RecordEntropy(a.dataStore.GetEntropyRepository(), a.entropySealer)
+	}
+	a.gameService = gameService
+	a.ledgerService = service.NewLedgerService(gameRepository, a.dataStore.GetMerkleRootRepository())
+	matchService := service.NewMatchService(gameService, a.dataStore.GetMatchRepository())
+	a.gameUseCase = usecase.NewGameUseCase(a.gameService, a.ledgerService, a.receiptService, matchService)
+	a.adminUseCase = usecase.NewAdminUseCase(a.randomService, a.gameService, a.generatorFactory())
+}
+
+// generatorFactory builds the generators operators may register at runtime:
+// the ones set up at startup, so they can be registered again after being
+// unregistered, and the generators that need no configuration.
+func (a *Application) generatorFactory() usecase.GeneratorFactory {
+	startup := make(map[string]random.Generator)
+	for _, info := range a.randomService.ListGenerators() {
+		if generator, err := a.randomService.GetGeneratorByName(info.Name); err == nil {
+			startup[info.Name] = generator
+		}
+	}
+
+	return func(name string) (random.Generator, error) {
+		if generator, ok := startup[name]; ok {
+			return generator, nil
+		}
+		if a.config.Game.Replay.Enabled {
+			// Any other generator would break the replay.
+			return nil, fmt.Errorf("%w in replay mode: %s", usecase.ErrUnknownGenerator, name)
+		}
+
+		switch name {
+		case "standard":
+			return random.NewStandardGenerator(), nil
+		case "crypto":
+			return random.NewCryptoGenerator(), nil
+		case "crypto_buffered":
+			return random.NewBufferedCryptoGenerator(a.config.Game.BufferedCrypto.ReseedInterval), nil
+		default:
+			return nil, fmt.Errorf("%w: %s", usecase.ErrUnknownGenerator, name)
+		}
+	}
 }
 
 func (a *Application) startGRPCServer(ctx context.Context) error {
 	grpcAddr := net.JoinHostPort(a.config.GRPCHost(), a.config.GRPCPort())
-	a.grpcServer = grpc.NewServer(grpcAddr, a.logger, a.gameUseCase)
+	a.grpcServer = grpc.NewServer(grpcAddr, a.logger, a.gameUseCase, a.adminUseCase, a.config.GRPC.AdminToken)
 	g, gCtx := errgroup.WithContext(ctx)
 
 	g.Go(func() error {
@@ -345,6 +897,81 @@ func (a *Application) startGRPCServer(ctx context.Context) error {
 	}
 }
 
+// runMerkleSealer seals finished days under a Merkle root until ctx is done.
+func (a *Application) runMerkleSealer(ctx context.Context) {
+	interval := a.config.Game.MerkleSealInterval
+	if interval <= 0 {
+		interval = defaultMerkleSealInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		roots, err := a.ledgerService.SealPendingDays(ctx, time.Now())
+		if err != nil {
+			a.logger.Error().Err(err).Msg("Failed to seal game results")
+		}
+		for _, root := range roots {
+			a.logger.Info().
+				Str("date", root.Date.Format("2006-01-02")).
+				Str("root_hash", root.RootHash).
+				Int("games", root.LeafCount).
+				Msg("Sealed game results under merkle root")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Application) logQuarantine(health model.GeneratorHealth) {
+	a.logger.Error().
+		Str("generator", health.Generator).
+		Int("min", health.Min).
+		Int("max", health.Max).
+		Str("reason", health.Reason).
+		Float64("chi_square", health.ChiSquare).
+		Float64("runs_z", health.RunsZ).
+		Int("repetition", health.Repetition).
+		Msg("Generator failed health tests and was quarantined")
+}
+
+// runHealthReporter logs the health test state of every generator until ctx
+// is done.
+func (a *Application) runHealthReporter(ctx context.Context) {
+	ticker := time.NewTicker(healthReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, health := range a.randomService.GetGeneratorHealth() {
+			event := a.logger.Info()
+			if health.Status == model.HealthQuarantined {
+				event = a.logger.Warn().Str("reason", health.Reason)
+			}
+			event.
+				Str("generator", health.Generator).
+				Int("min", health.Min).
+				Int("max", health.Max).
+				Str("status", string(health.Status)).
+				Int("samples", health.Samples).
+				Float64("chi_square", health.ChiSquare).
+				Float64("runs_z", health.RunsZ).
+				Int("repetition", health.Repetition).
+				Msg("Generator health")
+		}
+	}
+}
+
 func (a *Application) Stop(ctx context.Context) error {
 	a.logger.Info().Msg("Shutting down application components...")
 
@@ -353,6 +980,11 @@ func (a *Application) Stop(ctx context.Context) error {
 		a.grpcServer.Stop()
 	}
 
+	for _, plugin := range a.plugins {
+		a.logger.Info().Str("generator", plugin.Name()).Msg("Stopping generator plugin...")
+		plugin.Close()
+	}
+
 	if a.dataStore != nil {
 		a.logger.Info().Msg("Closing database connection...")
 		if err := a.dataStore.Close(ctx); err != nil {
@@ -0,0 +1,222 @@
+// Command rngtest certifies a random generator: it draws millions of values,
+// runs the NIST SP 800-22 style battery of pkg/infrastructure/rngtest on
+// them and writes a JSON report with the p-value of every test.
+//
+// Certify the crypto generator with the defaults, 8 million bits and one
+// million d6 rolls:
+//
+//	rngtest -generator crypto -out crypto-report.json
+//
+// Seeded generators take their seed from -seed, or a fresh random one:
+//
+//	rngtest -generator provably_fair -seed <server seed> -version 3
+//	rngtest -generator vrf -vrf-key-file vrf.key
+//
+// External generator plugins are started like the server starts them:
+//
+//	rngtest -generator hardware -plugin-command /usr/local/bin/hwrng-plugin
+//
+// The exit code is 0 when every test passed, 1 when one failed and 2 on
+// errors.
+package main
+
+import (
+	"crypto/rand"
+	"dice-game/pkg/infrastructure/random"
+	"dice-game/pkg/infrastructure/rngtest"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	exitPassed = 0
+	exitFailed = 1
+	exitError  = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// options are the command line flags that pick and configure the
+// generator under test.
+type options struct {
+	generator     string
+	seed          string
+	version       int
+	vrfKeyFile    string
+	pluginCommand string
+	pluginArgs    string
+	pluginSocket  string
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("rngtest", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var opts options
+	flags.StringVar(&opts.generator, "generator", "crypto", "generator to certify: standard, crypto, crypto_buffered, provably_fair, hash_chain, deterministic, vrf, or the name of a plugin")
+	flags.StringVar(&opts.seed, "seed", "", "server seed of provably_fair and hash_chain, or deterministic seed (default: random)")
+	flags.IntVar(&opts.version, "version", random.LatestSchemeVersion, "provably fair algorithm version")
+	flags.StringVar(&opts.vrfKeyFile, "vrf-key-file", "", "file with the base64 encoded VRF secret key seed (default: random key)")
+	flags.StringVar(&opts.pluginCommand, "plugin-command", "", "command that starts the generator plugin")
+	flags.StringVar(&opts.pluginArgs, "plugin-args", "", "space separated arguments of the plugin command")
+	flags.StringVar(&opts.pluginSocket, "plugin-socket", "", "Unix socket the generator plugin listens on")
+	byteCount := flags.Int("bytes", 1_000_000, "bytes to draw for the bit tests")
+	rolls := flags.Int("rolls", 1_000_000, "dice to roll for the chi-square test")
+	sides := flags.Int("sides", 6, "sides of the dice")
+	alpha := flags.Float64("alpha", rngtest.DefaultAlpha, "significance level")
+	out := flags.String("out", "", "file to write the JSON report to (default: stdout)")
+
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	generator, closeGenerator, err := newGenerator(opts)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	defer closeGenerator()
+
+	startedAt := time.Now()
+	sample, err := rngtest.Collect(generator, *byteCount, *rolls, *sides)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	report := rngtest.Run(sample, *alpha)
+	report.Generator = generator.Name()
+	report.StartedAt = startedAt.UTC()
+	report.DurationSeconds = time.Since(startedAt).Seconds()
+
+	if err := writeReport(report, *out, stdout); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	for _, result := range report.Tests {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(stderr, "%s %-20s %v %s\n", status, result.Name, result.PValues, result.Error)
+	}
+
+	if !report.Passed {
+		return exitFailed
+	}
+	return exitPassed
+}
+
+// newGenerator builds the generator under test and returns the function
+// that releases it.
+func newGenerator(opts options) (random.Generator, func(), error) {
+	noop := func() {}
+
+	switch opts.generator {
+	case "standard":
+		return random.NewStandardGenerator(), noop, nil
+	case "crypto":
+		return random.NewCryptoGenerator(), noop, nil
+	case "crypto_buffered":
+		return random.NewBufferedCryptoGenerator(0), noop, nil
+	case "provably_fair", "hash_chain":
+		scheme, err := random.SchemeByVersion(opts.version)
+		if err != nil {
+			return nil, nil, err
+		}
+		seed, err := seedOrRandom(opts.seed)
+		if err != nil {
+			return nil, nil, err
+		}
+		if opts.generator == "hash_chain" {
+			return &chainGenerator{HashChainGenerator: random.NewHashChainGenerator(scheme), seed: seed}, noop, nil
+		}
+		return random.NewProovablyFairGenerator(seed, scheme), noop, nil
+	case "deterministic":
+		seed, err := seedOrRandom(opts.seed)
+		if err != nil {
+			return nil, nil, err
+		}
+		return random.NewDeterministicGenerator(seed, 1), noop, nil
+	case "vrf":
+		seed := make([]byte, 32)
+		if opts.vrfKeyFile != "" {
+			data, err := os.ReadFile(opts.vrfKeyFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read vrf key file: %w", err)
+			}
+			if seed, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(data))); err != nil {
+				return nil, nil, fmt.Errorf("failed to decode vrf key file: %w", err)
+			}
+		} else if _, err := rand.Read(seed); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate vrf key: %w", err)
+		}
+		generator, err := random.NewVRFGenerator(seed)
+		return generator, noop, err
+	}
+
+	if opts.pluginCommand == "" && opts.pluginSocket == "" {
+		return nil, nil, fmt.Errorf("unknown generator %q: plugins need -plugin-command or -plugin-socket", opts.generator)
+	}
+
+	plugin := random.NewPluginGenerator(random.PluginConfig{
+		Name:    opts.generator,
+		Command: opts.pluginCommand,
+		Args:    strings.Fields(opts.pluginArgs),
+		Socket:  opts.pluginSocket,
+		Stderr:  os.Stderr,
+	})
+	if err := plugin.Start(); err != nil {
+		plugin.Close()
+		return nil, nil, err
+	}
+	return plugin, func() { plugin.Close() }, nil
+}
+
+// chainGenerator rolls every batch of a hash chain generator with the next
+// seed of a chain, walking it forwards. The server walks its chains
+// backwards, but both visit the same kind of seeds: SHA-256 hashes of the
+// previous one.
+type chainGenerator struct {
+	*random.HashChainGenerator
+	seed string
+}
+
+func (g *chainGenerator) Roll(req random.RollRequest) (*random.Roll, error) {
+	g.seed = random.HashServerSeed(g.seed)
+	return g.RollWithServerSeed(g.seed, req)
+}
+
+func seedOrRandom(seed string) (string, error) {
+	if seed != "" {
+		return seed, nil
+	}
+	return random.NewServerSeed()
+}
+
+func writeReport(report *rngtest.Report, path string, stdout io.Writer) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	data = append(data, '\n')
+
+	if path == "" {
+		_, err = stdout.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"dice-game/pkg/infrastructure/rngtest"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_WritesReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{
+		"-generator", "deterministic", "-seed", "rngtest",
+		"-bytes", "20000", "-rolls", "6000", "-out", path,
+	}, &stdout, &stderr)
+
+	assert.Equal(t, exitPassed, code, stderr.String())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var report rngtest.Report
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "deterministic", report.Generator)
+	assert.Equal(t, 160000, report.Bits)
+	assert.Equal(t, 6000, report.DiceRolls)
+	assert.True(t, report.Passed)
+	assert.Len(t, report.Tests, 7)
+	assert.Contains(t, stderr.String(), "PASS approximate_entropy")
+}
+
+func TestRun_ProvablyFairToStdout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{
+		"-generator", "hash_chain", "-seed", "rngtest-hash-chain",
+		"-bytes", "20000", "-rolls", "6000",
+	}, &stdout, &stderr)
+
+	assert.Equal(t, exitPassed, code, stderr.String())
+	var report rngtest.Report
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.Equal(t, "hash_chain", report.Generator)
+}
+
+func TestRun_FailingTestsExitWithOne(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-generator", "deterministic", "-seed", "rngtest", "-bytes", "5", "-rolls", "6000"}, &stdout, &stderr)
+
+	assert.Equal(t, exitFailed, code)
+	assert.Contains(t, stderr.String(), "FAIL frequency")
+}
+
+func TestRun_UnknownGenerator(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-generator", "quantum"}, &stdout, &stderr)
+
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr.String(), `unknown generator "quantum"`)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"dice-game/pkg/infrastructure/random"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// exportedGame is one game in a JSON or NDJSON export. Field names follow
+// the game_results and server_seeds columns. Hash chain games also carry the
+// chain's terminal hash and the link position from GetSeedChain; VRF games
+// carry the server's VRF public key instead of a server seed. Games rolled
+// with a dice expression carry it and the individual dice of both sides.
+// Exports made before variants existed carry neither variant nor winner and
+// are checked as classic games by their dice alone. Games carry their draw
+// policy and the rounds from the game_rounds table; the dice fields are
+// the last round, and exports without rounds only have it checked. Beacon
+// games carry their played_at, the beacon round's number, randomness and
+// publish time, and the created_at of their server seed.
+type exportedGame struct {
+	GameID            string          `json:"game_id"`
+	PlayerID          string          `json:"player_id"`
+	PlayerDice        int             `json:"player_dice"`
+	ServerDice        int             `json:"server_dice"`
+	Winner            string          `json:"winner"`
+	Variant           string          `json:"variant"`
+	VariantVersion    int             `json:"variant_version"`
+	DrawPolicy        string          `json:"draw_policy"`
+	Rounds            []exportedRound `json:"rounds"`
+	Dice              string          `json:"dice"`
+	PlayerRolls       []int           `json:"player_rolls"`
+	ServerRolls       []int           `json:"server_rolls"`
+	VerificationKey   string          `json:"verification_key"`
+	ClientSeed        string          `json:"client_seed"`
+	Nonce             int64           `json:"nonce"`
+	ServerSeed        string          `json:"server_seed"`
+	AlgorithmVersion  int             `json:"algorithm_version"`
+	ChainTerminalHash string          `json:"chain_terminal_hash"`
+	ChainPosition     int             `json:"chain_position"`
+	VRFPublicKey      string          `json:"vrf_public_key"`
+	PlayedAt          time.Time       `json:"played_at"`
+	BeaconRound       int64           `json:"beacon_round"`
+	BeaconRandomness  string          `json:"beacon_randomness"`
+	BeaconPublishedAt time.Time       `json:"beacon_published_at"`
+	// ServerSeedCreatedAt is server_seeds.created_at.
+	ServerSeedCreatedAt time.Time `json:"server_seed_created_at"`
+}
+
+// exportedRound is one row of game_rounds.
+type exportedRound struct {
+	PlayerDice  int   `json:"player_dice"`
+	ServerDice  int   `json:"server_dice"`
+	PlayerRolls []int `json:"player_rolls"`
+	ServerRolls []int `json:"server_rolls"`
+}
+
+// decodeGames reads either a JSON array of games or a stream of
+// newline-delimited game objects.
+func decodeGames(r io.Reader) ([]*exportedGame, error) {
+	reader := bufio.NewReader(r)
+
+	first, err := firstNonSpace(reader)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(reader)
+
+	if first == '[' {
+		var games []*exportedGame
+		if err := decoder.Decode(&games); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %w", err)
+		}
+		return games, nil
+	}
+
+	var games []*exportedGame
+	for {
+		var game exportedGame
+		err := decoder.Decode(&game)
+		if err == io.EOF {
+			return games, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid game #%d: %w", len(games)+1, err)
+		}
+		games = append(games, &game)
+	}
+}
+
+func firstNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, reader.UnreadByte()
+	}
+}
+
+// algorithmVersion returns the recorded scheme version, falling back to the
+// version in the verification key and then to version 1 for old exports.
+func (g *exportedGame) algorithmVersion() int {
+	if g.AlgorithmVersion != 0 {
+		return g.AlgorithmVersion
+	}
+
+	if key, err := random.ParseVerificationKey(g.VerificationKey); err == nil {
+		return key.Version
+	}
+
+	return 1
+}
@@ -0,0 +1,403 @@
+// Command verify recomputes provably fair games offline, without the
+// database or the gRPC server.
+//
+// Verify a single game:
+//
+//	verify -server-seed <seed> -player-id <player> -client-seed <seed> -nonce 3 \
+//	    -verification-key <key> -player-dice 4 -server-dice 2
+//
+// Games of other variants, dice or draw policies name them; dice expression
+// games also pass the individual dice of the deciding round:
+//
+//	verify ... -variant sum-of-three -draw-policy reroll:3 \
+//	    -player-dice 11 -player-rolls 2,4,5 -server-dice 9 -server-rolls 1,3,5
+//
+// Games played on a seed chain can also be checked against the chain's
+// published terminal hash:
+//
+//	verify -server-seed <seed> ... -chain-terminal-hash <hash> -chain-position 12
+//
+// Beacon games are checked from an export, which carries the beacon round
+// the game was bound to.
+//
+// VRF games need no server seed, only the server's VRF public key:
+//
+//	verify -vrf-public-key <hex> -player-id <player> -client-seed <seed> -nonce 3 \
+//	    -verification-key <key> -player-dice 4 -server-dice 2
+//
+// Verify every game in a JSON array or NDJSON export:
+//
+//	verify -file games.ndjson
+package main
+
+import (
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/rules"
+	"dice-game/pkg/domain/service"
+	"dice-game/pkg/infrastructure/random"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	exitValid    = 0
+	exitMismatch = 1
+	exitError    = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("file", "", "JSON array or NDJSON file of exported games")
+	serverSeed := flags.String("server-seed", "", "revealed server seed")
+	playerID := flags.String("player-id", "", "player the game was played by (algorithm version 4 and later)")
+	clientSeed := flags.String("client-seed", "", "client seed of the game")
+	nonce := flags.Int64("nonce", 0, "nonce of the game")
+	version := flags.Int("version", 0, "provably fair algorithm version (default: taken from -verification-key, else 1)")
+	verificationKey := flags.String("verification-key", "", "verification key returned by Play")
+	playerDice := flags.Int("player-dice", 0, "player dice to check")
+	serverDice := flags.Int("server-dice", 0, "server dice to check")
+	chainTerminalHash := flags.String("chain-terminal-hash", "", "published terminal hash of the game's seed chain")
+	chainPosition := flags.Int("chain-position", 0, "position of the server seed in its seed chain")
+	vrfPublicKey := flags.String("vrf-public-key", "", "hex public key of the server's VRF generator")
+	variant := flags.String("variant", "", "game variant (default: classic)")
+	variantVersion := flags.Int("variant-version", 0, "version of -variant (default: the latest)")
+	drawPolicy := flags.String("draw-policy", "", "draw policy, such as keep or reroll:3 (default: keep)")
+	diceNotation := flags.String("dice", "", "dice expression both sides rolled (default: the variant's dice)")
+	playerRolls := flags.String("player-rolls", "", "comma-separated player dice of the deciding round, for dice expressions")
+	serverRolls := flags.String("server-rolls", "", "comma-separated server dice of the deciding round, for dice expressions")
+
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if *file != "" {
+		return verifyFile(*file, stdout, stderr)
+	}
+
+	if *serverSeed == "" && *vrfPublicKey == "" {
+		fmt.Fprintln(stderr, "either -file, -server-seed or -vrf-public-key is required")
+		flags.Usage()
+		return exitError
+	}
+
+	if *variant != "" && *variantVersion == 0 {
+		latest, err := rules.Builtin().Get(*variant)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+		*variantVersion = latest.Version()
+	}
+
+	game := &exportedGame{
+		Variant:           *variant,
+		VariantVersion:    *variantVersion,
+		DrawPolicy:        *drawPolicy,
+		Dice:              *diceNotation,
+		ServerSeed:        *serverSeed,
+		PlayerID:          *playerID,
+		ClientSeed:        *clientSeed,
+		Nonce:             *nonce,
+		AlgorithmVersion:  *version,
+		VerificationKey:   *verificationKey,
+		PlayerDice:        *playerDice,
+		ServerDice:        *serverDice,
+		ChainTerminalHash: *chainTerminalHash,
+		ChainPosition:     *chainPosition,
+		VRFPublicKey:      *vrfPublicKey,
+	}
+
+	var err error
+	if game.PlayerRolls, err = parseRolls(*playerRolls); err != nil {
+		fmt.Fprintf(stderr, "invalid -player-rolls: %v\n", err)
+		return exitError
+	}
+	if game.ServerRolls, err = parseRolls(*serverRolls); err != nil {
+		fmt.Fprintf(stderr, "invalid -server-rolls: %v\n", err)
+		return exitError
+	}
+
+	if game.isVRF() {
+		return verifySingleVRF(game, stdout, stderr)
+	}
+
+	return verifySingle(game, stdout, stderr)
+}
+
+// verifySingle recomputes the values a game drew with the same draw plan and
+// rules as the batch check and prints every value and round.
+func verifySingle(game *exportedGame, stdout, stderr io.Writer) int {
+	scheme, err := random.SchemeByVersion(game.algorithmVersion())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	result := game.toGameResult()
+	plan, err := service.DrawPlan(result)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	values, hash, err := random.ProvablyFairValues(scheme, game.ServerSeed, game.PlayerID, game.ClientSeed, game.Nonce, plan.Count, plan.Min, plan.Max)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	rounds, winner, err := service.PlayDrawn(result, values)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	fmt.Fprintf(stdout, "algorithm:        v%d %s\n", scheme.Version(), scheme.Name())
+	fmt.Fprintf(stdout, "server seed hash: %s\n", random.HashServerSeed(game.ServerSeed))
+	fmt.Fprintf(stdout, "hash:             %s\n", hash)
+	fmt.Fprintf(stdout, "values drawn:     %s\n", formatRolls(values))
+	if len(rounds) > 1 {
+		for i, round := range rounds {
+			fmt.Fprintf(stdout, "%-18s player %d, server %d\n", fmt.Sprintf("round %d:", i+1), round.PlayerDice, round.ServerDice)
+		}
+	}
+
+	last := rounds[len(rounds)-1]
+	fmt.Fprintf(stdout, "player dice:      %d\n", last.PlayerDice)
+	if last.PlayerRolls != nil {
+		fmt.Fprintf(stdout, "player rolls:     %s\n", formatRolls(last.PlayerRolls))
+	}
+	fmt.Fprintf(stdout, "server dice:      %d\n", last.ServerDice)
+	if last.ServerRolls != nil {
+		fmt.Fprintf(stdout, "server rolls:     %s\n", formatRolls(last.ServerRolls))
+	}
+	fmt.Fprintf(stdout, "winner:           %s\n", winner)
+
+	if game.ChainTerminalHash != "" {
+		if err := game.checkSeedChain(); err != nil {
+			return reportFailure(stdout, stderr, "game", err)
+		}
+		fmt.Fprintln(stdout, "seed chain:       VALID")
+	}
+
+	if game.VerificationKey == "" {
+		return exitValid
+	}
+
+	if last.PlayerRolls != nil && (game.PlayerRolls == nil || game.ServerRolls == nil) {
+		fmt.Fprintln(stderr, "-player-rolls and -server-rolls are required to check games rolled with a dice expression")
+		return exitError
+	}
+
+	if err := service.CheckProvablyFair(result, game.ServerSeed); err != nil {
+		return reportFailure(stdout, stderr, "game", err)
+	}
+
+	fmt.Fprintln(stdout, "result:           VALID")
+	return exitValid
+}
+
+// parseRolls parses a comma-separated list of dice; an empty list is nil.
+func parseRolls(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(s, ",")
+	rolls := make([]int, len(fields))
+	for i, field := range fields {
+		roll, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		rolls[i] = roll
+	}
+
+	return rolls, nil
+}
+
+func formatRolls(rolls []int) string {
+	fields := make([]string, len(rolls))
+	for i, roll := range rolls {
+		fields[i] = strconv.Itoa(roll)
+	}
+	return strings.Join(fields, ",")
+}
+
+func verifySingleVRF(game *exportedGame, stdout, stderr io.Writer) int {
+	fmt.Fprintln(stdout, "algorithm:        ecvrf-edwards25519-sha512-tai")
+	fmt.Fprintf(stdout, "public key:       %s\n", game.VRFPublicKey)
+
+	if err := game.checkVRF(); err != nil {
+		return reportFailure(stdout, stderr, "game", err)
+	}
+
+	fmt.Fprintln(stdout, "result:           VALID")
+	return exitValid
+}
+
+func verifyFile(path string, stdout, stderr io.Writer) int {
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to open %s: %v\n", path, err)
+		return exitError
+	}
+	defer f.Close()
+
+	games, err := decodeGames(f)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to read %s: %v\n", path, err)
+		return exitError
+	}
+
+	exitCode := exitValid
+	valid := 0
+
+	for i, game := range games {
+		id := game.GameID
+		if id == "" {
+			id = fmt.Sprintf("#%d", i+1)
+		}
+
+		if err := game.check(); err != nil {
+			if code := reportFailure(stdout, stderr, id, err); code > exitCode {
+				exitCode = code
+			}
+			continue
+		}
+
+		valid++
+	}
+
+	fmt.Fprintf(stdout, "%d of %d games valid\n", valid, len(games))
+	return exitCode
+}
+
+func reportFailure(stdout, stderr io.Writer, id string, err error) int {
+	var mismatch *service.MismatchError
+	if errors.As(err, &mismatch) {
+		fmt.Fprintf(stdout, "MISMATCH %s: %v\n", id, mismatch)
+		return exitMismatch
+	}
+
+	fmt.Fprintf(stderr, "ERROR %s: %v\n", id, err)
+	return exitError
+}
+
+func (g *exportedGame) check() error {
+	if g.isVRF() {
+		return g.checkVRF()
+	}
+
+	if g.ChainTerminalHash != "" {
+		if err := g.checkSeedChain(); err != nil {
+			return err
+		}
+	}
+
+	if g.isBeacon() {
+		return g.checkBeacon()
+	}
+
+	return service.CheckProvablyFair(g.toGameResult(), g.ServerSeed)
+}
+
+func (g *exportedGame) isBeacon() bool {
+	return g.BeaconRound != 0
+}
+
+// checkBeacon verifies a beacon game with the round recorded in the export.
+// The randomness can be compared with the beacon's own record of the round.
+func (g *exportedGame) checkBeacon() error {
+	if g.BeaconRandomness == "" {
+		return fmt.Errorf("beacon randomness is required for beacon games")
+	}
+	if g.ServerSeedCreatedAt.IsZero() {
+		return fmt.Errorf("server seed created_at is required for beacon games")
+	}
+
+	round := &random.BeaconRound{
+		Round:       g.BeaconRound,
+		Randomness:  g.BeaconRandomness,
+		PublishedAt: g.BeaconPublishedAt,
+	}
+
+	return service.CheckBeacon(g.toGameResult(), g.ServerSeed, g.ServerSeedCreatedAt, round)
+}
+
+// checkSeedChain confirms the server seed belongs to the committed chain by
+// hashing it ChainPosition times.
+func (g *exportedGame) checkSeedChain() error {
+	if g.ChainPosition < 1 {
+		return fmt.Errorf("chain position must be positive, got %d", g.ChainPosition)
+	}
+
+	if walked := random.WalkSeedChain(g.ServerSeed, g.ChainPosition); walked != g.ChainTerminalHash {
+		return &service.MismatchError{Field: "terminal_hash", Stored: g.ChainTerminalHash, Computed: walked}
+	}
+
+	return nil
+}
+
+func (g *exportedGame) isVRF() bool {
+	return strings.HasPrefix(g.VerificationKey, "vrf:")
+}
+
+// checkVRF verifies the game's VRF proof against the given public key.
+func (g *exportedGame) checkVRF() error {
+	if g.VRFPublicKey == "" {
+		return fmt.Errorf("vrf public key is required for vrf games")
+	}
+
+	publicKey, err := hex.DecodeString(g.VRFPublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid vrf public key: %w", err)
+	}
+
+	return service.CheckVRF(g.toGameResult(), publicKey)
+}
+
+func (g *exportedGame) toGameResult() *model.GameResult {
+	rounds := make([]model.GameRound, len(g.Rounds))
+	for i, round := range g.Rounds {
+		rounds[i] = model.GameRound{
+			PlayerDice:  round.PlayerDice,
+			ServerDice:  round.ServerDice,
+			PlayerRolls: round.PlayerRolls,
+			ServerRolls: round.ServerRolls,
+		}
+	}
+
+	return &model.GameResult{
+		GameID:           g.GameID,
+		PlayerID:         g.PlayerID,
+		PlayerDice:       g.PlayerDice,
+		ServerDice:       g.ServerDice,
+		Winner:           model.Winner(g.Winner),
+		PlayedAt:         g.PlayedAt,
+		BeaconRound:      g.BeaconRound,
+		Variant:          g.Variant,
+		VariantVersion:   g.VariantVersion,
+		DrawPolicy:       g.DrawPolicy,
+		Rounds:           rounds,
+		Dice:             g.Dice,
+		PlayerRolls:      g.PlayerRolls,
+		ServerRolls:      g.ServerRolls,
+		VerificationKey:  g.VerificationKey,
+		ClientSeed:       g.ClientSeed,
+		Nonce:            g.Nonce,
+		AlgorithmVersion: g.algorithmVersion(),
+	}
+}
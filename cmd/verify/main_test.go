@@ -0,0 +1,398 @@
+package main
+
+import (
+	"bytes"
+	"dice-game/pkg/infrastructure/random"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func playedGame(t *testing.T, gameID string, nonce int64) *exportedGame {
+	t.Helper()
+
+	scheme, err := random.SchemeByVersion(random.LatestSchemeVersion)
+	require.NoError(t, err)
+
+	generator := random.NewProovablyFairGenerator("revealed-seed", scheme)
+	roll, err := generator.Roll(random.RollRequest{PlayerID: "player-1", ClientSeed: "client-seed", Nonce: nonce, Count: 2, Min: 1, Max: 6})
+	require.NoError(t, err)
+
+	return &exportedGame{
+		GameID:          gameID,
+		PlayerID:        "player-1",
+		PlayerDice:      roll.Values[0],
+		ServerDice:      roll.Values[1],
+		VerificationKey: roll.Proof,
+		ClientSeed:      "client-seed",
+		Nonce:           nonce,
+		ServerSeed:      "revealed-seed",
+	}
+}
+
+func writeExport(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestRun_SingleGame(t *testing.T) {
+	game := playedGame(t, "game-1", 3)
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{
+		"-server-seed", game.ServerSeed,
+		"-player-id", game.PlayerID,
+		"-client-seed", game.ClientSeed,
+		"-nonce", "3",
+		"-verification-key", game.VerificationKey,
+		"-player-dice", strconv.Itoa(game.PlayerDice),
+		"-server-dice", strconv.Itoa(game.ServerDice),
+	}, &stdout, &stderr)
+
+	assert.Equal(t, exitValid, code, stderr.String())
+	assert.Contains(t, stdout.String(), "VALID")
+}
+
+func TestRun_SingleGameDiceExpression(t *testing.T) {
+	scheme, err := random.SchemeByVersion(random.LatestSchemeVersion)
+	require.NoError(t, err)
+	generator := random.NewProovablyFairGenerator("revealed-seed", scheme)
+
+	tests := []struct {
+		name  string
+		flags []string
+	}{
+		{name: "Variant dice", flags: []string{"-variant", "sum-of-three"}},
+		{name: "Dice expression", flags: []string{"-dice", "3d6"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			roll, err := generator.Roll(random.RollRequest{PlayerID: "player-1", ClientSeed: "client-seed", Nonce: 5, Count: 6, Min: 1, Max: 6})
+			require.NoError(t, err)
+			player, server := roll.Values[:3], roll.Values[3:]
+			args := append([]string{
+				"-server-seed", "revealed-seed",
+				"-player-id", "player-1",
+				"-client-seed", "client-seed",
+				"-nonce", "5",
+				"-verification-key", roll.Proof,
+				"-player-dice", strconv.Itoa(player[0] + player[1] + player[2]),
+				"-server-dice", strconv.Itoa(server[0] + server[1] + server[2]),
+			}, tt.flags...)
+			var stdout, stderr bytes.Buffer
+
+			// Act
+			code := run(append(args, "-player-rolls", joinRolls(player), "-server-rolls", joinRolls(server)), &stdout, &stderr)
+
+			// Assert
+			assert.Equal(t, exitValid, code, stderr.String())
+			assert.Contains(t, stdout.String(), "values drawn:     "+joinRolls(roll.Values))
+			assert.Contains(t, stdout.String(), "player rolls:     "+joinRolls(player))
+			assert.Contains(t, stdout.String(), "result:           VALID")
+
+			stderr.Reset()
+			code = run(args, &stdout, &stderr)
+			assert.Equal(t, exitError, code)
+			assert.Contains(t, stderr.String(), "-player-rolls and -server-rolls are required")
+		})
+	}
+}
+
+func TestRun_SingleRerolledGame(t *testing.T) {
+	scheme, err := random.SchemeByVersion(random.LatestSchemeVersion)
+	require.NoError(t, err)
+	generator := random.NewProovablyFairGenerator("revealed-seed", scheme)
+
+	// Find a game whose first round is a draw and whose second is not.
+	var (
+		nonce int64
+		roll  *random.Roll
+	)
+	for nonce = 1; ; nonce++ {
+		roll, err = generator.Roll(random.RollRequest{PlayerID: "player-1", ClientSeed: "client-seed", Nonce: nonce, Count: 6, Min: 1, Max: 6})
+		require.NoError(t, err)
+		if roll.Values[0] == roll.Values[1] && roll.Values[2] != roll.Values[3] {
+			break
+		}
+	}
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{
+		"-server-seed", "revealed-seed",
+		"-player-id", "player-1",
+		"-client-seed", "client-seed",
+		"-nonce", strconv.FormatInt(nonce, 10),
+		"-verification-key", roll.Proof,
+		"-draw-policy", "reroll:3",
+		"-player-dice", strconv.Itoa(roll.Values[2]),
+		"-server-dice", strconv.Itoa(roll.Values[3]),
+	}, &stdout, &stderr)
+
+	assert.Equal(t, exitValid, code, stderr.String())
+	assert.Contains(t, stdout.String(), "values drawn:     "+joinRolls(roll.Values))
+	assert.Contains(t, stdout.String(), fmt.Sprintf("round 1:           player %d, server %d", roll.Values[0], roll.Values[1]))
+	assert.Contains(t, stdout.String(), fmt.Sprintf("round 2:           player %d, server %d", roll.Values[2], roll.Values[3]))
+	assert.NotContains(t, stdout.String(), "round 3:")
+	assert.Contains(t, stdout.String(), "result:           VALID")
+}
+
+func joinRolls(rolls []int) string {
+	fields := make([]string, len(rolls))
+	for i, roll := range rolls {
+		fields[i] = strconv.Itoa(roll)
+	}
+	return strings.Join(fields, ",")
+}
+
+func TestRun_SingleGameUnsupportedVersion(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-server-seed", "seed", "-version", "9"}, &stdout, &stderr)
+
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr.String(), "unsupported algorithm version 9")
+}
+
+func TestRun_NDJSONReportsEveryMismatch(t *testing.T) {
+	valid := playedGame(t, "valid", 1)
+	tamperedDice := playedGame(t, "tampered-dice", 2)
+	tamperedDice.PlayerDice = tamperedDice.PlayerDice%6 + 1
+	wrongSeed := playedGame(t, "wrong-seed", 3)
+	wrongSeed.ServerSeed = "other-seed"
+
+	var lines []string
+	for _, game := range []*exportedGame{valid, tamperedDice, wrongSeed} {
+		data, err := json.Marshal(game)
+		require.NoError(t, err)
+		lines = append(lines, string(data))
+	}
+	path := writeExport(t, "games.ndjson", strings.Join(lines, "\n")+"\n")
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-file", path}, &stdout, &stderr)
+
+	assert.Equal(t, exitMismatch, code)
+	assert.Contains(t, stdout.String(), "MISMATCH tampered-dice: player_dice mismatch")
+	assert.Contains(t, stdout.String(), "MISMATCH wrong-seed: server_seed_hash mismatch")
+	assert.NotContains(t, stdout.String(), "MISMATCH valid")
+	assert.Contains(t, stdout.String(), "1 of 3 games valid")
+}
+
+func beaconGame(t *testing.T, gameID string, nonce int64, playedAt time.Time) *exportedGame {
+	t.Helper()
+
+	scheme, err := random.SchemeByVersion(random.LatestSchemeVersion)
+	require.NoError(t, err)
+
+	round := &random.BeaconRound{
+		Round:       1042,
+		Randomness:  "101297f1ca7dc44ef6088d94ad5fb7ba03455dc33d53ddb412bbc4564ed986ec",
+		PublishedAt: playedAt.Add(2 * time.Second),
+	}
+	generator := random.NewBeaconGenerator(random.NewProovablyFairGenerator("revealed-seed", scheme), nil)
+	roll, err := generator.RollWithBeacon("revealed-seed", round, random.RollRequest{PlayerID: "player-1", ClientSeed: "client-seed", Nonce: nonce, Count: 2, Min: 1, Max: 6})
+	require.NoError(t, err)
+
+	return &exportedGame{
+		GameID:            gameID,
+		PlayerID:          "player-1",
+		PlayerDice:        roll.Values[0],
+		ServerDice:        roll.Values[1],
+		VerificationKey:   roll.Proof,
+		ClientSeed:        "client-seed",
+		Nonce:             nonce,
+		ServerSeed:        "revealed-seed",
+		PlayedAt:          playedAt,
+		BeaconRound:       round.Round,
+		BeaconRandomness:  round.Randomness,
+		BeaconPublishedAt: round.PublishedAt,
+		// The seed was committed an hour before the game.
+		ServerSeedCreatedAt: playedAt.Add(-time.Hour),
+	}
+}
+
+func TestRun_Beacon(t *testing.T) {
+	playedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	valid := beaconGame(t, "valid", 1, playedAt)
+	otherRandomness := beaconGame(t, "other-randomness", 2, playedAt)
+	otherRandomness.BeaconRandomness = "a57fcb9a97c8bd2cd5adb4a9b2ecc8ef9a2c4b5d1e8f7a6b5c4d3e2f1a0b9c8d"
+	earlyRound := beaconGame(t, "early-round", 3, playedAt)
+	earlyRound.BeaconPublishedAt = playedAt
+	noRandomness := beaconGame(t, "no-randomness", 4, playedAt)
+	noRandomness.BeaconRandomness = ""
+	lateSeed := beaconGame(t, "late-seed", 5, playedAt)
+	lateSeed.ServerSeedCreatedAt = lateSeed.BeaconPublishedAt
+
+	var lines []string
+	for _, game := range []*exportedGame{valid, otherRandomness, earlyRound, noRandomness, lateSeed} {
+		data, err := json.Marshal(game)
+		require.NoError(t, err)
+		lines = append(lines, string(data))
+	}
+	path := writeExport(t, "games.ndjson", strings.Join(lines, "\n")+"\n")
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-file", path}, &stdout, &stderr)
+
+	assert.Equal(t, exitError, code)
+	assert.NotContains(t, stdout.String(), "MISMATCH valid")
+	assert.Contains(t, stdout.String(), "MISMATCH other-randomness: hash mismatch")
+	assert.Contains(t, stdout.String(), "MISMATCH early-round: beacon_published_at mismatch")
+	assert.Contains(t, stderr.String(), "ERROR no-randomness: beacon randomness is required")
+	assert.Contains(t, stdout.String(), "MISMATCH late-seed: beacon_published_at mismatch: stored after server seed created at")
+	assert.Contains(t, stdout.String(), "1 of 5 games valid")
+}
+
+func TestRun_JSONArray(t *testing.T) {
+	games := []*exportedGame{playedGame(t, "a", 1), playedGame(t, "b", 2)}
+	data, err := json.Marshal(games)
+	require.NoError(t, err)
+	path := writeExport(t, "games.json", "  "+string(data))
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-file", path}, &stdout, &stderr)
+
+	assert.Equal(t, exitValid, code, stderr.String())
+	assert.Contains(t, stdout.String(), "2 of 2 games valid")
+}
+
+func TestRun_RerolledGame(t *testing.T) {
+	scheme, err := random.SchemeByVersion(random.LatestSchemeVersion)
+	require.NoError(t, err)
+	generator := random.NewProovablyFairGenerator("revealed-seed", scheme)
+
+	// Find a game whose first round is a draw and whose second is not.
+	var (
+		nonce int64
+		roll  *random.Roll
+	)
+	for nonce = 1; ; nonce++ {
+		roll, err = generator.Roll(random.RollRequest{ClientSeed: "client-seed", Nonce: nonce, Count: 4, Min: 1, Max: 6})
+		require.NoError(t, err)
+		if roll.Values[0] == roll.Values[1] && roll.Values[2] != roll.Values[3] {
+			break
+		}
+	}
+
+	rerolled := &exportedGame{
+		GameID:     "rerolled",
+		PlayerDice: roll.Values[2],
+		ServerDice: roll.Values[3],
+		DrawPolicy: "reroll:2",
+		Rounds: []exportedRound{
+			{PlayerDice: roll.Values[0], ServerDice: roll.Values[1]},
+			{PlayerDice: roll.Values[2], ServerDice: roll.Values[3]},
+		},
+		VerificationKey: roll.Proof,
+		ClientSeed:      "client-seed",
+		Nonce:           nonce,
+		ServerSeed:      "revealed-seed",
+	}
+	dropped := *rerolled
+	dropped.GameID = "dropped-round"
+	dropped.Rounds = rerolled.Rounds[1:]
+
+	data, err := json.Marshal([]*exportedGame{rerolled, &dropped})
+	require.NoError(t, err)
+	path := writeExport(t, "games.json", string(data))
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-file", path}, &stdout, &stderr)
+
+	assert.Equal(t, exitMismatch, code, stderr.String())
+	assert.Contains(t, stdout.String(), "MISMATCH dropped-round: rounds mismatch: stored 1, recomputed 2")
+	assert.Contains(t, stdout.String(), "1 of 2 games valid")
+}
+
+func TestRun_MalformedFile(t *testing.T) {
+	path := writeExport(t, "games.ndjson", "{not json}\n")
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-file", path}, &stdout, &stderr)
+
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr.String(), "invalid game #1")
+}
+
+func TestRun_SeedChain(t *testing.T) {
+	seeds, terminalHash, err := random.GenerateSeedChain(3)
+	require.NoError(t, err)
+
+	scheme, err := random.SchemeByVersion(random.LatestSchemeVersion)
+	require.NoError(t, err)
+
+	roll, err := random.NewHashChainGenerator(scheme).RollWithServerSeed(seeds[1], random.RollRequest{
+		ClientSeed: "client-seed", Nonce: 1, Count: 2, Min: 1, Max: 6,
+	})
+	require.NoError(t, err)
+
+	args := func(position string) []string {
+		return []string{
+			"-server-seed", seeds[1],
+			"-client-seed", "client-seed",
+			"-nonce", "1",
+			"-verification-key", roll.Proof,
+			"-player-dice", strconv.Itoa(roll.Values[0]),
+			"-server-dice", strconv.Itoa(roll.Values[1]),
+			"-chain-terminal-hash", terminalHash,
+			"-chain-position", position,
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(args("2"), &stdout, &stderr)
+
+	assert.Equal(t, exitValid, code, stderr.String())
+	assert.Contains(t, stdout.String(), "seed chain:       VALID")
+
+	stdout.Reset()
+	code = run(args("3"), &stdout, &stderr)
+
+	assert.Equal(t, exitMismatch, code)
+	assert.Contains(t, stdout.String(), "terminal_hash mismatch")
+}
+
+func TestRun_VRF(t *testing.T) {
+	generator, err := random.NewVRFGenerator([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	roll, err := generator.Roll(random.RollRequest{ClientSeed: "client-seed", Nonce: 4, Count: 2, Min: 1, Max: 6})
+	require.NoError(t, err)
+
+	args := func(clientSeed string) []string {
+		return []string{
+			"-vrf-public-key", hex.EncodeToString(generator.PublicKey()),
+			"-client-seed", clientSeed,
+			"-nonce", "4",
+			"-verification-key", roll.Proof,
+			"-player-dice", strconv.Itoa(roll.Values[0]),
+			"-server-dice", strconv.Itoa(roll.Values[1]),
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(args("client-seed"), &stdout, &stderr)
+
+	assert.Equal(t, exitValid, code, stderr.String())
+	assert.Contains(t, stdout.String(), "result:           VALID")
+
+	stdout.Reset()
+	code = run(args("other-seed"), &stdout, &stderr)
+
+	assert.Equal(t, exitMismatch, code)
+	assert.Contains(t, stdout.String(), "proof mismatch")
+}
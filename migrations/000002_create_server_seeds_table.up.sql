@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS server_seeds (
+    id SERIAL PRIMARY KEY,
+    seed_hash VARCHAR(64) NOT NULL UNIQUE,
+    seed VARCHAR(128) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revealed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_server_seeds_single_active
+    ON server_seeds ((revealed_at IS NULL))
+    WHERE revealed_at IS NULL;
+
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO postgresql;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO postgresql;
@@ -0,0 +1,12 @@
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS client_seed VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS nonce BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS player_nonces (
+    player_id VARCHAR(100) PRIMARY KEY,
+    nonce BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO postgresql;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO postgresql;
@@ -0,0 +1,6 @@
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS algorithm_version INTEGER NOT NULL DEFAULT 0;
+
+UPDATE game_results
+SET algorithm_version = 1
+WHERE generator_used = 'provably_fair' AND algorithm_version = 0;
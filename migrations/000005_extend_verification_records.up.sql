@@ -0,0 +1,6 @@
+ALTER TABLE verification_records
+    ADD COLUMN IF NOT EXISTS requested_by VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS error TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_verification_records_game_id ON verification_records(game_id);
+CREATE INDEX IF NOT EXISTS idx_verification_records_verified_at ON verification_records(verified_at);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS seed_chains (
+    id SERIAL PRIMARY KEY,
+    terminal_hash VARCHAR(64) NOT NULL UNIQUE,
+    length INTEGER NOT NULL CHECK (length > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS seed_chain_links (
+    chain_id INTEGER NOT NULL REFERENCES seed_chains(id),
+    position INTEGER NOT NULL CHECK (position > 0),
+    seed VARCHAR(64) NOT NULL,
+    seed_hash VARCHAR(64) NOT NULL UNIQUE,
+    consumed_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (chain_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_seed_chain_links_unconsumed
+    ON seed_chain_links(chain_id, position) WHERE consumed_at IS NULL;
+
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO postgresql;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO postgresql;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS game_merkle_roots (
+    date DATE PRIMARY KEY,
+    root_hash VARCHAR(64) NOT NULL,
+    leaf_count INTEGER NOT NULL CHECK (leaf_count > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO postgresql;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO postgresql;
@@ -0,0 +1,2 @@
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS beacon_round BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS selection_strategy TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS non_production BOOLEAN NOT NULL DEFAULT FALSE;
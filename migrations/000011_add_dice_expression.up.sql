@@ -0,0 +1,4 @@
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS dice TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS player_rolls INTEGER[],
+    ADD COLUMN IF NOT EXISTS server_rolls INTEGER[];
@@ -0,0 +1,8 @@
+-- Totals of dice expressions are not limited to the faces of a d6, so the
+-- range checks of 000001 only apply to classic games.
+ALTER TABLE game_results
+    DROP CONSTRAINT IF EXISTS game_results_player_dice_check,
+    DROP CONSTRAINT IF EXISTS game_results_server_dice_check,
+    ADD CONSTRAINT game_results_classic_dice_check CHECK (
+        dice <> '' OR (player_dice BETWEEN 1 AND 6 AND server_dice BETWEEN 1 AND 6)
+    );
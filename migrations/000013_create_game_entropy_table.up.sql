@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS game_entropy (
+    game_id VARCHAR(36) PRIMARY KEY REFERENCES game_results(game_id),
+    generator VARCHAR(50) NOT NULL,
+    mapping VARCHAR(32) NOT NULL,
+    value_count INTEGER NOT NULL CHECK (value_count > 0),
+    min_value INTEGER NOT NULL,
+    max_value INTEGER NOT NULL CHECK (max_value >= min_value),
+    data BYTEA NOT NULL,
+    -- key_id names the sealing key data is encrypted with, empty when it is
+    -- stored in the clear.
+    key_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO postgresql;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO postgresql;
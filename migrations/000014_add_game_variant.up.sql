@@ -0,0 +1,12 @@
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS variant VARCHAR(50) NOT NULL DEFAULT 'classic',
+    ADD COLUMN IF NOT EXISTS variant_version INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS payout DOUBLE PRECISION;
+
+-- Games played before variants existed are classic: a win paid twice the
+-- stake and a draw returned it.
+UPDATE game_results
+SET payout = CASE winner WHEN 'PLAYER' THEN 2 WHEN 'DRAW' THEN 1 ELSE 0 END
+WHERE payout IS NULL;
+
+ALTER TABLE game_results ALTER COLUMN payout SET NOT NULL;
@@ -0,0 +1,28 @@
+-- Every roll of a game is a round of its own, so games whose draws are
+-- re-rolled keep every round verifiable. The dice move from game_results
+-- into the rounds.
+CREATE TABLE IF NOT EXISTS game_rounds (
+    game_id VARCHAR(36) NOT NULL REFERENCES game_results(game_id),
+    round INTEGER NOT NULL CHECK (round > 0),
+    player_dice INTEGER NOT NULL,
+    server_dice INTEGER NOT NULL,
+    player_rolls INTEGER[],
+    server_rolls INTEGER[],
+    PRIMARY KEY (game_id, round)
+);
+
+-- Games played before draw policies kept their draws and played one round.
+INSERT INTO game_rounds (game_id, round, player_dice, server_dice, player_rolls, server_rolls)
+SELECT game_id, 1, player_dice, server_dice, player_rolls, server_rolls
+FROM game_results
+ON CONFLICT (game_id, round) DO NOTHING;
+
+ALTER TABLE game_results
+    DROP CONSTRAINT IF EXISTS game_results_classic_dice_check,
+    DROP COLUMN IF EXISTS player_dice,
+    DROP COLUMN IF EXISTS server_dice,
+    DROP COLUMN IF EXISTS player_rolls,
+    DROP COLUMN IF EXISTS server_rolls,
+    ADD COLUMN IF NOT EXISTS draw_policy VARCHAR(32) NOT NULL DEFAULT 'keep';
+
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO postgresql;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS matches (
+    match_id VARCHAR(36) PRIMARY KEY,
+    player_id VARCHAR(100) NOT NULL,
+    best_of INTEGER NOT NULL CHECK (best_of IN (3, 5, 7)),
+    variant VARCHAR(50) NOT NULL,
+    dice TEXT NOT NULL DEFAULT '',
+    generator VARCHAR(50) NOT NULL DEFAULT '',
+    player_wins INTEGER NOT NULL DEFAULT 0,
+    server_wins INTEGER NOT NULL DEFAULT 0,
+    draws INTEGER NOT NULL DEFAULT 0,
+    -- winner is empty while the match is in progress.
+    winner VARCHAR(10) NOT NULL DEFAULT '' CHECK (winner IN ('', 'PLAYER', 'SERVER', 'DRAW')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_matches_player_id ON matches(player_id);
+
+-- The unique round keeps concurrent rounds of a match from both being
+-- played as the same round.
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS match_id VARCHAR(36) REFERENCES matches(match_id),
+    ADD COLUMN IF NOT EXISTS match_round INTEGER,
+    ADD CONSTRAINT game_results_match_round_key UNIQUE (match_id, match_round);
+
+GRANT ALL PRIVILEGES ON ALL TABLES IN SCHEMA public TO postgresql;
@@ -0,0 +1,5 @@
+-- The beacon round's randomness and publish time are kept with the game so
+-- exports can be verified offline without asking the beacon.
+ALTER TABLE game_results
+    ADD COLUMN IF NOT EXISTS beacon_randomness TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS beacon_published_at TIMESTAMP WITH TIME ZONE;
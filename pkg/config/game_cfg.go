@@ -1,6 +1,104 @@
 package config
 
+import "time"
+
 type GameConfig struct {
-	DefaultGeneratorType string `mapstructure:"default_generator_type"`
-	EnableVerification   bool   `mapstructure:"enable_verification"`
+	// GeneratorSelection is the strategy that picks the generator of each
+	// game: random, fixed, weighted, round_robin or sticky.
+	GeneratorSelection string `mapstructure:"generator_selection"`
+	// DefaultGenerator is the generator the fixed strategy always picks.
+	DefaultGenerator string `mapstructure:"default_generator"`
+	// GeneratorWeights are the relative weights of the weighted strategy.
+	// Generators without a weight are never picked.
+	GeneratorWeights map[string]int `mapstructure:"generator_weights"`
+	// PlayerGenerators are the generators players may request in Play.
+	PlayerGenerators []string `mapstructure:"player_generators"`
+	// DiceTables are the dice expressions players may roll instead of a
+	// single d6, such as "2d20kh1".
+	DiceTables []string `mapstructure:"dice_tables"`
+	// DrawPolicies resolve the draws of the variants they are keyed by:
+	// keep, house_wins, push or reroll:N. Other variants keep their draws.
+	DrawPolicies       map[string]string `mapstructure:"draw_policies"`
+	EnableVerification bool              `mapstructure:"enable_verification"`
+	AlgorithmVersion   int               `mapstructure:"algorithm_version"`
+	SeedMode           string            `mapstructure:"seed_mode"`
+	SeedChainLength    int               `mapstructure:"seed_chain_length"`
+	// MerkleSealInterval is how often finished days are sealed under a
+	// Merkle root.
+	MerkleSealInterval time.Duration        `mapstructure:"merkle_seal_interval"`
+	Signing            SigningConfig        `mapstructure:"signing"`
+	VRF                VRFConfig            `mapstructure:"vrf"`
+	Beacon             BeaconConfig         `mapstructure:"beacon"`
+	Health             HealthConfig         `mapstructure:"health"`
+	Replay             ReplayConfig         `mapstructure:"replay"`
+	BufferedCrypto     BufferedCryptoConfig `mapstructure:"buffered_crypto"`
+	Plugins            []PluginConfig       `mapstructure:"plugins"`
+	EntropyAudit       EntropyAuditConfig   `mapstructure:"entropy_audit"`
+}
+
+// SigningConfig configures Ed25519 game receipts. Receipts are not signed
+// when KeyFile is empty.
+type SigningConfig struct {
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// EntropyAuditConfig makes every game store the raw bytes its dice were
+// drawn from. KeyFile holds the AES-256 keys the bytes are sealed with;
+// they are stored in the clear when it is empty.
+type EntropyAuditConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// VRFConfig enables the VRF generator. KeyFile holds the base64 encoded
+// 32-byte secret key seed; the generator is disabled when it is empty.
+type VRFConfig struct {
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// BeaconConfig enables the beacon generator. URL points at a drand-compatible
+// HTTP API; File names a local JSON file of rounds used instead of it in
+// tests and local setups. The generator is disabled when both are empty.
+type BeaconConfig struct {
+	URL  string `mapstructure:"url"`
+	File string `mapstructure:"file"`
+}
+
+// HealthConfig configures the online health tests run over every
+// generator's output. WindowSize is the number of recent values the
+// chi-square and runs tests look at.
+type HealthConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	WindowSize int  `mapstructure:"window_size"`
+}
+
+// ReplayConfig enables replay mode: every game is rolled by the
+// deterministic generator from Seed and the game's sequence number,
+// starting at StartSequence. Replay mode is refused in production.
+type ReplayConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	Seed          string `mapstructure:"seed"`
+	StartSequence int64  `mapstructure:"start_sequence"`
+}
+
+// BufferedCryptoConfig enables the crypto_buffered generator, a faster
+// alternative to crypto for high game rates. Each buffer reseeds from the
+// operating system every ReseedInterval.
+type BufferedCryptoConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	ReseedInterval time.Duration `mapstructure:"reseed_interval"`
+}
+
+// PluginConfig registers an external process as the generator Name. The
+// server talks to it over stdin/stdout, or over Socket when it is set.
+// Zero durations take the generator's defaults.
+type PluginConfig struct {
+	Name           string        `mapstructure:"name"`
+	Command        string        `mapstructure:"command"`
+	Args           []string      `mapstructure:"args"`
+	Env            []string      `mapstructure:"env"`
+	Socket         string        `mapstructure:"socket"`
+	Timeout        time.Duration `mapstructure:"timeout"`
+	StartTimeout   time.Duration `mapstructure:"start_timeout"`
+	HealthInterval time.Duration `mapstructure:"health_interval"`
 }
@@ -3,6 +3,9 @@ package config
 type GRPCConfig struct {
 	Port string `mapstructure:"port"`
 	Host string `mapstructure:"host"`
+	// AdminToken guards the generator admin service, which is disabled
+	// while it is empty. It is kept out of logged configuration.
+	AdminToken string `mapstructure:"admin_token" json:"-"`
 }
 
 func (c *AppConfig) GRPCHost() string {
@@ -0,0 +1,38 @@
+package dice
+
+import "fmt"
+
+// Draws hands out values drawn uniformly from [1, rangeSize] as die faces,
+// so a generator can draw every value of a game in one roll and a verifier
+// can map the recomputed values onto the same dice. A value v becomes
+// (v-1) mod sides + 1, which is uniform whenever sides divides rangeSize;
+// draw from an expression's DrawRange to make sure it does.
+type Draws struct {
+	values    []int
+	rangeSize int
+	next      int
+}
+
+func NewDraws(values []int, rangeSize int) *Draws {
+	return &Draws{values: values, rangeSize: rangeSize}
+}
+
+// Roll returns the next value as the face of a die with the given sides.
+func (d *Draws) Roll(sides int) (int, error) {
+	if sides < 1 || d.rangeSize%sides != 0 {
+		return 0, fmt.Errorf("d%d cannot be drawn from [1, %d]", sides, d.rangeSize)
+	}
+	if d.next == len(d.values) {
+		return 0, fmt.Errorf("all %d drawn values are used", len(d.values))
+	}
+
+	value := d.values[d.next]
+	d.next++
+
+	return (value-1)%sides + 1, nil
+}
+
+// Used is the number of values rolled so far.
+func (d *Draws) Used() int {
+	return d.next
+}
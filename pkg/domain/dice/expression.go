@@ -0,0 +1,464 @@
+// Package dice parses and evaluates dice notation such as "3d6+2",
+// "2d20kh1", "4d6dl1" or "3d6!".
+package dice
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxDice bounds the dice of an expression before explosions.
+	MaxDice = 100
+	// MaxSides bounds the sides of a single die.
+	MaxSides = 1000
+	// MaxExplosions bounds the extra dice an exploding term may roll. A
+	// term that reaches it stops exploding, so the values an expression
+	// needs are known before it is rolled.
+	MaxExplosions = 20
+
+	maxTerms    = 20
+	maxConstant = 1_000_000
+	// maxDrawRange is the largest range a provably fair stream can draw
+	// from without bias.
+	maxDrawRange = 1 << 31
+)
+
+// Expression is a parsed dice expression: a sum of dice terms and
+// constants. The grammar, case-insensitive and ignoring spaces, is
+//
+//	expression = [sign] term { sign term }
+//	term       = number | [number] "d" (number | "%") ["!"] [keep]
+//	keep       = ("kh" | "kl" | "k" | "dh" | "dl") [number]
+//
+// "d%" is a d100. "!" explodes a die that shows its highest face into an
+// extra die of the same term. kh/k and kl keep the highest or lowest n dice
+// of the term, dh and dl drop them; n defaults to 1. Keeping and dropping
+// apply after explosions.
+type Expression struct {
+	terms []term
+}
+
+type term struct {
+	negative bool
+	// constant is used when dice is nil.
+	constant int
+	dice     *diceTerm
+}
+
+type keepMode int
+
+const (
+	keepAll keepMode = iota
+	keepHighest
+	keepLowest
+	dropHighest
+	dropLowest
+)
+
+var keepNotation = map[keepMode]string{
+	keepHighest: "kh",
+	keepLowest:  "kl",
+	dropHighest: "dh",
+	dropLowest:  "dl",
+}
+
+type diceTerm struct {
+	count   int
+	sides   int
+	explode bool
+	keep    keepMode
+	keepN   int
+}
+
+// Die is a single die of a rolled expression.
+type Die struct {
+	Sides int
+	Value int
+	// Exploded marks dice rolled because the die before them in the same
+	// term showed its highest face.
+	Exploded bool
+	// Dropped dice do not count towards the total.
+	Dropped bool
+}
+
+// Result is a rolled expression: every die in the order it was rolled,
+// including dropped ones, and the total.
+type Result struct {
+	Dice  []Die
+	Total int
+}
+
+// Values returns the face of every die in the order they were rolled.
+func (r *Result) Values() []int {
+	values := make([]int, len(r.Dice))
+	for i, die := range r.Dice {
+		values[i] = die.Value
+	}
+	return values
+}
+
+// Parse parses s. Expressions must roll at least one die.
+func Parse(s string) (*Expression, error) {
+	p := &parser{src: strings.ToLower(strings.ReplaceAll(s, " ", ""))}
+
+	expr, err := p.expression()
+	if err != nil {
+		return nil, fmt.Errorf("invalid dice expression %q: %w", s, err)
+	}
+
+	return expr, nil
+}
+
+// String returns the expression in normalized notation, so equal
+// expressions compare equal as strings.
+func (e *Expression) String() string {
+	var b strings.Builder
+	for i, t := range e.terms {
+		switch {
+		case t.negative:
+			b.WriteByte('-')
+		case i > 0:
+			b.WriteByte('+')
+		}
+
+		if t.dice == nil {
+			b.WriteString(strconv.Itoa(t.constant))
+			continue
+		}
+
+		d := t.dice
+		fmt.Fprintf(&b, "%dd%d", d.count, d.sides)
+		if d.explode {
+			b.WriteByte('!')
+		}
+		if d.keep != keepAll {
+			fmt.Fprintf(&b, "%s%d", keepNotation[d.keep], d.keepN)
+		}
+	}
+
+	return b.String()
+}
+
+// DrawRange is the least common multiple of the expression's die sides.
+// Values drawn uniformly from [1, DrawRange] map onto every die of the
+// expression without bias; see Draws.
+func (e *Expression) DrawRange() int {
+	lcm := 1
+	for _, t := range e.terms {
+		if t.dice != nil {
+			lcm = lcm / gcd(lcm, t.dice.sides) * t.dice.sides
+		}
+	}
+	return lcm
+}
+
+// MaxDraws is the largest number of dice one evaluation may roll.
+func (e *Expression) MaxDraws() int {
+	draws := 0
+	for _, t := range e.terms {
+		if t.dice == nil {
+			continue
+		}
+		draws += t.dice.count
+		if t.dice.explode {
+			draws += MaxExplosions
+		}
+	}
+	return draws
+}
+
+// Evaluate rolls the expression, taking the face of every die from roll.
+func (e *Expression) Evaluate(roll func(sides int) (int, error)) (*Result, error) {
+	result := &Result{}
+
+	for _, t := range e.terms {
+		sign := 1
+		if t.negative {
+			sign = -1
+		}
+
+		if t.dice == nil {
+			result.Total += sign * t.constant
+			continue
+		}
+
+		dice, err := t.dice.roll(roll)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, die := range dice {
+			if !die.Dropped {
+				result.Total += sign * die.Value
+			}
+		}
+		result.Dice = append(result.Dice, dice...)
+	}
+
+	return result, nil
+}
+
+func (d *diceTerm) roll(roll func(sides int) (int, error)) ([]Die, error) {
+	dice := make([]Die, 0, d.count)
+	explosions := 0
+
+	for i := 0; i < d.count; i++ {
+		exploded := false
+		for {
+			value, err := roll(d.sides)
+			if err != nil {
+				return nil, err
+			}
+			if value < 1 || value > d.sides {
+				return nil, fmt.Errorf("d%d rolled %d", d.sides, value)
+			}
+			dice = append(dice, Die{Sides: d.sides, Value: value, Exploded: exploded})
+
+			if !d.explode || value != d.sides || explosions == MaxExplosions {
+				break
+			}
+			explosions++
+			exploded = true
+		}
+	}
+
+	d.drop(dice)
+	return dice, nil
+}
+
+// drop marks the dice keep and drop modifiers discard. Among equal faces
+// the earlier die is dropped first.
+func (d *diceTerm) drop(dice []Die) {
+	var (
+		n       int
+		highest bool
+	)
+	switch d.keep {
+	case keepAll:
+		return
+	case keepHighest:
+		n, highest = len(dice)-d.keepN, false
+	case keepLowest:
+		n, highest = len(dice)-d.keepN, true
+	case dropHighest:
+		n, highest = d.keepN, true
+	case dropLowest:
+		n, highest = d.keepN, false
+	}
+
+	order := make([]int, len(dice))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if highest {
+			return dice[order[a]].Value > dice[order[b]].Value
+		}
+		return dice[order[a]].Value < dice[order[b]].Value
+	})
+
+	for _, i := range order[:max(n, 0)] {
+		dice[i].Dropped = true
+	}
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) expression() (*Expression, error) {
+	if p.src == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	expr := &Expression{}
+	dice := 0
+	for p.pos < len(p.src) {
+		negative := false
+		switch p.peek() {
+		case '+':
+			p.pos++
+		case '-':
+			negative = true
+			p.pos++
+		default:
+			if len(expr.terms) > 0 {
+				return nil, fmt.Errorf("expected + or - at position %d", p.pos+1)
+			}
+		}
+
+		t, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		t.negative = negative
+		expr.terms = append(expr.terms, t)
+
+		if t.dice != nil {
+			dice += t.dice.count
+		}
+	}
+
+	switch {
+	case len(expr.terms) > maxTerms:
+		return nil, fmt.Errorf("more than %d terms", maxTerms)
+	case dice == 0:
+		return nil, fmt.Errorf("no dice to roll")
+	case dice > MaxDice:
+		return nil, fmt.Errorf("more than %d dice", MaxDice)
+	}
+
+	if lcm := expr.drawRange(); lcm > maxDrawRange {
+		return nil, fmt.Errorf("die sides have a least common multiple above %d", maxDrawRange)
+	}
+
+	return expr, nil
+}
+
+// drawRange is DrawRange computed without overflow, for validation.
+func (e *Expression) drawRange() uint64 {
+	lcm := uint64(1)
+	for _, t := range e.terms {
+		if t.dice == nil {
+			continue
+		}
+		sides := uint64(t.dice.sides)
+		lcm = lcm / uint64(gcd(int(lcm%sides), t.dice.sides)) * sides
+		if lcm > maxDrawRange {
+			return lcm
+		}
+	}
+	return lcm
+}
+
+func (p *parser) term() (term, error) {
+	count, hasCount, err := p.number()
+	if err != nil {
+		return term{}, err
+	}
+
+	if p.peek() != 'd' {
+		if !hasCount {
+			return term{}, fmt.Errorf("expected a number or dice at position %d", p.pos+1)
+		}
+		if count > maxConstant {
+			return term{}, fmt.Errorf("constant %d is above %d", count, maxConstant)
+		}
+		return term{constant: count}, nil
+	}
+	p.pos++
+
+	if !hasCount {
+		count = 1
+	}
+	if count < 1 || count > MaxDice {
+		return term{}, fmt.Errorf("dice count must be between 1 and %d", MaxDice)
+	}
+
+	d := &diceTerm{count: count}
+	if p.peek() == '%' {
+		p.pos++
+		d.sides = 100
+	} else {
+		sides, ok, err := p.number()
+		if err != nil {
+			return term{}, err
+		}
+		if !ok {
+			return term{}, fmt.Errorf("expected die sides at position %d", p.pos+1)
+		}
+		d.sides = sides
+	}
+	if d.sides < 1 || d.sides > MaxSides {
+		return term{}, fmt.Errorf("die sides must be between 1 and %d", MaxSides)
+	}
+
+	if p.peek() == '!' {
+		p.pos++
+		if d.sides == 1 {
+			return term{}, fmt.Errorf("a d1 cannot explode")
+		}
+		d.explode = true
+	}
+
+	if err := p.keep(d); err != nil {
+		return term{}, err
+	}
+
+	return term{dice: d}, nil
+}
+
+func (p *parser) keep(d *diceTerm) error {
+	rest := p.src[p.pos:]
+	switch {
+	case strings.HasPrefix(rest, "kh"):
+		d.keep, p.pos = keepHighest, p.pos+2
+	case strings.HasPrefix(rest, "kl"):
+		d.keep, p.pos = keepLowest, p.pos+2
+	case strings.HasPrefix(rest, "k"):
+		d.keep, p.pos = keepHighest, p.pos+1
+	case strings.HasPrefix(rest, "dh"):
+		d.keep, p.pos = dropHighest, p.pos+2
+	case strings.HasPrefix(rest, "dl"):
+		d.keep, p.pos = dropLowest, p.pos+2
+	default:
+		return nil
+	}
+
+	n, ok, err := p.number()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		n = 1
+	}
+
+	switch d.keep {
+	case keepHighest, keepLowest:
+		if n < 1 || n > d.count {
+			return fmt.Errorf("can keep between 1 and %d of %dd%d", d.count, d.count, d.sides)
+		}
+	default:
+		if n < 1 || n >= d.count {
+			return fmt.Errorf("can drop between 1 and %d of %dd%d", d.count-1, d.count, d.sides)
+		}
+	}
+	d.keepN = n
+
+	return nil
+}
+
+// number reads a decimal number if there is one.
+func (p *parser) number() (int, bool, error) {
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, false, nil
+	}
+	if p.pos-start > 7 {
+		return 0, false, fmt.Errorf("number at position %d is too large", start+1)
+	}
+
+	n, err := strconv.Atoi(p.src[start:p.pos])
+	return n, true, err
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,255 @@
+package dice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequence returns a roll function that hands out faces in order.
+func sequence(faces ...int) func(sides int) (int, error) {
+	next := 0
+	return func(int) (int, error) {
+		if next == len(faces) {
+			return 0, errors.New("out of faces")
+		}
+		next++
+		return faces[next-1], nil
+	}
+}
+
+func TestParse_Normalizes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"d6", "1d6"},
+		{"3d6+2", "3d6+2"},
+		{"3D6 + 2", "3d6+2"},
+		{"2d20kh1", "2d20kh1"},
+		{"2d20k", "2d20kh1"},
+		{"2d20kl", "2d20kl1"},
+		{"4d6dl1", "4d6dl1"},
+		{"4d6dh", "4d6dh1"},
+		{"3d6!", "3d6!"},
+		{"4d6!kh3", "4d6!kh3"},
+		{"d%", "1d100"},
+		{"1d20+1d4-1", "1d20+1d4-1"},
+		{"-1+2d8", "-1+2d8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// Act
+			expr, err := Parse(tt.input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expr.String())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"5",
+		"d",
+		"3d",
+		"0d6",
+		"101d6",
+		"1d0",
+		"1d1001",
+		"1d1!",
+		"2d6kh3",
+		"2d6dl2",
+		"2d6x",
+		"2d6++1",
+		"1d6+1d7+1d11+1d13+1d17+1d19+1d23+1d29+1d31",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			// Act
+			_, err := Parse(input)
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestExpression_Evaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		faces    []int
+		total    int
+		dropped  []bool
+		exploded []bool
+	}{
+		{
+			name:    "Sum with modifier",
+			input:   "3d6+2",
+			faces:   []int{1, 4, 6},
+			total:   13,
+			dropped: []bool{false, false, false},
+		},
+		{
+			name:    "Advantage keeps the highest",
+			input:   "2d20kh1",
+			faces:   []int{7, 15},
+			total:   15,
+			dropped: []bool{true, false},
+		},
+		{
+			name:    "Disadvantage keeps the lowest",
+			input:   "2d20kl1",
+			faces:   []int{7, 15},
+			total:   7,
+			dropped: []bool{false, true},
+		},
+		{
+			name:    "Drop lowest drops the earlier of equal dice",
+			input:   "4d6dl1",
+			faces:   []int{3, 5, 3, 6},
+			total:   14,
+			dropped: []bool{true, false, false, false},
+		},
+		{
+			name:     "Exploding dice roll again on the highest face",
+			input:    "2d6!",
+			faces:    []int{6, 6, 2, 3},
+			total:    17,
+			dropped:  []bool{false, false, false, false},
+			exploded: []bool{false, true, true, false},
+		},
+		{
+			name:    "Subtracted dice",
+			input:   "1d8-1d4",
+			faces:   []int{2, 4},
+			total:   -2,
+			dropped: []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			expr, err := Parse(tt.input)
+			require.NoError(t, err)
+
+			// Act
+			result, err := expr.Evaluate(sequence(tt.faces...))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.total, result.Total)
+			assert.Equal(t, tt.faces, result.Values())
+			for i, die := range result.Dice {
+				assert.Equal(t, tt.dropped[i], die.Dropped, "die %d dropped", i)
+				if tt.exploded != nil {
+					assert.Equal(t, tt.exploded[i], die.Exploded, "die %d exploded", i)
+				}
+			}
+		})
+	}
+}
+
+func TestExpression_ExplosionsAreCapped(t *testing.T) {
+	// Arrange
+	expr, err := Parse("1d6!")
+	require.NoError(t, err)
+
+	faces := make([]int, expr.MaxDraws()+5)
+	for i := range faces {
+		faces[i] = 6
+	}
+
+	// Act
+	result, err := expr.Evaluate(sequence(faces...))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, result.Dice, 1+MaxExplosions)
+	assert.Equal(t, 6*(1+MaxExplosions), result.Total)
+}
+
+func TestExpression_DrawRangeAndMaxDraws(t *testing.T) {
+	// Arrange
+	expr, err := Parse("2d20kh1+1d6!+1d4+3")
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.Equal(t, 60, expr.DrawRange())
+	assert.Equal(t, 2+1+MaxExplosions+1, expr.MaxDraws())
+}
+
+func TestExpression_RejectsFaceOutOfRange(t *testing.T) {
+	// Arrange
+	expr, err := Parse("1d6")
+	require.NoError(t, err)
+
+	// Act
+	_, err = expr.Evaluate(func(int) (int, error) { return 7, nil })
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestDraws_Roll(t *testing.T) {
+	// Arrange
+	draws := NewDraws([]int{1, 12, 7, 60}, 60)
+
+	// Act
+	d6, _ := draws.Roll(6)
+	d4, _ := draws.Roll(4)
+	d20, _ := draws.Roll(20)
+	d12, _ := draws.Roll(12)
+	_, exhaustedErr := draws.Roll(6)
+
+	// Assert
+	assert.Equal(t, 1, d6)
+	assert.Equal(t, 4, d4)
+	assert.Equal(t, 7, d20)
+	assert.Equal(t, 12, d12)
+	assert.Error(t, exhaustedErr)
+	assert.Equal(t, 4, draws.Used())
+}
+
+func TestDraws_RejectsSidesNotDividingRange(t *testing.T) {
+	// Arrange
+	draws := NewDraws([]int{1}, 6)
+
+	// Act
+	_, err := draws.Roll(4)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestDraws_Uniform(t *testing.T) {
+	// Arrange: every value of the range once maps onto every face equally
+	// often.
+	values := make([]int, 60)
+	for i := range values {
+		values[i] = i + 1
+	}
+	draws := NewDraws(values, 60)
+
+	// Act
+	counts := make(map[int]int)
+	for range values {
+		face, err := draws.Roll(20)
+		require.NoError(t, err)
+		counts[face]++
+	}
+
+	// Assert
+	assert.Len(t, counts, 20)
+	for face, count := range counts {
+		assert.Equal(t, 3, count, "face %d", face)
+	}
+}
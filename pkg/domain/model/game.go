@@ -11,12 +11,67 @@ const (
 )
 
 type GameResult struct {
-	GameID          string
-	PlayerID        string
-	PlayerDice      int
-	ServerDice      int
-	Winner          Winner
-	PlayedAt        time.Time
-	GeneratorUsed   string
-	VerificationKey string
+	GameID           string
+	PlayerID         string
+	PlayerDice       int
+	ServerDice       int
+	Winner           Winner
+	PlayedAt         time.Time
+	GeneratorUsed    string
+	VerificationKey  string
+	ClientSeed       string
+	Nonce            int64
+	AlgorithmVersion int
+	// BeaconRound is the randomness beacon round mixed into the roll, or 0
+	// when the generator does not use a beacon. BeaconRandomness and
+	// BeaconPublishedAt are that round's randomness and publish time.
+	BeaconRound       int64
+	BeaconRandomness  string
+	BeaconPublishedAt time.Time
+	// SelectionStrategy names the strategy that picked GeneratorUsed.
+	SelectionStrategy string
+	// NonProduction marks games played with a generator whose outcomes can
+	// be reproduced from a known seed, such as in replay mode.
+	NonProduction bool
+	// Dice is the normalized dice expression both sides rolled, or empty
+	// for classic games of one d6 each. PlayerDice and ServerDice are the
+	// totals of the expression; PlayerRolls and ServerRolls hold the face
+	// of every die, including dropped and exploded ones, in the order they
+	// were rolled, and are only set for games with Dice.
+	Dice        string
+	PlayerRolls []int
+	ServerRolls []int
+	// Variant and VariantVersion name the rules the game was played and is
+	// verified with. Payout is the multiple of the player's stake the
+	// variant returned to them.
+	Variant        string
+	VariantVersion int
+	Payout         float64
+	// DrawPolicy is how the game resolved drawn rounds, such as keep or
+	// reroll:3. Rounds holds every round played in order; the dice fields
+	// above repeat the last one, which decided the game.
+	DrawPolicy string
+	Rounds     []GameRound
+	// MatchID and MatchRound place the game in a match, counting rounds
+	// from 1; they are empty for games played on their own.
+	MatchID    string
+	MatchRound int
+}
+
+// GameRound is one roll of both sides. Games only play more than one round
+// when their draw policy re-rolls draws; every round but the last is a
+// draw.
+type GameRound struct {
+	PlayerDice  int
+	ServerDice  int
+	PlayerRolls []int
+	ServerRolls []int
+}
+
+// GameVariant is a set of game rules players may pick in Play, with the
+// policy its draws are resolved by.
+type GameVariant struct {
+	Name       string
+	Version    int
+	DrawPolicy string
 }
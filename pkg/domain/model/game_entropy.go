@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// GameEntropy is the raw randomness a game's dice were drawn from. Data is
+// sealed with the key KeyID, or plain when KeyID is empty, and maps onto
+// the Count values in [Min, Max] that both sides rolled, player first,
+// under Mapping.
+type GameEntropy struct {
+	GameID    string
+	Generator string
+	Mapping   string
+	Count     int
+	Min       int
+	Max       int
+	Data      []byte
+	KeyID     string
+	CreatedAt time.Time
+}
+
+// EntropyAudit is the outcome of replaying a game's stored entropy. Values
+// are the dice the entropy maps onto; Valid reports whether they are the
+// dice stored with the game, and Reason explains when they are not.
+type EntropyAudit struct {
+	Entropy *GameEntropy
+	Values  []int
+	Valid   bool
+	Reason  string
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+type HealthStatus string
+
+const (
+	// HealthWarmingUp means the window is not full yet; only the repetition
+	// count test has run.
+	HealthWarmingUp   HealthStatus = "warming_up"
+	HealthHealthy     HealthStatus = "healthy"
+	HealthQuarantined HealthStatus = "quarantined"
+)
+
+// GeneratorHealth is the state of a generator's online health tests over
+// its last Samples output values drawn from [Min, Max]. ChiSquareCritical
+// is 0 when the window is too small for the chi-square test over the range.
+type GeneratorHealth struct {
+	Generator         string
+	Min               int
+	Max               int
+	Status            HealthStatus
+	Samples           int
+	WindowSize        int
+	ChiSquare         float64
+	ChiSquareCritical float64
+	RunsZ             float64
+	RunsCritical      float64
+	Repetition        int
+	RepetitionCutoff  int
+	// Reason names the failed test of a quarantined generator.
+	Reason        string
+	QuarantinedAt *time.Time
+}
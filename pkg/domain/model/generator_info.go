@@ -0,0 +1,30 @@
+package model
+
+type GeneratorState string
+
+const (
+	GeneratorEnabled GeneratorState = "enabled"
+	// GeneratorDisabled generators are registered, so their games can still
+	// be verified, but they are not picked for new games.
+	GeneratorDisabled GeneratorState = "disabled"
+	// GeneratorDraining generators are not picked for new games and become
+	// disabled once their games in flight finish.
+	GeneratorDraining GeneratorState = "draining"
+)
+
+// GeneratorInfo describes a registered generator to players.
+type GeneratorInfo struct {
+	Name       string
+	Verifiable bool
+	// Algorithm and AlgorithmVersion are only set for verifiable generators;
+	// AlgorithmVersion is 0 outside the versioned provably fair schemes.
+	Algorithm        string
+	AlgorithmVersion int
+	// Selectable reports whether players may request the generator in Play.
+	Selectable  bool
+	Quarantined bool
+	State       GeneratorState
+	// InFlight is the number of games currently being played with the
+	// generator.
+	InFlight int
+}
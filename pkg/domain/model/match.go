@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Match is a best-of-BestOf series of games against the server. Every game
+// is played with the match's variant, dice and requested generator, and
+// Games holds them in the order they were played.
+type Match struct {
+	MatchID   string
+	PlayerID  string
+	BestOf    int
+	Variant   string
+	Dice      string
+	Generator string
+	// PlayerWins, ServerWins and Draws tally the games. Winner is empty
+	// while the match is in progress and DRAW for a match that ended
+	// level.
+	PlayerWins int
+	ServerWins int
+	Draws      int
+	Winner     Winner
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+	Games      []*GameResult
+}
+
+func (m *Match) IsFinished() bool {
+	return m.Winner != ""
+}
+
+// MatchStats summarizes a player's matches. Played counts finished matches
+// and Games every game played in any match.
+type MatchStats struct {
+	PlayerID   string
+	Played     int
+	Won        int
+	Lost       int
+	Drawn      int
+	InProgress int
+	Games      int
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// DailyMerkleRoot seals the game_results rows of one UTC day. Once stored it
+// is never rewritten, so any later edit to the day's games changes the
+// recomputed root.
+type DailyMerkleRoot struct {
+	Date      time.Time
+	RootHash  string
+	LeafCount int
+	CreatedAt time.Time
+}
+
+// InclusionProof shows that a game is leaf LeafIndex of its day's Merkle
+// tree. LeafData is the canonical serialization the leaf hash is taken over.
+type InclusionProof struct {
+	GameID    string
+	Date      time.Time
+	LeafIndex int
+	LeafCount int
+	LeafData  string
+	LeafHash  string
+	RootHash  string
+	Steps     []ProofStep
+}
+
+// ProofStep is a sibling hash on the path to the root. Left is true when the
+// sibling is hashed on the left.
+type ProofStep struct {
+	Hash string
+	Left bool
+}
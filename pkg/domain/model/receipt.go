@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// GameReceipt is the server's signed statement of a game result. Signature
+// is a detached Ed25519 signature over Payload made with key KeyID.
+type GameReceipt struct {
+	GameID    string
+	Payload   string
+	KeyID     string
+	Signature []byte
+}
+
+// SigningKey is a published receipt verification key. Retired keys no
+// longer sign but their receipts stay valid.
+type SigningKey struct {
+	ID        string
+	PublicKey []byte
+	Current   bool
+	RetiredAt *time.Time
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// SeedChain is a pre-generated reverse hash chain of server seeds. Only
+// TerminalHash is published when the chain is created; the seeds are
+// revealed one by one as games consume them.
+type SeedChain struct {
+	ID           int64
+	TerminalHash string
+	Length       int
+	Consumed     int
+	CreatedAt    time.Time
+}
+
+// SeedChainLink is one server seed of a SeedChain. Position counts from 1 in
+// consumption order, so hashing Seed Position times yields the terminal hash.
+type SeedChainLink struct {
+	ChainID    int64
+	Position   int
+	Seed       string
+	Hash       string
+	ConsumedAt *time.Time
+}
+
+func (l *SeedChainLink) IsConsumed() bool {
+	return l.ConsumedAt != nil
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// ServerSeed is a provably fair server seed together with its public
+// SHA-256 commitment. The seed itself stays secret until it is revealed.
+type ServerSeed struct {
+	Seed       string
+	Hash       string
+	CreatedAt  time.Time
+	RevealedAt *time.Time
+}
+
+func (s *ServerSeed) IsRevealed() bool {
+	return s.RevealedAt != nil
+}
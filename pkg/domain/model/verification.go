@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// VerificationRecord is the audit entry written for every Verify call: who
+// asked about which game, what they submitted and what they were told.
+type VerificationRecord struct {
+	ID               int64
+	GameID           string
+	PlayerID         string
+	RequestedBy      string
+	VerificationData string
+	IsValid          bool
+	// Error is the message returned instead of a verdict, empty when the
+	// verification completed.
+	Error      string
+	VerifiedAt time.Time
+}
@@ -3,8 +3,14 @@ package repository
 import (
 	"context"
 	"dice-game/pkg/domain/model"
+	"errors"
+	"time"
 )
 
+// ErrGameNotFound is returned by GameRepository.GetGameResult for unknown
+// game IDs.
+var ErrGameNotFound = errors.New("game not found")
+
 type DataStore interface {
 	Connect(ctx context.Context) error
 	Close(ctx context.Context) error
@@ -12,6 +18,12 @@ type DataStore interface {
 	RunMigrations(migrationsPath string) error
 	WithTransaction(ctx context.Context, txFunc func(tx Transaction) error) error
 	GetGameRepository() GameRepository
+	GetSeedRepository() SeedRepository
+	GetVerificationRepository() VerificationRepository
+	GetSeedChainRepository() SeedChainRepository
+	GetMerkleRootRepository() MerkleRootRepository
+	GetEntropyRepository() EntropyRepository
+	GetMatchRepository() MatchRepository
 }
 
 type Transaction interface {
@@ -24,4 +36,72 @@ type GameRepository interface {
 	GetGameResult(ctx context.Context, gameID string) (*model.GameResult, error)
 	GetGameResultsByPlayer(ctx context.Context, playerID string, limit, offset int) ([]*model.GameResult, error)
 	GetTotalGames(ctx context.Context) (int, error)
+	// GetGameResultsByDay returns every game played on the UTC day containing
+	// day, ordered by played_at and game_id.
+	GetGameResultsByDay(ctx context.Context, day time.Time) ([]*model.GameResult, error)
+	// NextNonce atomically increments and returns the player's game nonce.
+	NextNonce(ctx context.Context, playerID string) (int64, error)
+}
+
+type SeedRepository interface {
+	SaveServerSeed(ctx context.Context, seed *model.ServerSeed) error
+	GetServerSeed(ctx context.Context, seedHash string) (*model.ServerSeed, error)
+	// GetActiveServerSeed returns the unrevealed seed or nil when none exists.
+	GetActiveServerSeed(ctx context.Context) (*model.ServerSeed, error)
+	// RotateServerSeed reveals the seed with the given hash and stores the next
+	// one in a single transaction.
+	RotateServerSeed(ctx context.Context, revealHash string, revealedAt time.Time, next *model.ServerSeed) error
+}
+
+type VerificationRepository interface {
+	SaveVerificationRecord(ctx context.Context, record *model.VerificationRecord) error
+	GetVerificationRecordsByGame(ctx context.Context, gameID string, limit, offset int) ([]*model.VerificationRecord, error)
+	// GetVerificationRecordsByPlayer returns the checks made against any game
+	// the player played, regardless of who requested them.
+	GetVerificationRecordsByPlayer(ctx context.Context, playerID string, limit, offset int) ([]*model.VerificationRecord, error)
+}
+
+type SeedChainRepository interface {
+	// SaveSeedChain stores the chain and all of its links in one transaction
+	// and sets chain.ID.
+	SaveSeedChain(ctx context.Context, chain *model.SeedChain, links []*model.SeedChainLink) error
+	GetSeedChain(ctx context.Context, chainID int64) (*model.SeedChain, error)
+	// GetActiveSeedChain returns the oldest chain with unconsumed links or nil
+	// when every chain is exhausted.
+	GetActiveSeedChain(ctx context.Context) (*model.SeedChain, error)
+	// ConsumeNextLink marks the next unconsumed link as used and returns it,
+	// or nil when every chain is exhausted.
+	ConsumeNextLink(ctx context.Context, consumedAt time.Time) (*model.SeedChainLink, error)
+	GetLinkByHash(ctx context.Context, seedHash string) (*model.SeedChainLink, error)
+}
+
+type MerkleRootRepository interface {
+	// SaveDailyRoot stores the root of a day and fails if the day already
+	// has one.
+	SaveDailyRoot(ctx context.Context, root *model.DailyMerkleRoot) error
+	// GetDailyRoot returns the root of the UTC day containing day or nil when
+	// the day has not been sealed.
+	GetDailyRoot(ctx context.Context, day time.Time) (*model.DailyMerkleRoot, error)
+	// GetUnsealedDays returns the UTC days before the given time that have
+	// games but no root, oldest first.
+	GetUnsealedDays(ctx context.Context, before time.Time) ([]time.Time, error)
+}
+
+type EntropyRepository interface {
+	// SaveGameResultWithEntropy stores the game and its raw entropy in one
+	// transaction.
+	SaveGameResultWithEntropy(ctx context.Context, result *model.GameResult, entropy *model.GameEntropy) error
+	// GetGameEntropy returns the entropy recorded for the game or nil when
+	// none was.
+	GetGameEntropy(ctx context.Context, gameID string) (*model.GameEntropy, error)
+}
+
+type MatchRepository interface {
+	SaveMatch(ctx context.Context, match *model.Match) error
+	// GetMatch returns the match with its games ordered by round, or nil
+	// when there is no such match.
+	GetMatch(ctx context.Context, matchID string) (*model.Match, error)
+	// UpdateMatch stores the tallies, winner and finish time of a match.
+	UpdateMatch(ctx context.Context, match *model.Match) error
+	GetMatchStats(ctx context.Context, playerID string) (*model.MatchStats, error)
 }
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"dice-game/pkg/domain/dice"
+	"dice-game/pkg/domain/model"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DrawMode is how a drawn round is resolved.
+type DrawMode string
+
+const (
+	// DrawKeep lets the draw stand and pays what the variant pays for it.
+	DrawKeep DrawMode = "keep"
+	// DrawReroll has both sides roll again until a round is decided or the
+	// policy's rounds run out, after which the draw stands.
+	DrawReroll DrawMode = "reroll"
+	// DrawHouseWins gives drawn games to the server.
+	DrawHouseWins DrawMode = "house_wins"
+	// DrawPush returns the stake on a draw whatever the variant pays.
+	DrawPush DrawMode = "push"
+)
+
+// MaxDrawRounds caps the rounds a re-rolling policy may play. Every round
+// is drawn up front, so it bounds the values a game draws too.
+const MaxDrawRounds = 10
+
+// DrawPolicy decides games whose round ends in a draw. The zero value keeps
+// draws.
+type DrawPolicy struct {
+	Mode DrawMode
+	// MaxRounds is the number of rounds, the first one included, a
+	// re-rolling policy plays at most.
+	MaxRounds int
+}
+
+// KeepDraws is the policy of games played before draw policies existed.
+var KeepDraws = DrawPolicy{Mode: DrawKeep}
+
+// ParseDrawPolicy reads a policy in the notation String writes: keep,
+// house_wins, push or reroll:N. An empty string keeps draws.
+func ParseDrawPolicy(s string) (DrawPolicy, error) {
+	mode, rounds, hasRounds := strings.Cut(strings.TrimSpace(s), ":")
+
+	switch DrawMode(mode) {
+	case "", DrawKeep, DrawHouseWins, DrawPush:
+		if hasRounds {
+			return DrawPolicy{}, fmt.Errorf("draw policy %q takes no rounds", s)
+		}
+		if mode == "" {
+			return KeepDraws, nil
+		}
+		return DrawPolicy{Mode: DrawMode(mode)}, nil
+	case DrawReroll:
+		n, err := strconv.Atoi(rounds)
+		if err != nil || n < 2 || n > MaxDrawRounds {
+			return DrawPolicy{}, fmt.Errorf("draw policy %q needs between 2 and %d rounds, such as reroll:3", s, MaxDrawRounds)
+		}
+		return DrawPolicy{Mode: DrawReroll, MaxRounds: n}, nil
+	default:
+		return DrawPolicy{}, fmt.Errorf("unknown draw policy %q", s)
+	}
+}
+
+func (p DrawPolicy) String() string {
+	switch p.Mode {
+	case "":
+		return string(DrawKeep)
+	case DrawReroll:
+		return fmt.Sprintf("%s:%d", p.Mode, p.MaxRounds)
+	default:
+		return string(p.Mode)
+	}
+}
+
+// Rounds is the number of rounds a game may play under the policy.
+func (p DrawPolicy) Rounds() int {
+	if p.Mode == DrawReroll {
+		return p.MaxRounds
+	}
+	return 1
+}
+
+// Settle decides a game from the winner variant gave its last round and
+// returns the game's winner and payout.
+func (p DrawPolicy) Settle(variant GameRules, winner model.Winner, player, server *dice.Result) (model.Winner, float64) {
+	if winner == model.WinnerDraw {
+		switch p.Mode {
+		case DrawHouseWins:
+			winner = model.WinnerServer
+		case DrawPush:
+			return model.WinnerDraw, 1
+		}
+	}
+
+	return winner, variant.Payout(winner, player, server)
+}
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"dice-game/pkg/domain/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDrawPolicy(t *testing.T) {
+	tests := []struct {
+		notation string
+		policy   DrawPolicy
+		rounds   int
+	}{
+		{"", KeepDraws, 1},
+		{"keep", KeepDraws, 1},
+		{"house_wins", DrawPolicy{Mode: DrawHouseWins}, 1},
+		{"push", DrawPolicy{Mode: DrawPush}, 1},
+		{"reroll:3", DrawPolicy{Mode: DrawReroll, MaxRounds: 3}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			policy, err := ParseDrawPolicy(tt.notation)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.policy, policy)
+			assert.Equal(t, tt.rounds, policy.Rounds())
+
+			reparsed, err := ParseDrawPolicy(policy.String())
+			require.NoError(t, err)
+			assert.Equal(t, policy, reparsed)
+		})
+	}
+}
+
+func TestParseDrawPolicy_Invalid(t *testing.T) {
+	for _, notation := range []string{"reroll", "reroll:1", "reroll:11", "reroll:x", "push:2", "sudden_death"} {
+		t.Run(notation, func(t *testing.T) {
+			_, err := ParseDrawPolicy(notation)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDrawPolicy_Settle(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  DrawPolicy
+		variant GameRules
+		winner  model.Winner
+		settled model.Winner
+		payout  float64
+	}{
+		{"Keep pays the variant's draw", KeepDraws, ClassicRules{}, model.WinnerDraw, model.WinnerDraw, 1},
+		{"House wins draws", DrawPolicy{Mode: DrawHouseWins}, ClassicRules{}, model.WinnerDraw, model.WinnerServer, 0},
+		{"Push returns the stake", DrawPolicy{Mode: DrawPush}, ClassicRules{}, model.WinnerDraw, model.WinnerDraw, 1},
+		{"Draw after the last re-roll stands", DrawPolicy{Mode: DrawReroll, MaxRounds: 2}, ClassicRules{}, model.WinnerDraw, model.WinnerDraw, 1},
+		{"Decided rounds are left alone", DrawPolicy{Mode: DrawHouseWins}, ClassicRules{}, model.WinnerPlayer, model.WinnerPlayer, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			winner, payout := tt.policy.Settle(tt.variant, tt.winner, roll(3), roll(3))
+
+			assert.Equal(t, tt.settled, winner)
+			assert.Equal(t, tt.payout, payout)
+		})
+	}
+}
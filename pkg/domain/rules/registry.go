@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownVariant is returned for variant names, or versions of a
+// variant, that are not registered.
+var ErrUnknownVariant = errors.New("unknown game variant")
+
+// Registry holds the game variants by name. Older versions of a variant
+// stay registered next to the latest one, so games played under them can
+// still be verified. Names are looked up with underscores and hyphens
+// treated alike, so lowest_wins finds lowest-wins. It is safe for
+// concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	versions map[string]map[int]GameRules
+	latest   map[string]GameRules
+	// names keeps the order variants were first registered in.
+	names []string
+}
+
+func NewRegistry(variants ...GameRules) (*Registry, error) {
+	r := &Registry{
+		versions: make(map[string]map[int]GameRules),
+		latest:   make(map[string]GameRules),
+	}
+
+	for _, variant := range variants {
+		if err := r.Register(variant); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+var builtin = mustRegistry(ClassicRules{}, LowestWinsRules{}, DoublesBeatAllRules{}, SumOfThreeRules{})
+
+func mustRegistry(variants ...GameRules) *Registry {
+	r, err := NewRegistry(variants...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Builtin returns the registry of the variants games are played and
+// verified with. New variants ship by registering them here.
+func Builtin() *Registry {
+	return builtin
+}
+
+// Register adds a variant, or a new version of one. The highest version of
+// a name is the one new games are played with.
+func (r *Registry) Register(variant GameRules) error {
+	name, version := variantKey(variant.Name()), variant.Version()
+	if name == "" {
+		return fmt.Errorf("game variant without a name")
+	}
+	if version < 1 {
+		return fmt.Errorf("game variant %s has invalid version %d", name, version)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions, ok := r.versions[name]
+	if !ok {
+		versions = make(map[int]GameRules)
+		r.versions[name] = versions
+		r.names = append(r.names, name)
+	}
+	if _, ok := versions[version]; ok {
+		return fmt.Errorf("game variant %s version %d is already registered", name, version)
+	}
+
+	versions[version] = variant
+	if latest, ok := r.latest[name]; !ok || version > latest.Version() {
+		r.latest[name] = variant
+	}
+
+	return nil
+}
+
+// Get returns the latest version of the variant called name.
+func (r *Registry) Get(name string) (GameRules, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	variant, ok := r.latest[variantKey(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownVariant, name)
+	}
+
+	return variant, nil
+}
+
+// Version returns the given version of the variant called name.
+func (r *Registry) Version(name string, version int) (GameRules, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	variant, ok := r.versions[variantKey(name)][version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s version %d", ErrUnknownVariant, name, version)
+	}
+
+	return variant, nil
+}
+
+// List returns the latest version of every variant in the order they were
+// first registered.
+func (r *Registry) List() []GameRules {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	variants := make([]GameRules, len(r.names))
+	for i, name := range r.names {
+		variants[i] = r.latest[name]
+	}
+
+	return variants
+}
+
+// variantKey is the spelling a variant name is registered under.
+func variantKey(name string) string {
+	return strings.ReplaceAll(name, "_", "-")
+}
@@ -0,0 +1,89 @@
+// Package rules defines game variants: the dice both sides roll, the values
+// a game draws for them, who wins and what a win pays.
+package rules
+
+import (
+	"dice-game/pkg/domain/dice"
+	"dice-game/pkg/domain/model"
+	"fmt"
+)
+
+// GameRules is a game variant. Implementations must be deterministic: the
+// same dice always give the same winner and payout, so stored games can be
+// verified against the rules they were played with.
+type GameRules interface {
+	// Name identifies the variant in requests and on stored games.
+	Name() string
+	// Version is stored with every game and must change whenever the same
+	// draws would give a different outcome.
+	Version() int
+	// Dice returns the expression both sides roll. requested is the dice
+	// expression the player asked for, or nil; variants with a fixed dice
+	// set reject it.
+	Dice(requested *dice.Expression) (*dice.Expression, error)
+	// Plan returns the values a game rolling expr draws.
+	Plan(expr *dice.Expression) Plan
+	// Winner decides the game from both sides' rolls.
+	Winner(player, server *dice.Result) model.Winner
+	// Payout is the multiple of the player's stake returned to them.
+	Payout(winner model.Winner, player, server *dice.Result) float64
+}
+
+// Plan is what a game draws from its generator: Count values in
+// [Min, Max], mapped onto the player's dice first and then the server's.
+type Plan struct {
+	Count int
+	Min   int
+	Max   int
+}
+
+// ClassicDice is the single d6 each side rolls when neither the variant nor
+// the player picks other dice. Games rolled with it store no dice
+// expression.
+var ClassicDice = mustParse("1d6")
+
+// DefaultPlan draws enough values for both sides to roll their most
+// exploding dice, all from the expression's draw range.
+func DefaultPlan(expr *dice.Expression) Plan {
+	return Plan{Count: 2 * expr.MaxDraws(), Min: 1, Max: expr.DrawRange()}
+}
+
+func mustParse(notation string) *dice.Expression {
+	expr, err := dice.Parse(notation)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+// higherWins gives the game to the side with the higher total.
+func higherWins(player, server *dice.Result) model.Winner {
+	switch {
+	case player.Total > server.Total:
+		return model.WinnerPlayer
+	case server.Total > player.Total:
+		return model.WinnerServer
+	default:
+		return model.WinnerDraw
+	}
+}
+
+// evenMoney pays twice the stake for a win and returns it on a draw.
+func evenMoney(winner model.Winner) float64 {
+	switch winner {
+	case model.WinnerPlayer:
+		return 2
+	case model.WinnerDraw:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// fixedDice is the dice set of variants that do not let players pick.
+func fixedDice(name string, fixed, requested *dice.Expression) (*dice.Expression, error) {
+	if requested != nil {
+		return nil, fmt.Errorf("%s always rolls %s", name, fixed)
+	}
+	return fixed, nil
+}
@@ -0,0 +1,121 @@
+package rules
+
+import (
+	"dice-game/pkg/domain/dice"
+	"dice-game/pkg/domain/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func roll(faces ...int) *dice.Result {
+	result := &dice.Result{}
+	for _, face := range faces {
+		result.Dice = append(result.Dice, dice.Die{Sides: 6, Value: face})
+		result.Total += face
+	}
+	return result
+}
+
+func TestVariants_WinnerAndPayout(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          GameRules
+		player, server *dice.Result
+		winner         model.Winner
+		payout         float64
+	}{
+		{"Classic higher wins", ClassicRules{}, roll(5), roll(2), model.WinnerPlayer, 2},
+		{"Classic draw returns the stake", ClassicRules{}, roll(4), roll(4), model.WinnerDraw, 1},
+		{"Lowest wins", LowestWinsRules{}, roll(5), roll(2), model.WinnerServer, 0},
+		{"Lowest wins for the player", LowestWinsRules{}, roll(1), roll(6), model.WinnerPlayer, 2},
+		{"Double beats a higher total", DoublesBeatAllRules{}, roll(1, 1), roll(6, 5), model.WinnerPlayer, 3},
+		{"Server double beats a higher total", DoublesBeatAllRules{}, roll(6, 5), roll(2, 2), model.WinnerServer, 0},
+		{"Higher double wins", DoublesBeatAllRules{}, roll(3, 3), roll(5, 5), model.WinnerServer, 0},
+		{"No doubles, higher total wins", DoublesBeatAllRules{}, roll(6, 4), roll(1, 2), model.WinnerPlayer, 2},
+		{"Sum of three", SumOfThreeRules{}, roll(1, 2, 3), roll(2, 2, 2), model.WinnerDraw, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			winner := tt.rules.Winner(tt.player, tt.server)
+
+			assert.Equal(t, tt.winner, winner)
+			assert.Equal(t, tt.payout, tt.rules.Payout(winner, tt.player, tt.server))
+		})
+	}
+}
+
+func TestVariants_Dice(t *testing.T) {
+	d20, err := dice.Parse("1d20")
+	require.NoError(t, err)
+
+	expr, err := ClassicRules{}.Dice(nil)
+	require.NoError(t, err)
+	assert.Same(t, ClassicDice, expr)
+
+	expr, err = LowestWinsRules{}.Dice(d20)
+	require.NoError(t, err)
+	assert.Same(t, d20, expr)
+
+	expr, err = DoublesBeatAllRules{}.Dice(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2d6", expr.String())
+	assert.Equal(t, Plan{Count: 4, Min: 1, Max: 6}, DoublesBeatAllRules{}.Plan(expr))
+
+	_, err = SumOfThreeRules{}.Dice(d20)
+	assert.ErrorContains(t, err, "sum-of-three always rolls 3d6")
+}
+
+type classicV2 struct {
+	ClassicRules
+}
+
+func (classicV2) Version() int { return 2 }
+
+func TestRegistry(t *testing.T) {
+	// Arrange
+	r, err := NewRegistry(ClassicRules{}, SumOfThreeRules{})
+	require.NoError(t, err)
+
+	// Act
+	registerErr := r.Register(classicV2{})
+	duplicateErr := r.Register(ClassicRules{})
+
+	// Assert
+	require.NoError(t, registerErr)
+	assert.ErrorContains(t, duplicateErr, "classic version 1 is already registered")
+
+	latest, err := r.Get(Classic)
+	require.NoError(t, err)
+	assert.Equal(t, 2, latest.Version())
+
+	old, err := r.Version(Classic, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, old.Version())
+
+	stored, err := r.Version("sum_of_three", 1)
+	require.NoError(t, err, "games stored before the names were hyphenated still resolve")
+	assert.Equal(t, SumOfThree, stored.Name())
+
+	_, err = r.Get("poker")
+	assert.ErrorIs(t, err, ErrUnknownVariant)
+	_, err = r.Version(SumOfThree, 2)
+	assert.ErrorIs(t, err, ErrUnknownVariant)
+
+	names := make([]string, 0)
+	for _, variant := range r.List() {
+		names = append(names, variant.Name())
+	}
+	assert.Equal(t, []string{Classic, SumOfThree}, names)
+}
+
+func TestBuiltin(t *testing.T) {
+	names := make([]string, 0)
+	for _, variant := range Builtin().List() {
+		names = append(names, variant.Name())
+	}
+
+	assert.Equal(t, []string{Classic, LowestWins, DoublesBeatAll, SumOfThree}, names)
+}
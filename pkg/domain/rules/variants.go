@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"dice-game/pkg/domain/dice"
+	"dice-game/pkg/domain/model"
+)
+
+// Names of the built-in variants. Games played before the names were
+// hyphenated are stored with underscores, which Registry also accepts.
+const (
+	Classic        = "classic"
+	LowestWins     = "lowest-wins"
+	DoublesBeatAll = "doubles-beat-all"
+	SumOfThree     = "sum-of-three"
+)
+
+// ClassicRules is the original game: each side rolls one d6, or the dice
+// expression the player picked, and the higher total wins.
+type ClassicRules struct{}
+
+func (ClassicRules) Name() string { return Classic }
+
+func (ClassicRules) Version() int { return 1 }
+
+func (ClassicRules) Dice(requested *dice.Expression) (*dice.Expression, error) {
+	if requested == nil {
+		return ClassicDice, nil
+	}
+	return requested, nil
+}
+
+func (ClassicRules) Plan(expr *dice.Expression) Plan { return DefaultPlan(expr) }
+
+func (ClassicRules) Winner(player, server *dice.Result) model.Winner {
+	return higherWins(player, server)
+}
+
+func (ClassicRules) Payout(winner model.Winner, _, _ *dice.Result) float64 {
+	return evenMoney(winner)
+}
+
+// LowestWinsRules rolls like ClassicRules but gives the game to the lower
+// total.
+type LowestWinsRules struct {
+	ClassicRules
+}
+
+func (LowestWinsRules) Name() string { return LowestWins }
+
+func (LowestWinsRules) Version() int { return 1 }
+
+func (LowestWinsRules) Winner(player, server *dice.Result) model.Winner {
+	switch {
+	case player.Total < server.Total:
+		return model.WinnerPlayer
+	case server.Total < player.Total:
+		return model.WinnerServer
+	default:
+		return model.WinnerDraw
+	}
+}
+
+var twoD6 = mustParse("2d6")
+
+// DoublesBeatAllRules rolls 2d6 a side. A double beats any other roll;
+// otherwise, or when both sides roll a double, the higher total wins. A
+// player winning with a double is paid three times the stake.
+type DoublesBeatAllRules struct{}
+
+func (DoublesBeatAllRules) Name() string { return DoublesBeatAll }
+
+func (DoublesBeatAllRules) Version() int { return 1 }
+
+func (r DoublesBeatAllRules) Dice(requested *dice.Expression) (*dice.Expression, error) {
+	return fixedDice(r.Name(), twoD6, requested)
+}
+
+func (DoublesBeatAllRules) Plan(expr *dice.Expression) Plan { return DefaultPlan(expr) }
+
+func (DoublesBeatAllRules) Winner(player, server *dice.Result) model.Winner {
+	switch playerDouble, serverDouble := isDouble(player), isDouble(server); {
+	case playerDouble && !serverDouble:
+		return model.WinnerPlayer
+	case serverDouble && !playerDouble:
+		return model.WinnerServer
+	default:
+		return higherWins(player, server)
+	}
+}
+
+func (DoublesBeatAllRules) Payout(winner model.Winner, player, _ *dice.Result) float64 {
+	if winner == model.WinnerPlayer && isDouble(player) {
+		return 3
+	}
+	return evenMoney(winner)
+}
+
+// isDouble reports whether every die of a roll of at least two shows the
+// same face.
+func isDouble(r *dice.Result) bool {
+	if len(r.Dice) < 2 {
+		return false
+	}
+	for _, die := range r.Dice[1:] {
+		if die.Value != r.Dice[0].Value {
+			return false
+		}
+	}
+	return true
+}
+
+var threeD6 = mustParse("3d6")
+
+// SumOfThreeRules rolls 3d6 a side and the higher sum wins.
+type SumOfThreeRules struct{}
+
+func (SumOfThreeRules) Name() string { return SumOfThree }
+
+func (SumOfThreeRules) Version() int { return 1 }
+
+func (r SumOfThreeRules) Dice(requested *dice.Expression) (*dice.Expression, error) {
+	return fixedDice(r.Name(), threeD6, requested)
+}
+
+func (SumOfThreeRules) Plan(expr *dice.Expression) Plan { return DefaultPlan(expr) }
+
+func (SumOfThreeRules) Winner(player, server *dice.Result) model.Winner {
+	return higherWins(player, server)
+}
+
+func (SumOfThreeRules) Payout(winner model.Winner, _, _ *dice.Result) float64 {
+	return evenMoney(winner)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/rules"
+	"encoding/json"
+	"time"
+)
+
+// canonicalGameResult fixes the field order and encoding of a game. The
+// field set is frozen: changing it would break Merkle proofs for days that
+// are already sealed and receipts already handed out. Fields added later are
+// appended and omitted when empty, so older games encode as before.
+type canonicalGameResult struct {
+	GameID            string `json:"game_id"`
+	PlayerID          string `json:"player_id"`
+	PlayerDice        int    `json:"player_dice"`
+	ServerDice        int    `json:"server_dice"`
+	Winner            string `json:"winner"`
+	PlayedAt          string `json:"played_at"`
+	GeneratorUsed     string `json:"generator_used"`
+	VerificationKey   string `json:"verification_key"`
+	ClientSeed        string `json:"client_seed"`
+	Nonce             int64  `json:"nonce"`
+	AlgorithmVersion  int    `json:"algorithm_version"`
+	BeaconRound       int64  `json:"beacon_round,omitempty"`
+	SelectionStrategy string `json:"selection_strategy,omitempty"`
+	NonProduction     bool   `json:"non_production,omitempty"`
+	Dice              string `json:"dice,omitempty"`
+	PlayerRolls       []int  `json:"player_rolls,omitempty"`
+	ServerRolls       []int  `json:"server_rolls,omitempty"`
+	Variant           string `json:"variant,omitempty"`
+	VariantVersion    int    `json:"variant_version,omitempty"`
+	DrawPolicy        string `json:"draw_policy,omitempty"`
+	// Rounds are only encoded for games that re-rolled a draw; the dice
+	// fields above are their last round.
+	Rounds     []canonicalRound `json:"rounds,omitempty"`
+	MatchID    string           `json:"match_id,omitempty"`
+	MatchRound int              `json:"match_round,omitempty"`
+}
+
+type canonicalRound struct {
+	PlayerDice  int   `json:"player_dice"`
+	ServerDice  int   `json:"server_dice"`
+	PlayerRolls []int `json:"player_rolls,omitempty"`
+	ServerRolls []int `json:"server_rolls,omitempty"`
+}
+
+// CanonicalGameResult returns the bytes a game's Merkle leaf is hashed over
+// and its receipt is signed over: compact JSON with a fixed field order and
+// played_at in UTC with microsecond precision, as stored by PostgreSQL.
+func CanonicalGameResult(result *model.GameResult) ([]byte, error) {
+	// Classic games encode as they did before variants existed. The payout
+	// follows from the variant and the dice, so it is not encoded.
+	variant, variantVersion := result.Variant, result.VariantVersion
+	if variant == rules.Classic && variantVersion == 1 {
+		variant, variantVersion = "", 0
+	}
+
+	// Likewise for games that keep their draws and played a single round.
+	drawPolicy := result.DrawPolicy
+	if drawPolicy == rules.KeepDraws.String() {
+		drawPolicy = ""
+	}
+
+	var rounds []canonicalRound
+	if len(result.Rounds) > 1 {
+		rounds = make([]canonicalRound, len(result.Rounds))
+		for i, round := range result.Rounds {
+			rounds[i] = canonicalRound{
+				PlayerDice:  round.PlayerDice,
+				ServerDice:  round.ServerDice,
+				PlayerRolls: round.PlayerRolls,
+				ServerRolls: round.ServerRolls,
+			}
+		}
+	}
+
+	return json.Marshal(canonicalGameResult{
+		GameID:            result.GameID,
+		PlayerID:          result.PlayerID,
+		PlayerDice:        result.PlayerDice,
+		ServerDice:        result.ServerDice,
+		Winner:            string(result.Winner),
+		PlayedAt:          result.PlayedAt.UTC().Truncate(time.Microsecond).Format("2006-01-02T15:04:05.000000Z"),
+		GeneratorUsed:     result.GeneratorUsed,
+		VerificationKey:   result.VerificationKey,
+		ClientSeed:        result.ClientSeed,
+		Nonce:             result.Nonce,
+		AlgorithmVersion:  result.AlgorithmVersion,
+		BeaconRound:       result.BeaconRound,
+		SelectionStrategy: result.SelectionStrategy,
+		NonProduction:     result.NonProduction,
+		Dice:              result.Dice,
+		PlayerRolls:       result.PlayerRolls,
+		ServerRolls:       result.ServerRolls,
+		Variant:           variant,
+		VariantVersion:    variantVersion,
+		DrawPolicy:        drawPolicy,
+		Rounds:            rounds,
+		MatchID:           result.MatchID,
+		MatchRound:        result.MatchRound,
+	})
+}
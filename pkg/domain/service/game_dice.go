@@ -0,0 +1,193 @@
+package service
+
+import (
+	"dice-game/pkg/domain/dice"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/rules"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// ErrDiceNotOffered is returned when a player asks for a dice expression
+// the server does not offer.
+var ErrDiceNotOffered = errors.New("dice expression is not offered")
+
+// ruleset is everything a game is played by: the variant, the policy its
+// draws are resolved by and the dice both sides roll.
+type ruleset struct {
+	variant rules.GameRules
+	policy  rules.DrawPolicy
+	expr    *dice.Expression
+}
+
+// storedRuleset returns the ruleset a stored game was played with. Games
+// exported before variants existed carry none and are classic; games
+// before draw policies kept their draws.
+func storedRuleset(result *model.GameResult) (*ruleset, error) {
+	name, version := result.Variant, result.VariantVersion
+	if name == "" {
+		name, version = rules.Classic, 1
+	}
+
+	variant, err := rules.Builtin().Version(name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := rules.ParseDrawPolicy(result.DrawPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	var expr *dice.Expression
+	if result.Dice == "" {
+		expr, err = variant.Dice(nil)
+	} else {
+		expr, err = dice.Parse(result.Dice)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ruleset{variant: variant, policy: policy, expr: expr}, nil
+}
+
+// plan returns the values a game draws: enough for every round its draw
+// policy may play, so one roll decides the whole game.
+func (r *ruleset) plan() rules.Plan {
+	plan := r.variant.Plan(r.expr)
+	plan.Count *= r.policy.Rounds()
+	return plan
+}
+
+// DrawPlan returns the values a stored game drew, from the variant, draw
+// policy and dice recorded on it. With PlayDrawn it lets offline tools
+// replay a game the way CheckProvablyFair does.
+func DrawPlan(result *model.GameResult) (rules.Plan, error) {
+	r, err := storedRuleset(result)
+	if err != nil {
+		return rules.Plan{}, err
+	}
+	return r.plan(), nil
+}
+
+// PlayDrawn plays a stored game out from the values it drew and returns
+// every round it played and its winner.
+func PlayDrawn(result *model.GameResult, values []int) ([]model.GameRound, model.Winner, error) {
+	r, err := storedRuleset(result)
+	if err != nil {
+		return nil, "", err
+	}
+
+	played, err := r.play(values)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return played.rounds, played.winner, nil
+}
+
+// outcome is a game played out from its drawn values.
+type outcome struct {
+	rounds []model.GameRound
+	winner model.Winner
+	payout float64
+}
+
+// play maps drawn values onto the rounds of a game. Each round the player
+// and then the server roll the next values; a drawn round is rolled again
+// while the draw policy has rounds left. Values no round needed are
+// discarded.
+func (r *ruleset) play(values []int) (*outcome, error) {
+	draws := dice.NewDraws(values, r.plan().Max)
+	played := &outcome{}
+
+	for {
+		player, err := r.expr.Evaluate(draws.Roll)
+		if err != nil {
+			return nil, fmt.Errorf("failed to roll player dice: %w", err)
+		}
+		server, err := r.expr.Evaluate(draws.Roll)
+		if err != nil {
+			return nil, fmt.Errorf("failed to roll server dice: %w", err)
+		}
+
+		round := model.GameRound{PlayerDice: player.Total, ServerDice: server.Total}
+		if r.expr != rules.ClassicDice {
+			round.PlayerRolls = player.Values()
+			round.ServerRolls = server.Values()
+		}
+		played.rounds = append(played.rounds, round)
+
+		winner := r.variant.Winner(player, server)
+		if winner != model.WinnerDraw || len(played.rounds) == r.policy.Rounds() {
+			played.winner, played.payout = r.policy.Settle(r.variant, winner, player, server)
+			return played, nil
+		}
+	}
+}
+
+// checkDice plays a game out from the values recomputed for it and
+// compares every round, the dice of its deciding round and the winner with
+// the stored result. Results without rounds, such as exports, only have
+// the deciding round checked; results without a winner, such as old
+// exports, only have their dice checked.
+func checkDice(result *model.GameResult, r *ruleset, values []int) error {
+	played, err := r.play(values)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Rounds) > 0 {
+		if len(result.Rounds) != len(played.rounds) {
+			return &MismatchError{Field: "rounds", Stored: strconv.Itoa(len(result.Rounds)), Computed: strconv.Itoa(len(played.rounds))}
+		}
+		for i, round := range played.rounds {
+			if err := checkRound(fmt.Sprintf("round %d ", i+1), result.Rounds[i], round); err != nil {
+				return err
+			}
+		}
+	}
+
+	last := played.rounds[len(played.rounds)-1]
+	stored := model.GameRound{
+		PlayerDice:  result.PlayerDice,
+		ServerDice:  result.ServerDice,
+		PlayerRolls: result.PlayerRolls,
+		ServerRolls: result.ServerRolls,
+	}
+	if err := checkRound("", stored, last); err != nil {
+		return err
+	}
+
+	if result.Winner != "" && played.winner != result.Winner {
+		return &MismatchError{Field: "winner", Stored: string(result.Winner), Computed: string(played.winner)}
+	}
+
+	return nil
+}
+
+// checkRound compares the totals of a round and, for dice expressions,
+// every die with the recomputed round. prefix names the round in
+// mismatches.
+func checkRound(prefix string, stored, computed model.GameRound) error {
+	if computed.PlayerDice != stored.PlayerDice {
+		return &MismatchError{Field: prefix + "player_dice", Stored: strconv.Itoa(stored.PlayerDice), Computed: strconv.Itoa(computed.PlayerDice)}
+	}
+
+	if computed.ServerDice != stored.ServerDice {
+		return &MismatchError{Field: prefix + "server_dice", Stored: strconv.Itoa(stored.ServerDice), Computed: strconv.Itoa(computed.ServerDice)}
+	}
+
+	if !slices.Equal(computed.PlayerRolls, stored.PlayerRolls) {
+		return &MismatchError{Field: prefix + "player_rolls", Stored: fmt.Sprint(stored.PlayerRolls), Computed: fmt.Sprint(computed.PlayerRolls)}
+	}
+
+	if !slices.Equal(computed.ServerRolls, stored.ServerRolls) {
+		return &MismatchError{Field: prefix + "server_rolls", Stored: fmt.Sprint(stored.ServerRolls), Computed: fmt.Sprint(computed.ServerRolls)}
+	}
+
+	return nil
+}
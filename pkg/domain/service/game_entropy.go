@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+	"dice-game/pkg/infrastructure/random"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	// ErrEntropyAuditDisabled is returned by AuditEntropy when games are
+	// played without recording their entropy.
+	ErrEntropyAuditDisabled = errors.New("entropy audit is disabled")
+	// ErrEntropyNotRecorded is returned by AuditEntropy for games played
+	// before recording was enabled.
+	ErrEntropyNotRecorded = errors.New("no entropy was recorded for this game")
+)
+
+// EntropySealer encrypts recorded entropy at rest. Sealed data is bound to
+// its game, so it only opens with the gameID it was sealed for.
+type EntropySealer interface {
+	Seal(gameID string, plaintext []byte) (keyID string, sealed []byte, err error)
+	Open(keyID, gameID string, sealed []byte) ([]byte, error)
+}
+
+// RecordEntropy makes PlayGame store the raw bytes every roll was drawn
+// from together with the game. Without a sealer the bytes are stored in
+// the clear.
+func (s *GameService) RecordEntropy(repo repository.EntropyRepository, sealer EntropySealer) {
+	s.entropyRepo = repo
+	s.entropySealer = sealer
+}
+
+// checkRollEntropy makes sure the entropy a generator recorded replays to
+// the values it returned, so no game is stored with entropy an auditor
+// could not confirm.
+func checkRollEntropy(generator string, req random.RollRequest, roll *random.Roll) error {
+	if len(roll.Entropy) == 0 {
+		return fmt.Errorf("generator %s did not record its entropy", generator)
+	}
+
+	values, err := random.ValuesFromEntropy(roll.EntropyMapping, roll.Entropy, req.Count, req.Min, req.Max)
+	if err != nil {
+		return fmt.Errorf("generator %s recorded unusable entropy: %w", generator, err)
+	}
+
+	if !slices.Equal(values, roll.Values) {
+		return fmt.Errorf("entropy recorded by generator %s does not map onto its values", generator)
+	}
+
+	return nil
+}
+
+// saveGameWithEntropy seals the entropy of a roll and stores it together
+// with the game.
+func (s *GameService) saveGameWithEntropy(ctx context.Context, result *model.GameResult, req random.RollRequest, roll *random.Roll) error {
+	entropy := &model.GameEntropy{
+		GameID:    result.GameID,
+		Generator: result.GeneratorUsed,
+		Mapping:   roll.EntropyMapping,
+		Count:     req.Count,
+		Min:       req.Min,
+		Max:       req.Max,
+		Data:      roll.Entropy,
+		CreatedAt: result.PlayedAt,
+	}
+
+	if s.entropySealer != nil {
+		keyID, sealed, err := s.entropySealer.Seal(result.GameID, roll.Entropy)
+		if err != nil {
+			return fmt.Errorf("failed to seal entropy: %w", err)
+		}
+		entropy.KeyID = keyID
+		entropy.Data = sealed
+	}
+
+	return s.entropyRepo.SaveGameResultWithEntropy(ctx, result, entropy)
+}
+
+// AuditEntropy replays the entropy recorded for a game and checks that it
+// maps onto the dice stored with the game. The returned entropy is
+// unsealed.
+func (s *GameService) AuditEntropy(ctx context.Context, gameID string) (*model.EntropyAudit, error) {
+	if s.entropyRepo == nil {
+		return nil, ErrEntropyAuditDisabled
+	}
+
+	result, err := s.gameRepo.GetGameResult(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game result: %w", err)
+	}
+
+	entropy, err := s.entropyRepo.GetGameEntropy(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game entropy: %w", err)
+	}
+	if entropy == nil {
+		return nil, ErrEntropyNotRecorded
+	}
+
+	if entropy.KeyID != "" {
+		if s.entropySealer == nil {
+			return nil, fmt.Errorf("entropy is sealed with key %s but no sealing keys are configured", entropy.KeyID)
+		}
+		entropy.Data, err = s.entropySealer.Open(entropy.KeyID, gameID, entropy.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open sealed entropy: %w", err)
+		}
+	}
+
+	audit := &model.EntropyAudit{Entropy: entropy}
+
+	audit.Values, err = random.ValuesFromEntropy(entropy.Mapping, entropy.Data, entropy.Count, entropy.Min, entropy.Max)
+	if err != nil {
+		audit.Reason = err.Error()
+		return audit, nil
+	}
+
+	r, err := storedRuleset(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkDice(result, r, audit.Values); err != nil {
+		var mismatch *MismatchError
+		if !errors.As(err, &mismatch) {
+			return nil, err
+		}
+		audit.Reason = mismatch.Error()
+		return audit, nil
+	}
+
+	audit.Valid = true
+	return audit, nil
+}
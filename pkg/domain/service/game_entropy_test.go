@@ -0,0 +1,184 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/infrastructure/random"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockEntropyRepository struct {
+	mock.Mock
+}
+
+func (m *MockEntropyRepository) SaveGameResultWithEntropy(ctx context.Context, result *model.GameResult, entropy *model.GameEntropy) error {
+	args := m.Called(ctx, result, entropy)
+	return args.Error(0)
+}
+
+func (m *MockEntropyRepository) GetGameEntropy(ctx context.Context, gameID string) (*model.GameEntropy, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.GameEntropy), args.Error(1)
+}
+
+// prefixSealer "seals" by prepending the game ID, which is enough to tell
+// sealed from plain data and to catch entropy opened for the wrong game.
+type prefixSealer struct{}
+
+func (prefixSealer) Seal(gameID string, plaintext []byte) (string, []byte, error) {
+	return "test-key", append([]byte(gameID), plaintext...), nil
+}
+
+func (prefixSealer) Open(keyID, gameID string, sealed []byte) ([]byte, error) {
+	if keyID != "test-key" || !bytes.HasPrefix(sealed, []byte(gameID)) {
+		return nil, fmt.Errorf("cannot open")
+	}
+	return sealed[len(gameID):], nil
+}
+
+// playWithEntropy plays a game with generator while recording its entropy
+// and returns the stored game and entropy.
+func playWithEntropy(t *testing.T, generator random.Generator, sealer EntropySealer) (*GameService, *MockGameRepository, *MockEntropyRepository, *model.GameResult, *model.GameEntropy) {
+	t.Helper()
+
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockEntropy := new(MockEntropyRepository)
+
+	var stored *model.GameEntropy
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockEntropy.On("SaveGameResultWithEntropy", mock.Anything, mock.AnythingOfType("*model.GameResult"), mock.AnythingOfType("*model.GameEntropy")).
+		Run(func(args mock.Arguments) { stored = args.Get(2).(*model.GameEntropy) }).
+		Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+	service.RecordEntropy(mockEntropy, sealer)
+
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+
+	return service, mockRepo, mockEntropy, result, stored
+}
+
+func TestPlayGame_RecordsEntropy(t *testing.T) {
+	// Arrange
+	generator := random.NewCryptoGenerator()
+
+	// Act
+	_, mockRepo, _, result, entropy := playWithEntropy(t, generator, nil)
+
+	// Assert
+	assert.Equal(t, result.GameID, entropy.GameID)
+	assert.Equal(t, "crypto", entropy.Generator)
+	assert.Equal(t, random.EntropyWords, entropy.Mapping)
+	assert.Equal(t, 2, entropy.Count)
+	assert.Empty(t, entropy.KeyID)
+	values, err := random.ValuesFromEntropy(entropy.Mapping, entropy.Data, entropy.Count, entropy.Min, entropy.Max)
+	require.NoError(t, err)
+	assert.Equal(t, []int{result.PlayerDice, result.ServerDice}, values)
+	mockRepo.AssertNotCalled(t, "SaveGameResult", mock.Anything, mock.Anything)
+}
+
+func TestPlayGame_EntropyNotRecordedByGenerator(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockEntropy := new(MockEntropyRepository)
+	mockGen := new(MockGenerator)
+
+	req := diceRollRequest("client-seed", 1)
+	req.RecordEntropy = true
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", req).Return(&random.Roll{Values: []int{4, 2}}, nil)
+	mockGen.On("Name").Return("test_generator")
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+	service.RecordEntropy(mockEntropy, nil)
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.ErrorContains(t, err, "generator test_generator did not record its entropy")
+	assert.Nil(t, result)
+	mockEntropy.AssertNotCalled(t, "SaveGameResultWithEntropy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuditEntropy_Sealed(t *testing.T) {
+	// Arrange
+	service, mockRepo, mockEntropy, result, entropy := playWithEntropy(t, random.NewBufferedCryptoGenerator(0), prefixSealer{})
+	mockRepo.On("GetGameResult", mock.Anything, result.GameID).Return(result, nil)
+	mockEntropy.On("GetGameEntropy", mock.Anything, result.GameID).Return(entropy, nil)
+
+	// Act
+	audit, err := service.AuditEntropy(context.Background(), result.GameID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "test-key", entropy.KeyID)
+	assert.True(t, audit.Valid, audit.Reason)
+	assert.Equal(t, []int{result.PlayerDice, result.ServerDice}, audit.Values)
+	assert.Equal(t, random.EntropyLemire, audit.Entropy.Mapping)
+}
+
+func TestAuditEntropy_DiceDoNotMatch(t *testing.T) {
+	// Arrange
+	service, mockRepo, mockEntropy, result, entropy := playWithEntropy(t, random.NewCryptoGenerator(), nil)
+	tampered := *result
+	tampered.PlayerDice = result.PlayerDice%6 + 1
+	mockRepo.On("GetGameResult", mock.Anything, result.GameID).Return(&tampered, nil)
+	mockEntropy.On("GetGameEntropy", mock.Anything, result.GameID).Return(entropy, nil)
+
+	// Act
+	audit, err := service.AuditEntropy(context.Background(), result.GameID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, audit.Valid)
+	assert.Contains(t, audit.Reason, "player_dice mismatch")
+}
+
+func TestAuditEntropy_NotRecorded(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockGameRepository)
+	mockEntropy := new(MockEntropyRepository)
+	mockRepo.On("GetGameResult", mock.Anything, "old-game").Return(&model.GameResult{GameID: "old-game"}, nil)
+	mockEntropy.On("GetGameEntropy", mock.Anything, "old-game").Return(nil, nil)
+
+	service := NewGameService(new(MockRandomService), mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+	service.RecordEntropy(mockEntropy, nil)
+
+	// Act
+	audit, err := service.AuditEntropy(context.Background(), "old-game")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrEntropyNotRecorded)
+	assert.Nil(t, audit)
+}
+
+func TestAuditEntropy_Disabled(t *testing.T) {
+	// Arrange
+	service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	_, err := service.AuditEntropy(context.Background(), "test-game-id")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrEntropyAuditDisabled)
+}
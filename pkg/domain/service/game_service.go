@@ -2,159 +2,618 @@ package service
 
 import (
 	"context"
-	"crypto/sha256"
+	"dice-game/pkg/domain/dice"
 	"dice-game/pkg/domain/model"
 	"dice-game/pkg/domain/repository"
-	"encoding/hex"
+	"dice-game/pkg/domain/rules"
+	"dice-game/pkg/infrastructure/random"
+	"errors"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrGameNotFound is returned for unknown game IDs.
+	ErrGameNotFound = repository.ErrGameNotFound
+	// ErrNotVerifiable is returned by VerifyGame for games played with a
+	// generator players cannot verify.
+	ErrNotVerifiable = errors.New("game was not played with a verifiable generator")
+	// ErrSeedNotRevealed is returned by VerifyGame until the game's server
+	// seed is revealed by a seed rotation.
+	ErrSeedNotRevealed = errors.New("server seed has not been revealed yet")
+)
+
 type GameService struct {
-	randomService RandomServiceInterface
-	gameRepo      repository.GameRepository
+	randomService    RandomServiceInterface
+	gameRepo         repository.GameRepository
+	seedRepo         repository.SeedRepository
+	verificationRepo repository.VerificationRepository
+
+	refuseNonProduction bool
+	// offeredDice are the expressions players may roll instead of one d6,
+	// keyed by normalized notation, in the order they were offered.
+	offeredDice map[string]*dice.Expression
+	diceTables  []string
+	// drawPolicies resolve the draws of the variants they are keyed by;
+	// other variants keep their draws.
+	drawPolicies map[string]rules.DrawPolicy
+
+	entropyRepo   repository.EntropyRepository
+	entropySealer EntropySealer
 }
 
-func NewGameService(randomService RandomServiceInterface, gameRepo repository.GameRepository) *GameService {
+func NewGameService(
+	randomService RandomServiceInterface,
+	gameRepo repository.GameRepository,
+	seedRepo repository.SeedRepository,
+	verificationRepo repository.VerificationRepository,
+) *GameService {
 	return &GameService{
-		randomService: randomService,
-		gameRepo:      gameRepo,
+		randomService:    randomService,
+		gameRepo:         gameRepo,
+		seedRepo:         seedRepo,
+		verificationRepo: verificationRepo,
+	}
+}
+
+// RefuseNonProductionGames makes PlayGame fail instead of playing with a
+// NonProductionGenerator. It is set when running in production.
+func (s *GameService) RefuseNonProductionGames() {
+	s.refuseNonProduction = true
+}
+
+// OfferDice lets players roll the given dice expressions instead of a
+// single d6.
+func (s *GameService) OfferDice(notations []string) error {
+	offered := make(map[string]*dice.Expression, len(notations))
+	tables := make([]string, 0, len(notations))
+	for _, notation := range notations {
+		expr, err := dice.Parse(notation)
+		if err != nil {
+			return err
+		}
+		if _, ok := offered[expr.String()]; !ok {
+			offered[expr.String()] = expr
+			tables = append(tables, expr.String())
+		}
+	}
+
+	s.offeredDice = offered
+	s.diceTables = tables
+	return nil
+}
+
+// ResolveDraws sets the draw policy of each variant named in policies, in
+// the notation of rules.ParseDrawPolicy. Variants without a policy keep
+// their draws.
+func (s *GameService) ResolveDraws(policies map[string]string) error {
+	resolved := make(map[string]rules.DrawPolicy, len(policies))
+	for name, notation := range policies {
+		variant, err := rules.Builtin().Get(name)
+		if err != nil {
+			return err
+		}
+		policy, err := rules.ParseDrawPolicy(notation)
+		if err != nil {
+			return fmt.Errorf("variant %s: %w", name, err)
+		}
+		resolved[variant.Name()] = policy
+	}
+
+	s.drawPolicies = resolved
+	return nil
+}
+
+// drawPolicy returns the policy the draws of the named variant are
+// resolved by.
+func (s *GameService) drawPolicy(variant string) rules.DrawPolicy {
+	if policy, ok := s.drawPolicies[variant]; ok {
+		return policy
+	}
+	return rules.KeepDraws
+}
+
+// ListDiceTables returns the offered dice expressions in normalized
+// notation.
+func (s *GameService) ListDiceTables() []string {
+	return s.diceTables
+}
+
+// PlayGame plays a game of the named variant, classic when variantName is
+// empty, with the requested generator, or with the one the selection
+// strategy picks when generatorName is empty. Variants that let players
+// pick their dice roll the offered expression diceNotation, or a single d6
+// when it is empty. Drawn rounds are resolved by the variant's draw policy;
+// all values a re-rolling policy may need are drawn with the first round.
+func (s *GameService) PlayGame(ctx context.Context, playerID, clientSeed, generatorName, diceNotation, variantName string) (*model.GameResult, error) {
+	game, err := s.gameRuleset(variantName, diceNotation)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.playGame(ctx, playerID, clientSeed, generatorName, game, nil)
+}
+
+// gameRuleset returns the ruleset new games of the named variant are played
+// by, with the offered dice expression diceNotation when it is not empty.
+func (s *GameService) gameRuleset(variantName, diceNotation string) (*ruleset, error) {
+	if variantName == "" {
+		variantName = rules.Classic
+	}
+	variant, err := rules.Builtin().Get(variantName)
+	if err != nil {
+		return nil, err
+	}
+
+	var requested *dice.Expression
+	if diceNotation != "" {
+		if requested, err = s.diceExpression(diceNotation); err != nil {
+			return nil, err
+		}
 	}
+
+	expr, err := variant.Dice(requested)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiceNotOffered, err)
+	}
+
+	return &ruleset{variant: variant, policy: s.drawPolicy(variant.Name()), expr: expr}, nil
 }
 
-func (s *GameService) PlayGame(ctx context.Context, playerID string) (*model.GameResult, error) {
-	generator, err := s.randomService.GetRandomGenerator()
+// matchRound places a game in a match.
+type matchRound struct {
+	matchID string
+	round   int
+}
+
+// playGame plays and saves a game by game, as a round of a match when
+// match is not nil.
+func (s *GameService) playGame(ctx context.Context, playerID, clientSeed, generatorName string, game *ruleset, match *matchRound) (*model.GameResult, error) {
+	generator, strategy, err := s.randomService.SelectGenerator(playerID, generatorName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get random generator: %w", err)
 	}
+	defer s.randomService.ReleaseGenerator(generator.Name())
 
-	playerDice, err := generator.Generate(1, 6)
+	nonProduction := false
+	if g, ok := generator.(NonProductionGenerator); ok && g.NonProduction() {
+		if s.refuseNonProduction {
+			return nil, fmt.Errorf("generator %s may not be used in production", generator.Name())
+		}
+		nonProduction = true
+	}
+
+	if clientSeed == "" {
+		clientSeed, err = random.NewClientSeed()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client seed: %w", err)
+		}
+	}
+
+	nonce, err := s.gameRepo.NextNonce(ctx, playerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate player dice: %w", err)
+		return nil, fmt.Errorf("failed to get player nonce: %w", err)
+	}
+	// The game is committed once its nonce is reserved; a beacon game is
+	// bound to the first round published after this moment.
+	playedAt := time.Now()
+
+	plan := game.plan()
+	req := random.RollRequest{
+		PlayerID:   playerID,
+		ClientSeed: clientSeed,
+		Nonce:      nonce,
+		Count:      plan.Count,
+		Min:        plan.Min,
+		Max:        plan.Max,
+		// Entropy is only recorded when it is stored with the game.
+		RecordEntropy: s.entropyRepo != nil,
 	}
 
-	serverDice, err := generator.Generate(1, 6)
+	var (
+		roll   *random.Roll
+		beacon *random.BeaconRound
+	)
+	switch g := generator.(type) {
+	case SeedChainGenerator:
+		var link *model.SeedChainLink
+		link, err = s.randomService.ConsumeSeedChainLink(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seed chain link: %w", err)
+		}
+		roll, err = g.RollWithServerSeed(link.Seed, req)
+	case BeaconGenerator:
+		// The server seed is fixed before the round is published, so the
+		// server cannot rotate to a seed that suits the round's randomness.
+		serverSeed := g.ServerSeed()
+		beacon, err = g.NextBeaconRound(ctx, playedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get beacon round: %w", err)
+		}
+		roll, err = g.RollWithBeacon(serverSeed, beacon, req)
+	default:
+		roll, err = generator.Roll(req)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate server dice: %w", err)
+		return nil, fmt.Errorf("failed to roll dice: %w", err)
 	}
 
-	var winner model.Winner
-	if playerDice > serverDice {
-		winner = model.WinnerPlayer
-	} else if serverDice > playerDice {
-		winner = model.WinnerServer
-	} else {
-		winner = model.WinnerDraw
+	if len(roll.Values) != req.Count {
+		return nil, fmt.Errorf("generator %s returned %d dice, expected %d", generator.Name(), len(roll.Values), req.Count)
 	}
 
-	now := time.Now()
-	gameID := uuid.New().String()
+	if req.RecordEntropy {
+		if err := checkRollEntropy(generator.Name(), req, roll); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.randomService.ObserveRoll(generator.Name(), req.Min, req.Max, roll.Values); err != nil {
+		return nil, fmt.Errorf("generator failed health tests: %w", err)
+	}
 
-	var verificationKey string
-	if verifiableGenerator, ok := generator.(VerifiableGenerator); ok {
-		verificationKey = verifiableGenerator.GetVerificationData()
+	played, err := game.play(roll.Values)
+	if err != nil {
+		return nil, err
 	}
+	last := played.rounds[len(played.rounds)-1]
+
+	gameID := uuid.New().String()
 
 	result := &model.GameResult{
-		GameID:          gameID,
-		PlayerID:        playerID,
-		PlayerDice:      playerDice,
-		ServerDice:      serverDice,
-		Winner:          winner,
-		PlayedAt:        now,
-		GeneratorUsed:   generator.Name(),
-		VerificationKey: verificationKey,
+		GameID:            gameID,
+		PlayerID:          playerID,
+		PlayerDice:        last.PlayerDice,
+		ServerDice:        last.ServerDice,
+		PlayerRolls:       last.PlayerRolls,
+		ServerRolls:       last.ServerRolls,
+		Winner:            played.winner,
+		PlayedAt:          playedAt,
+		GeneratorUsed:     generator.Name(),
+		VerificationKey:   roll.Proof,
+		ClientSeed:        clientSeed,
+		Nonce:             nonce,
+		AlgorithmVersion:  roll.AlgorithmVersion,
+		SelectionStrategy: strategy,
+		NonProduction:     nonProduction,
+		Variant:           game.variant.Name(),
+		VariantVersion:    game.variant.Version(),
+		Payout:            played.payout,
+		DrawPolicy:        game.policy.String(),
+		Rounds:            played.rounds,
+	}
+	if game.expr != rules.ClassicDice {
+		result.Dice = game.expr.String()
+	}
+	if beacon != nil {
+		result.BeaconRound = beacon.Round
+		result.BeaconRandomness = beacon.Randomness
+		result.BeaconPublishedAt = beacon.PublishedAt
+	}
+	if match != nil {
+		result.MatchID = match.matchID
+		result.MatchRound = match.round
 	}
 
-	if err := s.gameRepo.SaveGameResult(ctx, result); err != nil {
+	if req.RecordEntropy {
+		err = s.saveGameWithEntropy(ctx, result, req, roll)
+	} else {
+		err = s.gameRepo.SaveGameResult(ctx, result)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to save game result: %w", err)
 	}
 
 	return result, nil
 }
 
+func (s *GameService) diceExpression(notation string) (*dice.Expression, error) {
+	expr, err := dice.Parse(notation)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiceNotOffered, err)
+	}
+
+	offered, ok := s.offeredDice[expr.String()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrDiceNotOffered, expr)
+	}
+
+	return offered, nil
+}
+
+// ListVariants returns the latest version of every game variant with its
+// draw policy.
+func (s *GameService) ListVariants() []model.GameVariant {
+	variants := rules.Builtin().List()
+
+	result := make([]model.GameVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = model.GameVariant{
+			Name:       variant.Name(),
+			Version:    variant.Version(),
+			DrawPolicy: s.drawPolicy(variant.Name()).String(),
+		}
+	}
+
+	return result
+}
+
+func (s *GameService) ListGenerators() []model.GeneratorInfo {
+	return s.randomService.ListGenerators()
+}
+
+func (s *GameService) GetGeneratorHealth() []model.GeneratorHealth {
+	return s.randomService.GetGeneratorHealth()
+}
+
 func (s *GameService) GetGameResult(ctx context.Context, gameID string) (*model.GameResult, error) {
 	return s.gameRepo.GetGameResult(ctx, gameID)
 }
 
-func (s *GameService) VerifyGame(ctx context.Context, gameID, clientSeed string) (bool, error) {
+// VerifyGame recomputes the game from its stored client seed and nonce. A
+// non-empty clientSeed must match the one the game was played with. Every
+// check of an existing game is recorded together with its outcome; if the
+// record cannot be saved the verdict is withheld.
+func (s *GameService) VerifyGame(ctx context.Context, gameID, clientSeed, requestedBy string) (bool, error) {
 	result, err := s.gameRepo.GetGameResult(ctx, gameID)
 	if err != nil {
 		return false, fmt.Errorf("failed to get game result: %w", err)
 	}
 
-	if result.GeneratorUsed != "provably_fair" {
-		return false, fmt.Errorf("game was not played with a verifiable generator")
+	isValid, verifyErr := s.verifyGameResult(ctx, result, clientSeed)
+
+	record := &model.VerificationRecord{
+		GameID:           result.GameID,
+		PlayerID:         result.PlayerID,
+		RequestedBy:      requestedBy,
+		VerificationData: clientSeed,
+		IsValid:          isValid,
+		VerifiedAt:       time.Now(),
+	}
+	if verifyErr != nil {
+		record.Error = verifyErr.Error()
 	}
 
-	if result.VerificationKey == "" {
-		return false, fmt.Errorf("verification data is missing for this game")
+	if err := s.verificationRepo.SaveVerificationRecord(ctx, record); err != nil {
+		return false, fmt.Errorf("failed to save verification record: %w", err)
 	}
 
-	parts := strings.Split(result.VerificationKey, ":")
-	if len(parts) != 3 {
-		return false, fmt.Errorf("invalid verification data format")
+	return isValid, verifyErr
+}
+
+// ListVerifications returns recorded checks for a game or, when gameID is
+// empty, for every game of a player.
+func (s *GameService) ListVerifications(ctx context.Context, gameID, playerID string, limit, offset int) ([]*model.VerificationRecord, error) {
+	var (
+		records []*model.VerificationRecord
+		err     error
+	)
+
+	switch {
+	case gameID != "":
+		records, err = s.verificationRepo.GetVerificationRecordsByGame(ctx, gameID, limit, offset)
+	case playerID != "":
+		records, err = s.verificationRepo.GetVerificationRecordsByPlayer(ctx, playerID, limit, offset)
+	default:
+		return nil, fmt.Errorf("either game ID or player ID is required")
 	}
 
-	serverSeed := parts[0]
-	nonce, err := strconv.Atoi(parts[1])
 	if err != nil {
-		return false, fmt.Errorf("invalid nonce in verification data: %w", err)
+		return nil, fmt.Errorf("failed to list verifications: %w", err)
 	}
-	originalHash := parts[2]
 
-	combinedData := fmt.Sprintf("%s:%s:%d", serverSeed, clientSeed, nonce)
-	hasher := sha256.New()
-	hasher.Write([]byte(combinedData))
-	calculatedHash := hex.EncodeToString(hasher.Sum(nil))
+	return records, nil
+}
 
-	if calculatedHash != originalHash {
-		return false, nil
+func (s *GameService) verifyGameResult(ctx context.Context, result *model.GameResult, clientSeed string) (bool, error) {
+	switch result.GeneratorUsed {
+	case "provably_fair", "hash_chain", "beacon", "vrf":
+	default:
+		return false, ErrNotVerifiable
 	}
 
-	playerDice, err := calculateDiceValue(calculatedHash[:8], 1, 6)
-	if err != nil {
-		return false, fmt.Errorf("failed to calculate player dice: %w", err)
+	if result.VerificationKey == "" {
+		return false, fmt.Errorf("verification data is missing for this game")
 	}
 
-	serverDice, err := calculateDiceValue(calculatedHash[8:16], 1, 6)
+	var err error
+	if result.GeneratorUsed == "vrf" {
+		_, err = random.ParseVRFProof(result.VerificationKey)
+	} else {
+		_, err = random.ParseVerificationKey(result.VerificationKey)
+	}
 	if err != nil {
-		return false, fmt.Errorf("failed to calculate server dice: %w", err)
+		return false, err
 	}
 
-	if playerDice != result.PlayerDice || serverDice != result.ServerDice {
+	if clientSeed != "" && clientSeed != result.ClientSeed {
 		return false, nil
 	}
 
+	if result.GeneratorUsed == "vrf" {
+		err = s.checkVRFGame(result)
+	} else {
+		err = s.checkSeededGame(ctx, result)
+	}
+
+	if err != nil {
+		var mismatch *MismatchError
+		if errors.As(err, &mismatch) {
+			return false, nil
+		}
+		return false, err
+	}
+
 	return true, nil
 }
 
-func calculateDiceValue(hexPart string, min, max int) (int, error) {
-	num, err := hex.DecodeString(hexPart)
+// checkSeededGame verifies a provably fair, hash chain or beacon game once
+// its server seed is known.
+func (s *GameService) checkSeededGame(ctx context.Context, result *model.GameResult) error {
+	key, err := random.ParseVerificationKey(result.VerificationKey)
+	if err != nil {
+		return err
+	}
+
+	if result.GeneratorUsed == "hash_chain" {
+		serverSeed, err := s.seedChainServerSeed(ctx, key.ServerSeedHash)
+		if err != nil {
+			return err
+		}
+		return CheckProvablyFair(result, serverSeed)
+	}
+
+	serverSeed, err := s.revealedServerSeed(ctx, key.ServerSeedHash)
+	if err != nil {
+		return err
+	}
+
+	if result.GeneratorUsed == "beacon" {
+		round, err := s.beaconRound(ctx, result.BeaconRound)
+		if err != nil {
+			return err
+		}
+		return CheckBeacon(result, serverSeed.Seed, serverSeed.CreatedAt, round)
+	}
+
+	return CheckProvablyFair(result, serverSeed.Seed)
+}
+
+// beaconRound fetches a past round from the running beacon generator.
+func (s *GameService) beaconRound(ctx context.Context, round int64) (*random.BeaconRound, error) {
+	generator, err := s.randomService.GetGeneratorByName("beacon")
 	if err != nil {
-		return 0, fmt.Errorf("failed to decode hex: %w", err)
+		return nil, fmt.Errorf("beacon generator is not configured: %w", err)
 	}
 
-	var value int = 0
-	for _, b := range num {
-		value = (value << 8) | int(b)
+	beacon, ok := generator.(BeaconGenerator)
+	if !ok {
+		return nil, fmt.Errorf("generator %s does not read a beacon", generator.Name())
 	}
 
-	rangeSize := max - min + 1
-	result := (value % rangeSize) + min
+	result, err := beacon.BeaconRound(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get beacon round %d: %w", round, err)
+	}
 
 	return result, nil
 }
 
-func hexToDiceValue(hexPart string) (int, error) {
-	value, err := strconv.ParseInt(hexPart, 16, 64)
+// checkVRFGame verifies a VRF game against the public key of the running
+// VRF generator; nothing has to be revealed first.
+func (s *GameService) checkVRFGame(result *model.GameResult) error {
+	generator, err := s.randomService.GetGeneratorByName("vrf")
 	if err != nil {
-		return 0, err
+		return fmt.Errorf("vrf generator is not configured: %w", err)
 	}
 
-	return int(value%6) + 1, nil
+	vrfGenerator, ok := generator.(VRFGenerator)
+	if !ok {
+		return fmt.Errorf("generator %s does not expose a vrf public key", generator.Name())
+	}
+
+	return CheckVRF(result, vrfGenerator.PublicKey())
+}
+
+func (s *GameService) revealedServerSeed(ctx context.Context, seedHash string) (*model.ServerSeed, error) {
+	serverSeed, err := s.seedRepo.GetServerSeed(ctx, seedHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server seed: %w", err)
+	}
+
+	if !serverSeed.IsRevealed() {
+		return nil, fmt.Errorf("%w, rotate the seed first", ErrSeedNotRevealed)
+	}
+
+	return serverSeed, nil
+}
+
+// seedChainServerSeed returns the seed of a consumed chain link once the link
+// is shown to belong to its committed chain.
+func (s *GameService) seedChainServerSeed(ctx context.Context, seedHash string) (string, error) {
+	link, err := s.randomService.VerifySeedChainLink(ctx, seedHash)
+	if err != nil {
+		return "", err
+	}
+
+	return link.Seed, nil
+}
+
+// GetSeedChain returns the chain a hash chain game was played on together
+// with the game's link. With an empty gameID it returns the active chain
+// and a nil link.
+func (s *GameService) GetSeedChain(ctx context.Context, gameID string) (*model.SeedChain, *model.SeedChainLink, error) {
+	if gameID == "" {
+		chain, err := s.randomService.GetActiveSeedChain(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get active seed chain: %w", err)
+		}
+		if chain == nil {
+			return nil, nil, fmt.Errorf("no active seed chain")
+		}
+		return chain, nil, nil
+	}
+
+	result, err := s.gameRepo.GetGameResult(ctx, gameID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get game result: %w", err)
+	}
+
+	if result.GeneratorUsed != "hash_chain" {
+		return nil, nil, fmt.Errorf("game was not played on a seed chain")
+	}
+
+	key, err := random.ParseVerificationKey(result.VerificationKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	link, chain, err := s.randomService.GetSeedChainLink(ctx, key.ServerSeedHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return chain, link, nil
+}
+
+// RotateSeed reveals the active provably fair server seed and commits to a
+// fresh one. The returned next seed carries only its commitment.
+func (s *GameService) RotateSeed(ctx context.Context) (*model.ServerSeed, *model.ServerSeed, error) {
+	generator, err := s.randomService.GetGeneratorByName("provably_fair")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get provably fair generator: %w", err)
+	}
+
+	rotatable, ok := generator.(SeedRotatableGenerator)
+	if !ok {
+		return nil, nil, fmt.Errorf("generator %s does not support seed rotation", generator.Name())
+	}
+
+	active, err := s.seedRepo.GetServerSeed(ctx, rotatable.ServerSeedHash())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get active server seed: %w", err)
+	}
+
+	nextSeed, err := random.NewServerSeed()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate server seed: %w", err)
+	}
+
+	now := time.Now()
+	next := &model.ServerSeed{
+		Seed:      nextSeed,
+		Hash:      random.HashServerSeed(nextSeed),
+		CreatedAt: now,
+	}
+
+	if err := s.seedRepo.RotateServerSeed(ctx, active.Hash, now, next); err != nil {
+		return nil, nil, fmt.Errorf("failed to rotate server seed: %w", err)
+	}
+
+	rotatable.RotateServerSeed(next.Seed)
+	active.RevealedAt = &now
+
+	return active, &model.ServerSeed{Hash: next.Hash, CreatedAt: next.CreatedAt}, nil
 }
@@ -6,7 +6,15 @@ import (
 )
 
 type GameServiceInterface interface {
-	PlayGame(ctx context.Context, playerID string) (*model.GameResult, error)
-	VerifyGame(ctx context.Context, gameID string, verificationData string) (bool, error)
+	PlayGame(ctx context.Context, playerID string, clientSeed string, generatorName string, diceNotation string, variantName string) (*model.GameResult, error)
+	VerifyGame(ctx context.Context, gameID string, verificationData string, requestedBy string) (bool, error)
+	ListVerifications(ctx context.Context, gameID string, playerID string, limit, offset int) ([]*model.VerificationRecord, error)
 	GetGameResult(ctx context.Context, gameID string) (*model.GameResult, error)
+	RotateSeed(ctx context.Context) (revealed *model.ServerSeed, next *model.ServerSeed, err error)
+	GetSeedChain(ctx context.Context, gameID string) (*model.SeedChain, *model.SeedChainLink, error)
+	GetGeneratorHealth() []model.GeneratorHealth
+	ListGenerators() []model.GeneratorInfo
+	ListDiceTables() []string
+	ListVariants() []model.GameVariant
+	AuditEntropy(ctx context.Context, gameID string) (*model.EntropyAudit, error)
 }
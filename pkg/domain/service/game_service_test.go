@@ -3,25 +3,44 @@ package service
 import (
 	"context"
 	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/rules"
 	"dice-game/pkg/infrastructure/random"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockRandomService struct {
 	mock.Mock
 }
 
-func (m *MockRandomService) GetRandomGenerator() (random.Generator, error) {
+func (m *MockRandomService) SelectGenerator(playerID, requested string) (random.Generator, string, error) {
+	args := m.Called(playerID, requested)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(random.Generator), args.String(1), args.Error(2)
+}
+
+func (m *MockRandomService) UseSelector(selector GeneratorSelector) {
+	m.Called(selector)
+}
+
+func (m *MockRandomService) AllowGeneratorChoice(names []string) {
+	m.Called(names)
+}
+
+func (m *MockRandomService) ListGenerators() []model.GeneratorInfo {
 	args := m.Called()
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil
 	}
-	return args.Get(0).(random.Generator), args.Error(1)
+	return args.Get(0).([]model.GeneratorInfo)
 }
 
 func (m *MockRandomService) GetGeneratorByName(name string) (random.Generator, error) {
@@ -32,8 +51,91 @@ func (m *MockRandomService) GetGeneratorByName(name string) (random.Generator, e
 	return args.Get(0).(random.Generator), args.Error(1)
 }
 
-func (m *MockRandomService) AddGenerator(generator random.Generator) {
-	m.Called(generator)
+func (m *MockRandomService) AddGenerator(generator random.Generator) error {
+	args := m.Called(generator)
+	return args.Error(0)
+}
+
+func (m *MockRandomService) ReleaseGenerator(name string) {
+	m.Called(name)
+}
+
+func (m *MockRandomService) RemoveGenerator(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockRandomService) EnableGenerator(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockRandomService) ResetGeneratorHealth(name string) bool {
+	args := m.Called(name)
+	return args.Bool(0)
+}
+
+func (m *MockRandomService) DisableGenerator(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockRandomService) DrainGenerator(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockRandomService) CreateSeedChain(ctx context.Context, length int) (*model.SeedChain, error) {
+	args := m.Called(ctx, length)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SeedChain), args.Error(1)
+}
+
+func (m *MockRandomService) GetActiveSeedChain(ctx context.Context) (*model.SeedChain, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SeedChain), args.Error(1)
+}
+
+func (m *MockRandomService) ConsumeSeedChainLink(ctx context.Context) (*model.SeedChainLink, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SeedChainLink), args.Error(1)
+}
+
+func (m *MockRandomService) GetSeedChainLink(ctx context.Context, seedHash string) (*model.SeedChainLink, *model.SeedChain, error) {
+	args := m.Called(ctx, seedHash)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.SeedChainLink), args.Get(1).(*model.SeedChain), args.Error(2)
+}
+
+func (m *MockRandomService) VerifySeedChainLink(ctx context.Context, seedHash string) (*model.SeedChainLink, error) {
+	args := m.Called(ctx, seedHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SeedChainLink), args.Error(1)
+}
+
+func (m *MockRandomService) ObserveRoll(generator string, min, max int, values []int) error {
+	args := m.Called(generator, min, max, values)
+	return args.Error(0)
+}
+
+func (m *MockRandomService) GetGeneratorHealth() []model.GeneratorHealth {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]model.GeneratorHealth)
 }
 
 type MockGenerator struct {
@@ -45,20 +147,83 @@ func (m *MockGenerator) Generate(min, max int) (int, error) {
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockGenerator) Roll(req random.RollRequest) (*random.Roll, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*random.Roll), args.Error(1)
+}
+
 func (m *MockGenerator) Name() string {
 	args := m.Called()
 	return args.String(0)
 }
 
-type MockVerifiableGenerator struct {
+type MockSeedRotatableGenerator struct {
 	MockGenerator
 }
 
-func (m *MockVerifiableGenerator) GetVerificationData() string {
+func (m *MockSeedRotatableGenerator) ServerSeedHash() string {
 	args := m.Called()
 	return args.String(0)
 }
 
+func (m *MockSeedRotatableGenerator) RotateServerSeed(serverSeed string) string {
+	args := m.Called(serverSeed)
+	return args.String(0)
+}
+
+type MockBeaconSource struct {
+	mock.Mock
+}
+
+func (m *MockBeaconSource) RoundAfter(ctx context.Context, t time.Time) (*random.BeaconRound, error) {
+	args := m.Called(ctx, t)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*random.BeaconRound), args.Error(1)
+}
+
+func (m *MockBeaconSource) Round(ctx context.Context, round int64) (*random.BeaconRound, error) {
+	args := m.Called(ctx, round)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*random.BeaconRound), args.Error(1)
+}
+
+type MockSeedRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeedRepository) SaveServerSeed(ctx context.Context, seed *model.ServerSeed) error {
+	args := m.Called(ctx, seed)
+	return args.Error(0)
+}
+
+func (m *MockSeedRepository) GetServerSeed(ctx context.Context, seedHash string) (*model.ServerSeed, error) {
+	args := m.Called(ctx, seedHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ServerSeed), args.Error(1)
+}
+
+func (m *MockSeedRepository) GetActiveServerSeed(ctx context.Context) (*model.ServerSeed, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ServerSeed), args.Error(1)
+}
+
+func (m *MockSeedRepository) RotateServerSeed(ctx context.Context, revealHash string, revealedAt time.Time, next *model.ServerSeed) error {
+	args := m.Called(ctx, revealHash, revealedAt, next)
+	return args.Error(0)
+}
+
 type MockGameRepository struct {
 	mock.Mock
 }
@@ -89,24 +254,83 @@ func (m *MockGameRepository) GetTotalGames(ctx context.Context) (int, error) {
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockGameRepository) GetGameResultsByDay(ctx context.Context, day time.Time) ([]*model.GameResult, error) {
+	args := m.Called(ctx, day)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.GameResult), args.Error(1)
+}
+
+func (m *MockGameRepository) NextNonce(ctx context.Context, playerID string) (int64, error) {
+	args := m.Called(ctx, playerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockVerificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockVerificationRepository) SaveVerificationRecord(ctx context.Context, record *model.VerificationRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockVerificationRepository) GetVerificationRecordsByGame(ctx context.Context, gameID string, limit, offset int) ([]*model.VerificationRecord, error) {
+	args := m.Called(ctx, gameID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.VerificationRecord), args.Error(1)
+}
+
+func (m *MockVerificationRepository) GetVerificationRecordsByPlayer(ctx context.Context, playerID string, limit, offset int) ([]*model.VerificationRecord, error) {
+	args := m.Called(ctx, playerID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.VerificationRecord), args.Error(1)
+}
+
+func acceptVerificationRecords() *MockVerificationRepository {
+	repo := new(MockVerificationRepository)
+	repo.On("SaveVerificationRecord", mock.Anything, mock.AnythingOfType("*model.VerificationRecord")).Return(nil)
+	return repo
+}
+
+func latestScheme(t *testing.T) random.Scheme {
+	scheme, err := random.SchemeByVersion(random.LatestSchemeVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func diceRollRequest(clientSeed string, nonce int64) random.RollRequest {
+	return random.RollRequest{PlayerID: "test-player", ClientSeed: clientSeed, Nonce: nonce, Count: 2, Min: 1, Max: 6}
+}
+
 func TestPlayGame_Success(t *testing.T) {
 	// Arrange
 	mockRandom := new(MockRandomService)
 	mockRepo := new(MockGameRepository)
 	mockGen := new(MockGenerator)
 
-	mockRandom.On("GetRandomGenerator").Return(mockGen, nil)
-	mockGen.On("Generate", 1, 6).Return(4, nil).Once()
-	mockGen.On("Generate", 1, 6).Return(2, nil).Once()
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", diceRollRequest("client-seed", 1)).Return(&random.Roll{Values: []int{4, 2}}, nil)
 	mockGen.On("Name").Return("test_generator")
 	mockRepo.On("SaveGameResult", mock.Anything, mock.MatchedBy(func(result *model.GameResult) bool {
-		return result.PlayerDice == 4 && result.ServerDice == 2 && result.Winner == model.WinnerPlayer
+		return result.PlayerDice == 4 && result.ServerDice == 2 && result.Winner == model.WinnerPlayer &&
+			result.SelectionStrategy == SelectionRandom
 	})).Return(nil)
 
-	service := NewGameService(mockRandom, mockRepo)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
 
 	// Act
-	result, err := service.PlayGame(context.Background(), "test-player")
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
 
 	// Assert
 	assert.NoError(t, err)
@@ -117,7 +341,13 @@ func TestPlayGame_Success(t *testing.T) {
 	assert.Equal(t, "test_generator", result.GeneratorUsed)
 	assert.Equal(t, "test-player", result.PlayerID)
 	assert.NotEmpty(t, result.GameID)
+	assert.Empty(t, result.VerificationKey)
 	assert.WithinDuration(t, time.Now(), result.PlayedAt, 2*time.Second)
+	assert.Equal(t, rules.Classic, result.Variant)
+	assert.Equal(t, 1, result.VariantVersion)
+	assert.Equal(t, 2.0, result.Payout)
+	assert.Equal(t, "keep", result.DrawPolicy)
+	assert.Equal(t, []model.GameRound{{PlayerDice: 4, ServerDice: 2}}, result.Rounds)
 
 	mockRandom.AssertExpectations(t)
 	mockGen.AssertExpectations(t)
@@ -128,21 +358,23 @@ func TestPlayGame_WithVerifiableGenerator(t *testing.T) {
 	// Arrange
 	mockRandom := new(MockRandomService)
 	mockRepo := new(MockGameRepository)
-	mockGen := new(MockVerifiableGenerator)
+	mockGen := new(MockGenerator)
 
-	mockRandom.On("GetRandomGenerator").Return(mockGen, nil)
-	mockGen.On("Generate", 1, 6).Return(3, nil).Once()
-	mockGen.On("Generate", 1, 6).Return(3, nil).Once()
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", diceRollRequest("client-seed", 1)).
+		Return(&random.Roll{Values: []int{3, 3}, Proof: "testServerSeed:1:testHash"}, nil)
 	mockGen.On("Name").Return("provably_fair")
-	mockGen.On("GetVerificationData").Return("testServerSeed:1:testHash")
 	mockRepo.On("SaveGameResult", mock.Anything, mock.MatchedBy(func(result *model.GameResult) bool {
 		return result.PlayerDice == 3 && result.ServerDice == 3 && result.Winner == model.WinnerDraw
 	})).Return(nil)
 
-	service := NewGameService(mockRandom, mockRepo)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
 
 	// Act
-	result, err := service.PlayGame(context.Background(), "test-player")
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
 
 	// Assert
 	assert.NoError(t, err)
@@ -152,218 +384,914 @@ func TestPlayGame_WithVerifiableGenerator(t *testing.T) {
 	assert.Equal(t, model.WinnerDraw, result.Winner)
 	assert.Equal(t, "provably_fair", result.GeneratorUsed)
 	assert.Equal(t, "testServerSeed:1:testHash", result.VerificationKey)
+	assert.Equal(t, "client-seed", result.ClientSeed)
+	assert.Equal(t, int64(1), result.Nonce)
 
 	mockRandom.AssertExpectations(t)
 	mockGen.AssertExpectations(t)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestPlayGame_GetGeneratorFails(t *testing.T) {
+func TestPlayGame_ProvablyFairProofMatchesDice(t *testing.T) {
 	// Arrange
 	mockRandom := new(MockRandomService)
 	mockRepo := new(MockGameRepository)
-	expectedErr := errors.New("no generators available")
-
-	mockRandom.On("GetRandomGenerator").Return((*MockGenerator)(nil), expectedErr)
-
-	service := NewGameService(mockRandom, mockRepo)
+	mockSeedRepo := new(MockSeedRepository)
+	generator := random.NewProovablyFairGenerator("testServerSeed", latestScheme(t))
+	revealedAt := time.Now()
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(3), nil)
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
+	mockSeedRepo.On("GetServerSeed", mock.Anything, random.HashServerSeed("testServerSeed")).Return(&model.ServerSeed{
+		Seed:       "testServerSeed",
+		Hash:       random.HashServerSeed("testServerSeed"),
+		RevealedAt: &revealedAt,
+	}, nil)
+
+	service := NewGameService(mockRandom, mockRepo, mockSeedRepo, acceptVerificationRecords())
 
 	// Act
-	result, err := service.PlayGame(context.Background(), "test-player")
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+	assert.NoError(t, err)
+	mockRepo.On("GetGameResult", mock.Anything, result.GameID).Return(result, nil)
+	isValid, verifyErr := service.VerifyGame(context.Background(), result.GameID, "", "auditor")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to get random generator")
-
-	mockRandom.AssertExpectations(t)
-	mockRepo.AssertNotCalled(t, "SaveGameResult")
+	assert.NoError(t, verifyErr)
+	assert.True(t, isValid)
 }
 
-func TestPlayGame_PlayerDiceGenerationFails(t *testing.T) {
+func TestPlayGame_OfferedDiceExpression(t *testing.T) {
 	// Arrange
 	mockRandom := new(MockRandomService)
 	mockRepo := new(MockGameRepository)
 	mockGen := new(MockGenerator)
-	expectedErr := errors.New("generation failed")
 
-	mockRandom.On("GetRandomGenerator").Return(mockGen, nil)
-	mockGen.On("Generate", 1, 6).Return(0, expectedErr).Once()
+	values := []int{7, 15, 12, 3}
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 20, values).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", random.RollRequest{PlayerID: "test-player", ClientSeed: "client-seed", Nonce: 1, Count: 4, Min: 1, Max: 20}).
+		Return(&random.Roll{Values: values}, nil)
+	mockGen.On("Name").Return("test_generator")
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
 
-	service := NewGameService(mockRandom, mockRepo)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+	assert.NoError(t, service.OfferDice([]string{"2d20k", "1d6"}))
 
 	// Act
-	result, err := service.PlayGame(context.Background(), "test-player")
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "2D20KH1", "")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to generate player dice")
-
-	mockRandom.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2d20kh1", "1d6"}, service.ListDiceTables())
+	assert.Equal(t, "2d20kh1", result.Dice)
+	assert.Equal(t, []int{7, 15}, result.PlayerRolls)
+	assert.Equal(t, []int{12, 3}, result.ServerRolls)
+	assert.Equal(t, 15, result.PlayerDice)
+	assert.Equal(t, 12, result.ServerDice)
+	assert.Equal(t, model.WinnerPlayer, result.Winner)
 	mockGen.AssertExpectations(t)
-	mockRepo.AssertNotCalled(t, "SaveGameResult")
 }
 
-func TestPlayGame_ServerDiceGenerationFails(t *testing.T) {
+func TestPlayGame_DiceNotOffered(t *testing.T) {
 	// Arrange
 	mockRandom := new(MockRandomService)
-	mockRepo := new(MockGameRepository)
-	mockGen := new(MockGenerator)
-	expectedErr := errors.New("generation failed")
-
-	mockRandom.On("GetRandomGenerator").Return(mockGen, nil)
-	mockGen.On("Generate", 1, 6).Return(4, nil).Once()
-	mockGen.On("Generate", 1, 6).Return(0, expectedErr).Once()
+	service := NewGameService(mockRandom, new(MockGameRepository), new(MockSeedRepository), new(MockVerificationRepository))
+	assert.NoError(t, service.OfferDice([]string{"1d20"}))
 
-	service := NewGameService(mockRandom, mockRepo)
-
-	// Act
-	result, err := service.PlayGame(context.Background(), "test-player")
-
-	// Assert
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to generate server dice")
+	for _, notation := range []string{"3d6", "1d20+1", "not dice"} {
+		// Act
+		_, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", notation, "")
 
-	mockRandom.AssertExpectations(t)
-	mockGen.AssertExpectations(t)
-	mockRepo.AssertNotCalled(t, "SaveGameResult")
+		// Assert
+		assert.ErrorIs(t, err, ErrDiceNotOffered, notation)
+	}
+	mockRandom.AssertNotCalled(t, "SelectGenerator", mock.Anything, mock.Anything)
 }
 
-func TestPlayGame_SaveGameFails(t *testing.T) {
+func TestPlayGame_DiceExpressionProvablyFair(t *testing.T) {
 	// Arrange
 	mockRandom := new(MockRandomService)
 	mockRepo := new(MockGameRepository)
-	mockGen := new(MockGenerator)
-	expectedErr := errors.New("database error")
+	generator := random.NewProovablyFairGenerator("testServerSeed", latestScheme(t))
 
-	mockRandom.On("GetRandomGenerator").Return(mockGen, nil)
-	mockGen.On("Generate", 1, 6).Return(6, nil).Once()
-	mockGen.On("Generate", 1, 6).Return(1, nil).Once()
-	mockGen.On("Name").Return("test_generator")
-	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(expectedErr)
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 60, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(5), nil)
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
 
-	service := NewGameService(mockRandom, mockRepo)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+	assert.NoError(t, service.OfferDice([]string{"4d6!dl1+1d20-1d4"}))
 
 	// Act
-	result, err := service.PlayGame(context.Background(), "test-player")
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "4d6!dl1+1d20-1d4", "")
+	assert.NoError(t, err)
+	fairErr := CheckProvablyFair(result, "testServerSeed")
 
-	// Assert
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to save game result")
+	tampered := *result
+	tampered.PlayerRolls = append([]int(nil), result.PlayerRolls...)
+	tampered.PlayerRolls[len(tampered.PlayerRolls)-1] = 5 - tampered.PlayerRolls[len(tampered.PlayerRolls)-1]
+	tamperedErr := CheckProvablyFair(&tampered, "testServerSeed")
 
-	mockRandom.AssertExpectations(t)
-	mockGen.AssertExpectations(t)
-	mockRepo.AssertExpectations(t)
+	// Assert
+	assert.NoError(t, fairErr)
+	var mismatch *MismatchError
+	if assert.ErrorAs(t, tamperedErr, &mismatch) {
+		assert.Equal(t, "player_rolls", mismatch.Field)
+	}
 }
 
-func TestGetGameResult_Success(t *testing.T) {
+func TestPlayGame_DoublesBeatAll(t *testing.T) {
 	// Arrange
 	mockRandom := new(MockRandomService)
 	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
 
-	expectedResult := &model.GameResult{
-		GameID:        "test-game-id",
-		PlayerID:      "test-player",
-		PlayerDice:    5,
-		ServerDice:    2,
-		Winner:        model.WinnerPlayer,
-		PlayedAt:      time.Now(),
-		GeneratorUsed: "test_generator",
-	}
+	values := []int{2, 2, 6, 5}
 
-	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(expectedResult, nil)
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, values).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", random.RollRequest{PlayerID: "test-player", ClientSeed: "client-seed", Nonce: 1, Count: 4, Min: 1, Max: 6}).
+		Return(&random.Roll{Values: values}, nil)
+	mockGen.On("Name").Return("test_generator")
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
 
-	service := NewGameService(mockRandom, mockRepo)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
 
 	// Act
-	result, err := service.GetGameResult(context.Background(), "test-game-id")
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", rules.DoublesBeatAll)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, expectedResult, result)
-
-	mockRepo.AssertExpectations(t)
+	assert.Equal(t, "2d6", result.Dice)
+	assert.Equal(t, 4, result.PlayerDice)
+	assert.Equal(t, 11, result.ServerDice)
+	assert.Equal(t, model.WinnerPlayer, result.Winner)
+	assert.Equal(t, rules.DoublesBeatAll, result.Variant)
+	assert.Equal(t, 1, result.VariantVersion)
+	assert.Equal(t, 3.0, result.Payout)
+	mockGen.AssertExpectations(t)
 }
 
-func TestGetGameResult_NotFound(t *testing.T) {
+func TestPlayGame_UnknownVariant(t *testing.T) {
 	// Arrange
-	mockRandom := new(MockRandomService)
-	mockRepo := new(MockGameRepository)
-	expectedErr := errors.New("game not found")
+	service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), new(MockVerificationRepository))
 
-	mockRepo.On("GetGameResult", mock.Anything, "non-existent-id").Return(nil, expectedErr)
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "poker")
+
+	// Assert
+	assert.ErrorIs(t, err, rules.ErrUnknownVariant)
+	assert.Nil(t, result)
+}
 
-	service := NewGameService(mockRandom, mockRepo)
+func TestPlayGame_VariantWithFixedDiceRejectsExpression(t *testing.T) {
+	// Arrange
+	service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), new(MockVerificationRepository))
+	assert.NoError(t, service.OfferDice([]string{"1d20"}))
 
 	// Act
-	result, err := service.GetGameResult(context.Background(), "non-existent-id")
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "1d20", rules.SumOfThree)
 
 	// Assert
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrDiceNotOffered)
+	assert.ErrorContains(t, err, "sum-of-three always rolls 3d6")
 	assert.Nil(t, result)
-	assert.Equal(t, expectedErr, err)
-
-	mockRepo.AssertExpectations(t)
 }
 
-func TestVerifyGame_Success(t *testing.T) {
+func TestPlayGame_VariantProvablyFair(t *testing.T) {
 	// Arrange
 	mockRandom := new(MockRandomService)
 	mockRepo := new(MockGameRepository)
+	generator := random.NewProovablyFairGenerator("testServerSeed", latestScheme(t))
 
-	testServerSeed := "testServerSeed"
-	testClientSeed := "testClientSeed"
-	testHash := "01234567890abcdef"
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(7), nil)
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
 
-	gameResult := &model.GameResult{
-		GameID:          "test-game-id",
-		PlayerDice:      3,
-		ServerDice:      2,
-		GeneratorUsed:   "provably_fair",
-		VerificationKey: testServerSeed + ":" + "1" + ":" + testHash,
-	}
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
 
-	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", rules.LowestWins)
+	assert.NoError(t, err)
+	fairErr := CheckProvablyFair(result, "testServerSeed")
 
-	service := NewGameService(mockRandom, mockRepo)
+	stored := *result
+	stored.Variant = "lowest_wins"
+	storedErr := CheckProvablyFair(&stored, "testServerSeed")
 
-	// Act
-	isValid, err := service.VerifyGame(context.Background(), "test-game-id", testClientSeed)
+	tampered := *result
+	tampered.Variant = rules.Classic
+	tamperedErr := CheckProvablyFair(&tampered, "testServerSeed")
 
 	// Assert
-	assert.NoError(t, err, "Verification should not return an error")
-	if !isValid && err == nil {
-		t.Log("Verification returned false, but this could be due to the test environment. " +
-			"In a real scenario with matching dice values, it should return true.")
+	assert.NoError(t, fairErr)
+	assert.NoError(t, storedErr, "games stored with the underscore name verify")
+	assert.Equal(t, rules.LowestWins, result.Variant)
+	assert.Empty(t, result.Dice)
+	if result.Winner == model.WinnerDraw {
+		assert.NoError(t, tamperedErr)
+		return
 	}
+	var mismatch *MismatchError
+	if assert.ErrorAs(t, tamperedErr, &mismatch) {
+		assert.Equal(t, "winner", mismatch.Field)
+	}
+}
 
-	mockRepo.AssertExpectations(t)
+func TestListVariants(t *testing.T) {
+	service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), new(MockVerificationRepository))
+	require.NoError(t, service.ResolveDraws(map[string]string{"sum_of_three": "reroll:3"}))
+
+	variants := service.ListVariants()
+
+	assert.Equal(t, []model.GameVariant{
+		{Name: rules.Classic, Version: 1, DrawPolicy: "keep"},
+		{Name: rules.LowestWins, Version: 1, DrawPolicy: "keep"},
+		{Name: rules.DoublesBeatAll, Version: 1, DrawPolicy: "keep"},
+		{Name: rules.SumOfThree, Version: 1, DrawPolicy: "reroll:3"},
+	}, variants)
 }
 
-func TestVerifyGame_NotProvablyFair(t *testing.T) {
-	// Arrange
+func TestResolveDraws_Invalid(t *testing.T) {
+	service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), new(MockVerificationRepository))
+
+	assert.ErrorIs(t, service.ResolveDraws(map[string]string{"poker": "push"}), rules.ErrUnknownVariant)
+	assert.ErrorContains(t, service.ResolveDraws(map[string]string{rules.Classic: "reroll:1"}), "variant classic")
+}
+
+// playDrawPolicy plays a classic game under policy with a generator that
+// returns values.
+func playDrawPolicy(t *testing.T, policy string, values []int) *model.GameResult {
+	t.Helper()
+
 	mockRandom := new(MockRandomService)
 	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
 
-	gameResult := &model.GameResult{
-		GameID:        "test-game-id",
-		GeneratorUsed: "standard",
-	}
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, values).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", random.RollRequest{PlayerID: "test-player", ClientSeed: "client-seed", Nonce: 1, Count: len(values), Min: 1, Max: 6}).
+		Return(&random.Roll{Values: values}, nil)
+	mockGen.On("Name").Return("test_generator")
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
 
-	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+	require.NoError(t, service.ResolveDraws(map[string]string{rules.Classic: policy}))
 
-	service := NewGameService(mockRandom, mockRepo)
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+	require.NoError(t, err)
+	mockGen.AssertExpectations(t)
 
-	// Act
-	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed")
+	return result
+}
+
+func TestPlayGame_DrawPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		values []int
+		rounds []model.GameRound
+		winner model.Winner
+		payout float64
+	}{
+		{
+			name:   "Re-roll until decided",
+			policy: "reroll:3",
+			values: []int{3, 3, 5, 2, 1, 1},
+			rounds: []model.GameRound{{PlayerDice: 3, ServerDice: 3}, {PlayerDice: 5, ServerDice: 2}},
+			winner: model.WinnerPlayer,
+			payout: 2,
+		},
+		{
+			name:   "Draw stands after the last round",
+			policy: "reroll:2",
+			values: []int{3, 3, 6, 6},
+			rounds: []model.GameRound{{PlayerDice: 3, ServerDice: 3}, {PlayerDice: 6, ServerDice: 6}},
+			winner: model.WinnerDraw,
+			payout: 1,
+		},
+		{
+			name:   "House wins",
+			policy: "house_wins",
+			values: []int{4, 4},
+			rounds: []model.GameRound{{PlayerDice: 4, ServerDice: 4}},
+			winner: model.WinnerServer,
+			payout: 0,
+		},
+		{
+			name:   "Push",
+			policy: "push",
+			values: []int{4, 4},
+			rounds: []model.GameRound{{PlayerDice: 4, ServerDice: 4}},
+			winner: model.WinnerDraw,
+			payout: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := playDrawPolicy(t, tt.policy, tt.values)
+
+			assert.Equal(t, tt.policy, result.DrawPolicy)
+			assert.Equal(t, tt.rounds, result.Rounds)
+			assert.Equal(t, tt.rounds[len(tt.rounds)-1].PlayerDice, result.PlayerDice)
+			assert.Equal(t, tt.rounds[len(tt.rounds)-1].ServerDice, result.ServerDice)
+			assert.Equal(t, tt.winner, result.Winner)
+			assert.Equal(t, tt.payout, result.Payout)
+		})
+	}
+}
+
+func TestPlayGame_RerolledGameProvablyFair(t *testing.T) {
+	// Arrange
+	serverSeed := "testServerSeed"
+	scheme := latestScheme(t)
+
+	// Find a nonce whose first round is a draw, so the game is re-rolled.
+	nonce := int64(1)
+	for ; ; nonce++ {
+		values, _, err := random.ProvablyFairValues(scheme, serverSeed, "test-player", "client-seed", nonce, 6, 1, 6)
+		require.NoError(t, err)
+		if values[0] == values[1] && values[2] != values[3] {
+			break
+		}
+	}
+
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	generator := random.NewProovablyFairGenerator(serverSeed, scheme)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(nonce, nil)
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+	require.NoError(t, service.ResolveDraws(map[string]string{rules.Classic: "reroll:3"}))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+	require.NoError(t, err)
+	fairErr := CheckProvablyFair(result, serverSeed)
+
+	kept := *result
+	kept.DrawPolicy = "keep"
+	keptErr := CheckProvablyFair(&kept, serverSeed)
+
+	// Assert
+	assert.NoError(t, fairErr)
+	assert.Len(t, result.Rounds, 2)
+	var mismatch *MismatchError
+	if assert.ErrorAs(t, keptErr, &mismatch) {
+		assert.Equal(t, "rounds", mismatch.Field)
+	}
+}
+
+func TestPlayGame_GeneratesClientSeedWhenEmpty(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(7), nil)
+	mockGen.On("Roll", mock.MatchedBy(func(req random.RollRequest) bool {
+		return req.ClientSeed != "" && req.Nonce == 7
+	})).Return(&random.Roll{Values: []int{1, 5}}, nil)
+	mockGen.On("Name").Return("test_generator")
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "", "", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.ClientSeed)
+	assert.Equal(t, int64(7), result.Nonce)
+	assert.Equal(t, model.WinnerServer, result.Winner)
+
+	mockGen.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPlayGame_NextNonceFails(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(0), errors.New("database error"))
+	mockGen.On("Name").Return("test_generator")
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get player nonce")
+	mockGen.AssertNotCalled(t, "Roll", mock.Anything)
+	mockRepo.AssertNotCalled(t, "SaveGameResult")
+}
+
+func TestPlayGame_GetGeneratorFails(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	expectedErr := errors.New("no generators available")
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return((*MockGenerator)(nil), "", expectedErr)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get random generator")
+
+	mockRandom.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SaveGameResult")
+}
+
+func TestPlayGame_RollFails(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
+	expectedErr := errors.New("generation failed")
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", diceRollRequest("client-seed", 1)).Return(nil, expectedErr)
+	mockGen.On("Name").Return("test_generator")
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to roll dice")
+
+	mockRandom.AssertExpectations(t)
+	mockGen.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SaveGameResult")
+}
+
+func TestPlayGame_RollReturnsWrongDiceCount(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", diceRollRequest("client-seed", 1)).Return(&random.Roll{Values: []int{4}}, nil)
+	mockGen.On("Name").Return("test_generator")
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "returned 1 dice")
+	mockRepo.AssertNotCalled(t, "SaveGameResult")
+}
+
+func TestPlayGame_RequestedGenerator(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
+
+	mockRandom.On("SelectGenerator", "test-player", "provably_fair").Return(mockGen, SelectionRequested, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", "provably_fair", 1, 6, []int{3, 5}).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", diceRollRequest("client-seed", 1)).Return(&random.Roll{Values: []int{3, 5}, Proof: "proof"}, nil)
+	mockGen.On("Name").Return("provably_fair")
+	mockRepo.On("SaveGameResult", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "provably_fair", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "provably_fair", result.GeneratorUsed)
+	assert.Equal(t, SelectionRequested, result.SelectionStrategy)
+	mockRandom.AssertExpectations(t)
+}
+
+func TestPlayGame_RequestedGeneratorNotAllowed(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+
+	mockRandom.On("SelectGenerator", "test-player", "standard").
+		Return(nil, "", fmt.Errorf("%w: standard", ErrGeneratorNotAllowed))
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "standard", "", "")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, ErrGeneratorNotAllowed))
+	mockRepo.AssertNotCalled(t, "NextNonce", mock.Anything, mock.Anything)
+}
+
+func TestPlayGame_NonProductionGenerator(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	generator := random.NewDeterministicGenerator("qa-seed", 7)
+	expected, _ := random.NewDeterministicGenerator("qa-seed", 7).Roll(diceRollRequest("", 0))
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", "deterministic", 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockRepo.On("SaveGameResult", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.NonProduction)
+	assert.Equal(t, expected.Values, []int{result.PlayerDice, result.ServerDice})
+	assert.Equal(t, expected.Proof, result.VerificationKey)
+}
+
+func TestPlayGame_NonProductionGeneratorRefused(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").
+		Return(random.NewDeterministicGenerator("qa-seed", 1), SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+	service.RefuseNonProductionGames()
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "generator deterministic may not be used in production")
+	mockRepo.AssertNotCalled(t, "SaveGameResult", mock.Anything, mock.Anything)
+}
+
+func TestPlayGame_GeneratorQuarantined(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", "test_generator", 1, 6, []int{6, 6}).
+		Return(errors.New("generator test_generator is quarantined: repetition count test"))
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", diceRollRequest("client-seed", 1)).Return(&random.Roll{Values: []int{6, 6}}, nil)
+	mockGen.On("Name").Return("test_generator")
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "generator failed health tests")
+	mockRepo.AssertNotCalled(t, "SaveGameResult", mock.Anything, mock.Anything)
+}
+
+func TestPlayGame_SaveGameFails(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
+	expectedErr := errors.New("database error")
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", diceRollRequest("client-seed", 1)).Return(&random.Roll{Values: []int{6, 1}}, nil)
+	mockGen.On("Name").Return("test_generator")
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(expectedErr)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to save game result")
+
+	mockRandom.AssertExpectations(t)
+	mockGen.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetGameResult_Success(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+
+	expectedResult := &model.GameResult{
+		GameID:        "test-game-id",
+		PlayerID:      "test-player",
+		PlayerDice:    5,
+		ServerDice:    2,
+		Winner:        model.WinnerPlayer,
+		PlayedAt:      time.Now(),
+		GeneratorUsed: "test_generator",
+	}
+
+	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(expectedResult, nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.GetGameResult(context.Background(), "test-game-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResult, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetGameResult_NotFound(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	expectedErr := errors.New("game not found")
+
+	mockRepo.On("GetGameResult", mock.Anything, "non-existent-id").Return(nil, expectedErr)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.GetGameResult(context.Background(), "non-existent-id")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, expectedErr, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVerifyGame_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockGameRepository)
+	mockVerificationRepo := new(MockVerificationRepository)
+	mockRepo.On("GetGameResult", mock.Anything, "non-existent-id").Return(nil, ErrGameNotFound)
+
+	service := NewGameService(new(MockRandomService), mockRepo, new(MockSeedRepository), mockVerificationRepo)
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "non-existent-id", "", "auditor")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrGameNotFound)
+	assert.False(t, isValid)
+	mockVerificationRepo.AssertNotCalled(t, "SaveVerificationRecord", mock.Anything, mock.Anything)
+}
+
+func TestVerifyGame_Success(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockSeedRepo := new(MockSeedRepository)
+
+	testServerSeed := "testServerSeed"
+	testClientSeed := "testClientSeed"
+	serverSeedHash := random.HashServerSeed(testServerSeed)
+	scheme, _ := random.SchemeByVersion(1)
+	dice, testHash, _ := random.ProvablyFairValues(scheme, testServerSeed, "", testClientSeed, 1, 2, 1, 6)
+	revealedAt := time.Now()
+
+	gameResult := &model.GameResult{
+		GameID:           "test-game-id",
+		PlayerDice:       dice[0],
+		ServerDice:       dice[1],
+		GeneratorUsed:    "provably_fair",
+		VerificationKey:  serverSeedHash + ":1:" + testHash,
+		ClientSeed:       testClientSeed,
+		Nonce:            1,
+		AlgorithmVersion: 1,
+	}
+
+	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+	mockSeedRepo.On("GetServerSeed", mock.Anything, serverSeedHash).Return(&model.ServerSeed{
+		Seed:       testServerSeed,
+		Hash:       serverSeedHash,
+		RevealedAt: &revealedAt,
+	}, nil)
+
+	service := NewGameService(mockRandom, mockRepo, mockSeedRepo, acceptVerificationRecords())
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", testClientSeed, "auditor")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, isValid)
+
+	mockRepo.AssertExpectations(t)
+	mockSeedRepo.AssertExpectations(t)
+}
+
+func TestVerifyGame_UsesStoredClientSeed(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockSeedRepo := new(MockSeedRepository)
+
+	testServerSeed := "testServerSeed"
+	serverSeedHash := random.HashServerSeed(testServerSeed)
+	generator := random.NewProovablyFairGenerator(testServerSeed, latestScheme(t))
+	roll, _ := generator.Roll(diceRollRequest("player-seed", 42))
+	revealedAt := time.Now()
+
+	gameResult := &model.GameResult{
+		GameID:           "test-game-id",
+		PlayerID:         "test-player",
+		PlayerDice:       roll.Values[0],
+		ServerDice:       roll.Values[1],
+		GeneratorUsed:    "provably_fair",
+		VerificationKey:  roll.Proof,
+		ClientSeed:       "player-seed",
+		Nonce:            42,
+		AlgorithmVersion: roll.AlgorithmVersion,
+	}
+
+	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+	mockSeedRepo.On("GetServerSeed", mock.Anything, serverSeedHash).Return(&model.ServerSeed{
+		Seed:       testServerSeed,
+		Hash:       serverSeedHash,
+		RevealedAt: &revealedAt,
+	}, nil)
+
+	service := NewGameService(mockRandom, mockRepo, mockSeedRepo, acceptVerificationRecords())
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "", "auditor")
+	mismatchValid, mismatchErr := service.VerifyGame(context.Background(), "test-game-id", "other-seed", "auditor")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, isValid)
+	assert.NoError(t, mismatchErr)
+	assert.False(t, mismatchValid)
+}
+
+func TestVerifyGame_AlgorithmVersionMismatch(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockSeedRepo := new(MockSeedRepository)
+
+	testServerSeed := "testServerSeed"
+	serverSeedHash := random.HashServerSeed(testServerSeed)
+	scheme, _ := random.SchemeByVersion(2)
+	generator := random.NewProovablyFairGenerator(testServerSeed, scheme)
+	roll, _ := generator.Roll(diceRollRequest("player-seed", 7))
+	revealedAt := time.Now()
+
+	gameResult := &model.GameResult{
+		GameID:           "test-game-id",
+		PlayerID:         "test-player",
+		PlayerDice:       roll.Values[0],
+		ServerDice:       roll.Values[1],
+		GeneratorUsed:    "provably_fair",
+		VerificationKey:  roll.Proof,
+		ClientSeed:       "player-seed",
+		Nonce:            7,
+		AlgorithmVersion: 3,
+	}
+
+	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+	mockSeedRepo.On("GetServerSeed", mock.Anything, serverSeedHash).Return(&model.ServerSeed{
+		Seed:       testServerSeed,
+		Hash:       serverSeedHash,
+		RevealedAt: &revealedAt,
+	}, nil)
+
+	service := NewGameService(mockRandom, mockRepo, mockSeedRepo, acceptVerificationRecords())
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "", "auditor")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, isValid)
+}
+
+func TestVerifyGame_SeedNotRevealed(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockSeedRepo := new(MockSeedRepository)
+
+	serverSeedHash := random.HashServerSeed("testServerSeed")
+
+	gameResult := &model.GameResult{
+		GameID:          "test-game-id",
+		GeneratorUsed:   "provably_fair",
+		VerificationKey: serverSeedHash + ":1:testHash",
+		ClientSeed:      "testClientSeed",
+		Nonce:           1,
+	}
+
+	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+	mockSeedRepo.On("GetServerSeed", mock.Anything, serverSeedHash).Return(&model.ServerSeed{
+		Seed: "testServerSeed",
+		Hash: serverSeedHash,
+	}, nil)
+
+	service := NewGameService(mockRandom, mockRepo, mockSeedRepo, acceptVerificationRecords())
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed", "auditor")
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, isValid)
+	assert.ErrorIs(t, err, ErrSeedNotRevealed)
+
+	mockRepo.AssertExpectations(t)
+	mockSeedRepo.AssertExpectations(t)
+}
+
+func TestVerifyGame_NotProvablyFair(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+
+	gameResult := &model.GameResult{
+		GameID:        "test-game-id",
+		GeneratorUsed: "standard",
+	}
+
+	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), acceptVerificationRecords())
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed", "auditor")
 
 	// Assert
 	assert.Error(t, err)
 	assert.False(t, isValid)
-	assert.Contains(t, err.Error(), "not played with a verifiable generator")
+	assert.ErrorIs(t, err, ErrNotVerifiable)
 
 	mockRepo.AssertExpectations(t)
 }
@@ -381,10 +1309,10 @@ func TestVerifyGame_MissingVerificationKey(t *testing.T) {
 
 	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
 
-	service := NewGameService(mockRandom, mockRepo)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), acceptVerificationRecords())
 
 	// Act
-	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed")
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed", "auditor")
 
 	// Assert
 	assert.Error(t, err)
@@ -407,10 +1335,10 @@ func TestVerifyGame_InvalidVerificationFormat(t *testing.T) {
 
 	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
 
-	service := NewGameService(mockRandom, mockRepo)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), acceptVerificationRecords())
 
 	// Act
-	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed")
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed", "auditor")
 
 	// Assert
 	assert.Error(t, err)
@@ -433,10 +1361,10 @@ func TestVerifyGame_InvalidNonce(t *testing.T) {
 
 	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
 
-	service := NewGameService(mockRandom, mockRepo)
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), acceptVerificationRecords())
 
 	// Act
-	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed")
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed", "auditor")
 
 	// Assert
 	assert.Error(t, err)
@@ -446,31 +1374,634 @@ func TestVerifyGame_InvalidNonce(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-func TestHexToDiceValue(t *testing.T) {
+func TestVerifyGame_RecordsAttempt(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockSeedRepo := new(MockSeedRepository)
+	mockVerificationRepo := new(MockVerificationRepository)
+
+	gameResult := &model.GameResult{
+		GameID:        "test-game-id",
+		PlayerID:      "test-player",
+		GeneratorUsed: "crypto",
+	}
+
+	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+	mockVerificationRepo.On("SaveVerificationRecord", mock.Anything, mock.MatchedBy(func(record *model.VerificationRecord) bool {
+		return record.GameID == "test-game-id" &&
+			record.PlayerID == "test-player" &&
+			record.RequestedBy == "auditor" &&
+			record.VerificationData == "testClientSeed" &&
+			!record.IsValid &&
+			record.Error == "game was not played with a verifiable generator" &&
+			!record.VerifiedAt.IsZero()
+	})).Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, mockSeedRepo, mockVerificationRepo)
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "testClientSeed", "auditor")
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, isValid)
+
+	mockRepo.AssertExpectations(t)
+	mockVerificationRepo.AssertExpectations(t)
+}
+
+func TestVerifyGame_RecordFails(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockVerificationRepo := new(MockVerificationRepository)
+
+	gameResult := &model.GameResult{
+		GameID:        "test-game-id",
+		GeneratorUsed: "crypto",
+	}
+
+	mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+	mockVerificationRepo.On("SaveVerificationRecord", mock.Anything, mock.AnythingOfType("*model.VerificationRecord")).
+		Return(errors.New("database error"))
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), mockVerificationRepo)
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "test-game-id", "", "auditor")
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, isValid)
+	assert.Contains(t, err.Error(), "failed to save verification record")
+
+	mockVerificationRepo.AssertExpectations(t)
+}
+
+func TestVerifyGame_GameNotFoundIsNotRecorded(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockVerificationRepo := new(MockVerificationRepository)
+
+	mockRepo.On("GetGameResult", mock.Anything, "missing").Return(nil, errors.New("game not found"))
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), mockVerificationRepo)
+
+	// Act
+	isValid, err := service.VerifyGame(context.Background(), "missing", "", "auditor")
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, isValid)
+
+	mockVerificationRepo.AssertNotCalled(t, "SaveVerificationRecord", mock.Anything, mock.Anything)
+}
+
+func TestListVerifications(t *testing.T) {
+	records := []*model.VerificationRecord{{ID: 1, GameID: "test-game-id", PlayerID: "test-player", IsValid: true}}
+
+	t.Run("By game", func(t *testing.T) {
+		// Arrange
+		mockVerificationRepo := new(MockVerificationRepository)
+		mockVerificationRepo.On("GetVerificationRecordsByGame", mock.Anything, "test-game-id", 10, 0).Return(records, nil)
+
+		service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), mockVerificationRepo)
+
+		// Act
+		result, err := service.ListVerifications(context.Background(), "test-game-id", "", 10, 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, records, result)
+		mockVerificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("By player", func(t *testing.T) {
+		// Arrange
+		mockVerificationRepo := new(MockVerificationRepository)
+		mockVerificationRepo.On("GetVerificationRecordsByPlayer", mock.Anything, "test-player", 10, 20).Return(records, nil)
+
+		service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), mockVerificationRepo)
+
+		// Act
+		result, err := service.ListVerifications(context.Background(), "", "test-player", 10, 20)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, records, result)
+		mockVerificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing filter", func(t *testing.T) {
+		// Arrange
+		service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), new(MockVerificationRepository))
+
+		// Act
+		result, err := service.ListVerifications(context.Background(), "", "", 10, 0)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		mockVerificationRepo := new(MockVerificationRepository)
+		mockVerificationRepo.On("GetVerificationRecordsByGame", mock.Anything, "test-game-id", 10, 0).
+			Return(nil, errors.New("database error"))
+
+		service := NewGameService(new(MockRandomService), new(MockGameRepository), new(MockSeedRepository), mockVerificationRepo)
+
+		// Act
+		result, err := service.ListVerifications(context.Background(), "test-game-id", "", 10, 0)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to list verifications")
+	})
+}
+
+func TestPlayGame_WithSeedChainGenerator(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	generator := random.NewHashChainGenerator(latestScheme(t))
+	link := &model.SeedChainLink{ChainID: 1, Position: 4, Seed: "link-seed", Hash: random.HashServerSeed("link-seed")}
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	mockRandom.On("ConsumeSeedChainLink", mock.Anything).Return(link, nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(2), nil)
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "hash_chain", result.GeneratorUsed)
+
+	key, err := random.ParseVerificationKey(result.VerificationKey)
+	assert.NoError(t, err)
+	assert.Equal(t, link.Hash, key.ServerSeedHash)
+	assert.NoError(t, CheckProvablyFair(result, link.Seed))
+
+	mockRandom.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPlayGame_SeedChainExhausted(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(random.NewHashChainGenerator(latestScheme(t)), SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ConsumeSeedChainLink", mock.Anything).Return(nil, errors.New("seed chain exhausted"))
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get seed chain link")
+	mockRepo.AssertNotCalled(t, "SaveGameResult", mock.Anything, mock.Anything)
+}
+
+func TestVerifyGame_HashChain(t *testing.T) {
+	link := &model.SeedChainLink{ChainID: 1, Position: 4, Seed: "link-seed", Hash: random.HashServerSeed("link-seed")}
+	roll, _ := random.NewHashChainGenerator(latestScheme(t)).RollWithServerSeed(link.Seed, diceRollRequest("player-seed", 5))
+
+	gameResult := &model.GameResult{
+		GameID:           "test-game-id",
+		PlayerID:         "test-player",
+		PlayerDice:       roll.Values[0],
+		ServerDice:       roll.Values[1],
+		GeneratorUsed:    "hash_chain",
+		VerificationKey:  roll.Proof,
+		ClientSeed:       "player-seed",
+		Nonce:            5,
+		AlgorithmVersion: roll.AlgorithmVersion,
+	}
+
+	t.Run("Link on committed chain", func(t *testing.T) {
+		// Arrange
+		mockRandom := new(MockRandomService)
+		mockRepo := new(MockGameRepository)
+		mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+		mockRandom.On("VerifySeedChainLink", mock.Anything, link.Hash).Return(link, nil)
+
+		service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), acceptVerificationRecords())
+
+		// Act
+		isValid, err := service.VerifyGame(context.Background(), "test-game-id", "", "auditor")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, isValid)
+		mockRandom.AssertExpectations(t)
+	})
+
+	t.Run("Link off chain", func(t *testing.T) {
+		// Arrange
+		mockRandom := new(MockRandomService)
+		mockRepo := new(MockGameRepository)
+		mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+		mockRandom.On("VerifySeedChainLink", mock.Anything, link.Hash).
+			Return(nil, &MismatchError{Field: "terminal_hash", Stored: "a", Computed: "b"})
+
+		service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), acceptVerificationRecords())
+
+		// Act
+		isValid, err := service.VerifyGame(context.Background(), "test-game-id", "", "auditor")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, isValid)
+	})
+}
+
+func TestVerifyGame_VRF(t *testing.T) {
+	generator, _ := random.NewVRFGenerator([]byte("0123456789abcdef0123456789abcdef"))
+	otherGenerator, _ := random.NewVRFGenerator([]byte("fedcba9876543210fedcba9876543210"))
+	roll, _ := generator.Roll(diceRollRequest("player-seed", 5))
+
+	gameResult := &model.GameResult{
+		GameID:          "test-game-id",
+		PlayerID:        "test-player",
+		PlayerDice:      roll.Values[0],
+		ServerDice:      roll.Values[1],
+		GeneratorUsed:   "vrf",
+		VerificationKey: roll.Proof,
+		ClientSeed:      "player-seed",
+		Nonce:           5,
+	}
+
+	tamperedResult := *gameResult
+	tamperedResult.ClientSeed = "other-seed"
+
 	tests := []struct {
-		name        string
-		hexValue    string
-		expected    int
-		expectError bool
+		name      string
+		result    *model.GameResult
+		generator random.Generator
+		isValid   bool
 	}{
-		{"Simple value 1", "0", 1, false},
-		{"Simple value 2", "1", 2, false},
-		{"Simple value 6", "5", 6, false},
-		{"Wrapping value", "6", 1, false},
-		{"Larger value", "ff", 4, false},
-		{"Invalid hex", "g", 0, true},
+		{name: "Valid proof", result: gameResult, generator: generator, isValid: true},
+		{name: "Proof for another input", result: &tamperedResult, generator: generator, isValid: false},
+		{name: "Proof under another key", result: gameResult, generator: otherGenerator, isValid: false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := hexToDiceValue(tt.hexValue)
+			// Arrange
+			mockRandom := new(MockRandomService)
+			mockRepo := new(MockGameRepository)
+			mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(tt.result, nil)
+			mockRandom.On("GetGeneratorByName", "vrf").Return(tt.generator, nil)
+
+			service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), acceptVerificationRecords())
+
+			// Act
+			isValid, err := service.VerifyGame(context.Background(), "test-game-id", "", "auditor")
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.isValid, isValid)
+			mockRandom.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Generator not configured", func(t *testing.T) {
+		// Arrange
+		mockRandom := new(MockRandomService)
+		mockRepo := new(MockGameRepository)
+		mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+		mockRandom.On("GetGeneratorByName", "vrf").Return(nil, errors.New("generator not found"))
+
+		service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), acceptVerificationRecords())
+
+		// Act
+		isValid, err := service.VerifyGame(context.Background(), "test-game-id", "", "auditor")
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, isValid)
+		assert.Contains(t, err.Error(), "vrf generator is not configured")
+	})
+}
+
+func TestPlayGame_WithBeaconGenerator(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	beacon := new(MockBeaconSource)
+	round := &random.BeaconRound{Round: 42, Randomness: "0a0b0c0d", PublishedAt: time.Now().Add(time.Minute)}
+	generator := random.NewBeaconGenerator(random.NewProovablyFairGenerator("server-seed", latestScheme(t)), beacon)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	beacon.On("RoundAfter", mock.Anything, mock.AnythingOfType("time.Time")).Return(round, nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(2), nil)
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "beacon", result.GeneratorUsed)
+	assert.Equal(t, int64(42), result.BeaconRound)
+	assert.Equal(t, round.Randomness, result.BeaconRandomness)
+	assert.Equal(t, round.PublishedAt, result.BeaconPublishedAt)
+	assert.Equal(t, "client-seed", result.ClientSeed)
+	assert.NoError(t, CheckBeacon(result, "server-seed", result.PlayedAt.Add(-time.Hour), round))
+	beacon.AssertCalled(t, "RoundAfter", mock.Anything, result.PlayedAt)
+
+	mockRandom.AssertExpectations(t)
+	beacon.AssertExpectations(t)
+}
+
+func TestPlayGame_BeaconKeepsSeedRotatedWhileWaiting(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	beacon := new(MockBeaconSource)
+	round := &random.BeaconRound{Round: 42, Randomness: "0a0b0c0d", PublishedAt: time.Now().Add(time.Minute)}
+	seeds := random.NewProovablyFairGenerator("server-seed", latestScheme(t))
+	generator := random.NewBeaconGenerator(seeds, beacon)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, mock.Anything).Return(nil)
+	beacon.On("RoundAfter", mock.Anything, mock.AnythingOfType("time.Time")).
+		Run(func(mock.Arguments) { seeds.RotateServerSeed("rotated-seed") }).
+		Return(round, nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(2), nil)
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	require.NoError(t, err)
+	key, err := random.ParseVerificationKey(result.VerificationKey)
+	require.NoError(t, err)
+	assert.Equal(t, random.HashServerSeed("server-seed"), key.ServerSeedHash, "the seed is taken before the round is published")
+	assert.NoError(t, CheckBeacon(result, "server-seed", result.PlayedAt.Add(-time.Hour), round))
+}
+
+func TestPlayGame_BeaconRoundAlreadyPublic(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	beacon := new(MockBeaconSource)
+	stale := &random.BeaconRound{Round: 41, Randomness: "0a0b0c0d", PublishedAt: time.Now().Add(-time.Minute)}
+	generator := random.NewBeaconGenerator(random.NewProovablyFairGenerator("server-seed", latestScheme(t)), beacon)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	beacon.On("RoundAfter", mock.Anything, mock.AnythingOfType("time.Time")).Return(stale, nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(2), nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not after")
+	mockRepo.AssertNotCalled(t, "SaveGameResult", mock.Anything, mock.Anything)
+}
+
+func TestPlayGame_BeaconUnavailable(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	beacon := new(MockBeaconSource)
+	generator := random.NewBeaconGenerator(random.NewProovablyFairGenerator("server-seed", latestScheme(t)), beacon)
+
+	mockRandom.On("SelectGenerator", mock.Anything, "").Return(generator, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	beacon.On("RoundAfter", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, errors.New("connection refused"))
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(2), nil)
+
+	service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+	// Act
+	result, err := service.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get beacon round")
+	mockRepo.AssertNotCalled(t, "SaveGameResult", mock.Anything, mock.Anything)
+}
+
+func TestVerifyGame_Beacon(t *testing.T) {
+	playedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	round := &random.BeaconRound{Round: 42, Randomness: "0a0b0c0d", PublishedAt: playedAt.Add(3 * time.Second)}
+	beacon := new(MockBeaconSource)
+	generator := random.NewBeaconGenerator(random.NewProovablyFairGenerator("server-seed", latestScheme(t)), beacon)
+	roll, _ := generator.RollWithBeacon("server-seed", round, diceRollRequest("player-seed", 5))
+	revealedAt := time.Now()
+
+	gameResult := &model.GameResult{
+		GameID:           "test-game-id",
+		PlayerID:         "test-player",
+		PlayerDice:       roll.Values[0],
+		ServerDice:       roll.Values[1],
+		GeneratorUsed:    "beacon",
+		VerificationKey:  roll.Proof,
+		ClientSeed:       "player-seed",
+		Nonce:            5,
+		AlgorithmVersion: roll.AlgorithmVersion,
+		BeaconRound:      42,
+		PlayedAt:         playedAt,
+	}
 
-			if tt.expectError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.expected, result)
+	tests := []struct {
+		name          string
+		round         *random.BeaconRound
+		seedCreatedAt time.Time
+		isValid       bool
+	}{
+		{name: "Same round", round: round, isValid: true},
+		{name: "Seed committed after the round was published", round: round, seedCreatedAt: round.PublishedAt, isValid: false},
+		{name: "Beacon reports other randomness", round: &random.BeaconRound{Round: 42, Randomness: "ffff", PublishedAt: round.PublishedAt}, isValid: false},
+		{name: "Round published when the game was played", round: &random.BeaconRound{Round: 42, Randomness: "0a0b0c0d", PublishedAt: playedAt}, isValid: false},
+		{name: "Round published before the game", round: &random.BeaconRound{Round: 42, Randomness: "0a0b0c0d", PublishedAt: playedAt.Add(-time.Second)}, isValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRandom := new(MockRandomService)
+			mockRepo := new(MockGameRepository)
+			mockSeedRepo := new(MockSeedRepository)
+			beacon := new(MockBeaconSource)
+			serverSeed := &model.ServerSeed{Seed: "server-seed", Hash: random.HashServerSeed("server-seed"), CreatedAt: playedAt.Add(-time.Hour), RevealedAt: &revealedAt}
+			if !tt.seedCreatedAt.IsZero() {
+				serverSeed.CreatedAt = tt.seedCreatedAt
 			}
+
+			mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(gameResult, nil)
+			mockSeedRepo.On("GetServerSeed", mock.Anything, serverSeed.Hash).Return(serverSeed, nil)
+			mockRandom.On("GetGeneratorByName", "beacon").Return(random.NewBeaconGenerator(nil, beacon), nil)
+			beacon.On("Round", mock.Anything, int64(42)).Return(tt.round, nil)
+
+			service := NewGameService(mockRandom, mockRepo, mockSeedRepo, acceptVerificationRecords())
+
+			// Act
+			isValid, err := service.VerifyGame(context.Background(), "test-game-id", "", "auditor")
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.isValid, isValid)
+			beacon.AssertExpectations(t)
 		})
 	}
 }
+
+func TestGetSeedChain(t *testing.T) {
+	chain := &model.SeedChain{ID: 1, TerminalHash: "terminal", Length: 10, Consumed: 4}
+
+	t.Run("Active chain", func(t *testing.T) {
+		// Arrange
+		mockRandom := new(MockRandomService)
+		mockRandom.On("GetActiveSeedChain", mock.Anything).Return(chain, nil)
+
+		service := NewGameService(mockRandom, new(MockGameRepository), new(MockSeedRepository), new(MockVerificationRepository))
+
+		// Act
+		gotChain, gotLink, err := service.GetSeedChain(context.Background(), "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, chain, gotChain)
+		assert.Nil(t, gotLink)
+	})
+
+	t.Run("Chain of a game", func(t *testing.T) {
+		// Arrange
+		mockRandom := new(MockRandomService)
+		mockRepo := new(MockGameRepository)
+		link := &model.SeedChainLink{ChainID: 1, Position: 4, Seed: "link-seed", Hash: "link-hash"}
+
+		mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(&model.GameResult{
+			GameID:          "test-game-id",
+			GeneratorUsed:   "hash_chain",
+			VerificationKey: "v3:link-hash:1:hash",
+		}, nil)
+		mockRandom.On("GetSeedChainLink", mock.Anything, "link-hash").Return(link, chain, nil)
+
+		service := NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+		// Act
+		gotChain, gotLink, err := service.GetSeedChain(context.Background(), "test-game-id")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, chain, gotChain)
+		assert.Equal(t, link, gotLink)
+	})
+
+	t.Run("Game not on a chain", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(MockGameRepository)
+		mockRepo.On("GetGameResult", mock.Anything, "test-game-id").Return(&model.GameResult{GeneratorUsed: "crypto"}, nil)
+
+		service := NewGameService(new(MockRandomService), mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+
+		// Act
+		_, _, err := service.GetSeedChain(context.Background(), "test-game-id")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestRotateSeed_Success(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockSeedRepo := new(MockSeedRepository)
+	mockGen := new(MockSeedRotatableGenerator)
+
+	activeSeed := &model.ServerSeed{
+		Seed: "activeSeed",
+		Hash: random.HashServerSeed("activeSeed"),
+	}
+
+	mockRandom.On("GetGeneratorByName", "provably_fair").Return(mockGen, nil)
+	mockGen.On("ServerSeedHash").Return(activeSeed.Hash)
+	mockSeedRepo.On("GetServerSeed", mock.Anything, activeSeed.Hash).Return(activeSeed, nil)
+	mockSeedRepo.On("RotateServerSeed", mock.Anything, activeSeed.Hash, mock.AnythingOfType("time.Time"),
+		mock.MatchedBy(func(next *model.ServerSeed) bool {
+			return next.Hash == random.HashServerSeed(next.Seed) && next.Seed != activeSeed.Seed
+		})).Return(nil)
+	mockGen.On("RotateServerSeed", mock.AnythingOfType("string")).Return(activeSeed.Seed)
+
+	service := NewGameService(mockRandom, mockRepo, mockSeedRepo, new(MockVerificationRepository))
+
+	// Act
+	revealed, next, err := service.RotateSeed(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "activeSeed", revealed.Seed)
+	assert.True(t, revealed.IsRevealed())
+	assert.NotEmpty(t, next.Hash)
+	assert.Empty(t, next.Seed)
+
+	mockRandom.AssertExpectations(t)
+	mockGen.AssertExpectations(t)
+	mockSeedRepo.AssertExpectations(t)
+}
+
+func TestRotateSeed_RepositoryFails(t *testing.T) {
+	// Arrange
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockSeedRepo := new(MockSeedRepository)
+	mockGen := new(MockSeedRotatableGenerator)
+
+	activeSeed := &model.ServerSeed{
+		Seed: "activeSeed",
+		Hash: random.HashServerSeed("activeSeed"),
+	}
+
+	mockRandom.On("GetGeneratorByName", "provably_fair").Return(mockGen, nil)
+	mockGen.On("ServerSeedHash").Return(activeSeed.Hash)
+	mockSeedRepo.On("GetServerSeed", mock.Anything, activeSeed.Hash).Return(activeSeed, nil)
+	mockSeedRepo.On("RotateServerSeed", mock.Anything, activeSeed.Hash, mock.Anything, mock.Anything).
+		Return(errors.New("database error"))
+
+	service := NewGameService(mockRandom, mockRepo, mockSeedRepo, new(MockVerificationRepository))
+
+	// Act
+	revealed, next, err := service.RotateSeed(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, revealed)
+	assert.Nil(t, next)
+	assert.Contains(t, err.Error(), "failed to rotate server seed")
+	mockGen.AssertNotCalled(t, "RotateServerSeed", mock.Anything)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/infrastructure/random"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrGeneratorNotFound is returned for names no registered generator has.
+var ErrGeneratorNotFound = errors.New("generator not found")
+
+// GeneratorRegistry holds the generators of a RandomService and their
+// state. It is safe for concurrent use: games pick and release generators
+// while operators register, disable or drain them.
+type GeneratorRegistry struct {
+	mu      sync.RWMutex
+	entries []*registryEntry
+}
+
+type registryEntry struct {
+	generator random.Generator
+	state     model.GeneratorState
+	inFlight  int
+	// drained is closed when a draining generator has no games in flight.
+	drained chan struct{}
+}
+
+// RegisteredGenerator is a snapshot of a registry entry.
+type RegisteredGenerator struct {
+	Generator random.Generator
+	State     model.GeneratorState
+	InFlight  int
+}
+
+// NewGeneratorRegistry returns a registry with generators enabled in the
+// given order.
+func NewGeneratorRegistry(generators []random.Generator) *GeneratorRegistry {
+	r := &GeneratorRegistry{}
+	for _, gen := range generators {
+		r.entries = append(r.entries, &registryEntry{generator: gen, state: model.GeneratorEnabled})
+	}
+	return r
+}
+
+// Register adds an enabled generator. Names must be unique.
+func (r *GeneratorRegistry) Register(generator random.Generator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.find(generator.Name()) != nil {
+		return fmt.Errorf("generator %s is already registered", generator.Name())
+	}
+
+	r.entries = append(r.entries, &registryEntry{generator: generator, state: model.GeneratorEnabled})
+	return nil
+}
+
+// Unregister removes a generator that has no games in flight. Its games can
+// no longer be verified while it is unregistered.
+func (r *GeneratorRegistry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, entry := range r.entries {
+		if entry.generator.Name() != name {
+			continue
+		}
+		if entry.inFlight > 0 {
+			return fmt.Errorf("generator %s has %d games in flight, drain it first", name, entry.inFlight)
+		}
+		r.entries = append(r.entries[:i], r.entries[i+1:]...)
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrGeneratorNotFound, name)
+}
+
+// Enable puts a generator back into rotation.
+func (r *GeneratorRegistry) Enable(name string) error {
+	return r.setState(name, model.GeneratorEnabled)
+}
+
+// Disable takes a generator out of rotation at once; games in flight
+// finish.
+func (r *GeneratorRegistry) Disable(name string) error {
+	return r.setState(name, model.GeneratorDisabled)
+}
+
+// Drain takes a generator out of rotation and waits until its games in
+// flight finish, then disables it. If ctx is done first the generator stays
+// draining and ctx's error is returned; if the generator is enabled or
+// disabled meanwhile the drain is cancelled.
+func (r *GeneratorRegistry) Drain(ctx context.Context, name string) error {
+	r.mu.Lock()
+	entry := r.find(name)
+	if entry == nil {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrGeneratorNotFound, name)
+	}
+	if entry.state != model.GeneratorDraining {
+		entry.state = model.GeneratorDraining
+		entry.drained = make(chan struct{})
+		if entry.inFlight == 0 {
+			entry.finishDrain()
+		}
+	}
+	drained := entry.drained
+	r.mu.Unlock()
+
+	select {
+	case <-drained:
+		r.mu.RLock()
+		state := entry.state
+		r.mu.RUnlock()
+
+		if state != model.GeneratorDisabled {
+			return fmt.Errorf("drain of generator %s was cancelled, it is %s", name, state)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("generator %s still has games in flight: %w", name, ctx.Err())
+	}
+}
+
+// Acquire marks a game in flight on an enabled generator. Every successful
+// Acquire must be followed by a Release.
+func (r *GeneratorRegistry) Acquire(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := r.find(name)
+	if entry == nil {
+		return fmt.Errorf("%w: %s", ErrGeneratorNotFound, name)
+	}
+	if entry.state != model.GeneratorEnabled {
+		return fmt.Errorf("generator %s is %s", name, entry.state)
+	}
+
+	entry.inFlight++
+	return nil
+}
+
+// AcquireSelected marks a game in flight on the enabled generator pick
+// chooses. pick is given the enabled generators in registration order and
+// runs under the registry lock, so the generator cannot be disabled or
+// drained between being picked and being acquired; it must not call back
+// into the registry.
+func (r *GeneratorRegistry) AcquireSelected(pick func(enabled []random.Generator) (random.Generator, error)) (random.Generator, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	generator, err := pick(r.enabled())
+	if err != nil {
+		return nil, err
+	}
+
+	entry := r.find(generator.Name())
+	if entry == nil || entry.state != model.GeneratorEnabled {
+		return nil, fmt.Errorf("picked generator %s is not enabled", generator.Name())
+	}
+
+	entry.inFlight++
+	return generator, nil
+}
+
+func (r *GeneratorRegistry) Release(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := r.find(name)
+	if entry == nil || entry.inFlight == 0 {
+		return
+	}
+
+	entry.inFlight--
+	if entry.inFlight == 0 && entry.state == model.GeneratorDraining {
+		entry.finishDrain()
+	}
+}
+
+// Get returns a registered generator in any state, so games of disabled
+// generators can still be verified.
+func (r *GeneratorRegistry) Get(name string) (random.Generator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if entry := r.find(name); entry != nil {
+		return entry.generator, nil
+	}
+
+	return nil, ErrGeneratorNotFound
+}
+
+// Enabled returns the generators in rotation in registration order.
+func (r *GeneratorRegistry) Enabled() []random.Generator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.enabled()
+}
+
+func (r *GeneratorRegistry) enabled() []random.Generator {
+	generators := make([]random.Generator, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if entry.state == model.GeneratorEnabled {
+			generators = append(generators, entry.generator)
+		}
+	}
+
+	return generators
+}
+
+// Snapshot returns every registered generator in registration order.
+func (r *GeneratorRegistry) Snapshot() []RegisteredGenerator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make([]RegisteredGenerator, 0, len(r.entries))
+	for _, entry := range r.entries {
+		snapshot = append(snapshot, RegisteredGenerator{
+			Generator: entry.generator,
+			State:     entry.state,
+			InFlight:  entry.inFlight,
+		})
+	}
+
+	return snapshot
+}
+
+func (r *GeneratorRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.entries)
+}
+
+func (r *GeneratorRegistry) setState(name string, state model.GeneratorState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := r.find(name)
+	if entry == nil {
+		return fmt.Errorf("%w: %s", ErrGeneratorNotFound, name)
+	}
+
+	if entry.state == model.GeneratorDraining {
+		// Wake up Drain callers; the generator is no longer draining.
+		close(entry.drained)
+	}
+	entry.state = state
+
+	return nil
+}
+
+func (r *GeneratorRegistry) find(name string) *registryEntry {
+	for _, entry := range r.entries {
+		if entry.generator.Name() == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+// finishDrain disables a draining generator and wakes up Drain callers.
+func (e *registryEntry) finishDrain() {
+	e.state = model.GeneratorDisabled
+	close(e.drained)
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/infrastructure/random"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorRegistry_EnableDisable(t *testing.T) {
+	// Arrange
+	randomService := NewRandomService([]random.Generator{random.NewStandardGenerator(), random.NewCryptoGenerator()})
+
+	// Act
+	err := randomService.DisableGenerator("standard")
+
+	// Assert
+	assert.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		generator, _, err := randomService.SelectGenerator("player", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "crypto", generator.Name())
+		randomService.ReleaseGenerator(generator.Name())
+	}
+
+	generator, err := randomService.GetGeneratorByName("standard")
+	assert.NoError(t, err, "disabled generators stay registered for verification")
+	assert.Equal(t, "standard", generator.Name())
+
+	assert.NoError(t, randomService.DisableGenerator("crypto"))
+	_, _, err = randomService.SelectGenerator("player", "")
+	assert.EqualError(t, err, "every random generator is disabled")
+
+	assert.NoError(t, randomService.EnableGenerator("standard"))
+	generator, _, err = randomService.SelectGenerator("player", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "standard", generator.Name())
+
+	assert.True(t, errors.Is(randomService.EnableGenerator("vrf"), ErrGeneratorNotFound))
+}
+
+func TestGeneratorRegistry_RequestedGeneratorDisabled(t *testing.T) {
+	// Arrange
+	randomService := NewRandomService([]random.Generator{random.NewCryptoGenerator()})
+	randomService.AllowGeneratorChoice([]string{"crypto"})
+	_ = randomService.DisableGenerator("crypto")
+
+	// Act
+	_, _, err := randomService.SelectGenerator("player", "crypto")
+
+	// Assert
+	assert.EqualError(t, err, "generator crypto is disabled")
+}
+
+func TestGeneratorRegistry_Drain(t *testing.T) {
+	t.Run("Waits for games in flight", func(t *testing.T) {
+		// Arrange
+		randomService := NewRandomService([]random.Generator{random.NewCryptoGenerator()})
+		_, _, _ = randomService.SelectGenerator("player-1", "")
+		_, _, _ = randomService.SelectGenerator("player-2", "")
+
+		done := make(chan error)
+		go func() {
+			done <- randomService.DrainGenerator(context.Background(), "crypto")
+		}()
+
+		// Act & Assert
+		assert.Eventually(t, func() bool {
+			return randomService.ListGenerators()[0].State == model.GeneratorDraining
+		}, time.Second, time.Millisecond)
+
+		_, _, err := randomService.SelectGenerator("player-3", "")
+		assert.Error(t, err, "draining generators are out of rotation")
+		assert.Error(t, randomService.RemoveGenerator("crypto"), "games still in flight")
+
+		randomService.ReleaseGenerator("crypto")
+		select {
+		case <-done:
+			t.Fatal("drain finished with a game in flight")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		randomService.ReleaseGenerator("crypto")
+		assert.NoError(t, <-done)
+
+		info := randomService.ListGenerators()[0]
+		assert.Equal(t, model.GeneratorDisabled, info.State)
+		assert.Zero(t, info.InFlight)
+		assert.NoError(t, randomService.RemoveGenerator("crypto"))
+		assert.Empty(t, randomService.ListGenerators())
+	})
+
+	t.Run("Times out", func(t *testing.T) {
+		// Arrange
+		randomService := NewRandomService([]random.Generator{random.NewCryptoGenerator()})
+		_, _, _ = randomService.SelectGenerator("player", "")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// Act
+		err := randomService.DrainGenerator(ctx, "crypto")
+
+		// Assert
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, model.GeneratorDraining, randomService.ListGenerators()[0].State)
+	})
+
+	t.Run("Cancelled by enable", func(t *testing.T) {
+		// Arrange
+		randomService := NewRandomService([]random.Generator{random.NewCryptoGenerator()})
+		_, _, _ = randomService.SelectGenerator("player", "")
+
+		done := make(chan error)
+		go func() {
+			done <- randomService.DrainGenerator(context.Background(), "crypto")
+		}()
+		assert.Eventually(t, func() bool {
+			return randomService.ListGenerators()[0].State == model.GeneratorDraining
+		}, time.Second, time.Millisecond)
+
+		// Act
+		assert.NoError(t, randomService.EnableGenerator("crypto"))
+
+		// Assert
+		assert.EqualError(t, <-done, "drain of generator crypto was cancelled, it is enabled")
+	})
+}
+
+func TestGeneratorRegistry_SelectWhileDisabling(t *testing.T) {
+	// Arrange
+	randomService := NewRandomService([]random.Generator{random.NewStandardGenerator(), random.NewCryptoGenerator()})
+	stop := make(chan struct{})
+	toggled := make(chan struct{})
+	go func() {
+		defer close(toggled)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_ = randomService.DisableGenerator("standard")
+			_ = randomService.EnableGenerator("standard")
+		}
+	}()
+
+	// Act
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				generator, _, err := randomService.SelectGenerator("player", "")
+				if err != nil {
+					errs <- err
+					return
+				}
+				randomService.ReleaseGenerator(generator.Name())
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-toggled
+	close(errs)
+
+	// Assert
+	for err := range errs {
+		assert.NoError(t, err, "crypto stays enabled, so a game always finds a generator")
+	}
+}
+
+func TestGeneratorRegistry_AcquireSelected(t *testing.T) {
+	// Arrange
+	standard := random.NewStandardGenerator()
+	registry := NewGeneratorRegistry([]random.Generator{standard, random.NewCryptoGenerator()})
+	_ = registry.Disable("crypto")
+	var offered []random.Generator
+
+	// Act
+	generator, err := registry.AcquireSelected(func(enabled []random.Generator) (random.Generator, error) {
+		offered = enabled
+		return enabled[0], nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []random.Generator{standard}, offered)
+	assert.Equal(t, "standard", generator.Name())
+	assert.Equal(t, 1, registry.Snapshot()[0].InFlight)
+
+	_, err = registry.AcquireSelected(func([]random.Generator) (random.Generator, error) {
+		return random.NewCryptoGenerator(), nil
+	})
+	assert.EqualError(t, err, "picked generator crypto is not enabled")
+	assert.Zero(t, registry.Snapshot()[1].InFlight)
+}
+
+func TestGeneratorRegistry_Concurrent(t *testing.T) {
+	// Arrange
+	randomService := NewRandomService([]random.Generator{random.NewStandardGenerator(), random.NewCryptoGenerator()})
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if generator, _, err := randomService.SelectGenerator("player", ""); err == nil {
+					randomService.ReleaseGenerator(generator.Name())
+				}
+			}
+		}()
+	}
+	for j := 0; j < 50; j++ {
+		_ = randomService.DisableGenerator("standard")
+		_ = randomService.AddGenerator(random.NewBufferedCryptoGenerator(0))
+		_ = randomService.EnableGenerator("standard")
+		_ = randomService.RemoveGenerator("crypto_buffered")
+	}
+	wg.Wait()
+
+	// Assert
+	for _, info := range randomService.ListGenerators() {
+		assert.Zero(t, info.InFlight)
+	}
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"dice-game/pkg/infrastructure/random"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	SelectionRandom     = "random"
+	SelectionFixed      = "fixed"
+	SelectionWeighted   = "weighted"
+	SelectionRoundRobin = "round_robin"
+	SelectionSticky     = "sticky"
+
+	// SelectionRequested is recorded on games whose player requested the
+	// generator.
+	SelectionRequested = "requested"
+)
+
+// GeneratorSelector picks the generator of a game among the candidates the
+// random service offers: every registered generator that is not
+// quarantined, in registration order. Name is recorded on every game the
+// selector picks a generator for.
+type GeneratorSelector interface {
+	Name() string
+	Select(playerID string, candidates []random.Generator) (random.Generator, error)
+}
+
+// NewGeneratorSelector returns the selector called strategy. fixed needs
+// defaultGenerator, weighted needs weights; an empty strategy is random.
+func NewGeneratorSelector(strategy, defaultGenerator string, weights map[string]int) (GeneratorSelector, error) {
+	switch strategy {
+	case "", SelectionRandom:
+		return NewRandomSelector(), nil
+	case SelectionFixed:
+		if defaultGenerator == "" {
+			return nil, errors.New("fixed generator selection needs a default generator")
+		}
+		return NewFixedSelector(defaultGenerator), nil
+	case SelectionWeighted:
+		return NewWeightedSelector(weights)
+	case SelectionRoundRobin:
+		return NewRoundRobinSelector(), nil
+	case SelectionSticky:
+		return NewStickySelector(), nil
+	default:
+		return nil, fmt.Errorf("unknown generator selection strategy %q", strategy)
+	}
+}
+
+// RandomSelector picks a candidate uniformly at random.
+type RandomSelector struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+func (s *RandomSelector) Name() string {
+	return SelectionRandom
+}
+
+func (s *RandomSelector) Select(_ string, candidates []random.Generator) (random.Generator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return candidates[s.rnd.Intn(len(candidates))], nil
+}
+
+// FixedSelector always picks the same generator and fails while it is
+// unavailable rather than falling back to another one.
+type FixedSelector struct {
+	generator string
+}
+
+func NewFixedSelector(generator string) *FixedSelector {
+	return &FixedSelector{generator: generator}
+}
+
+func (s *FixedSelector) Name() string {
+	return SelectionFixed
+}
+
+func (s *FixedSelector) Select(_ string, candidates []random.Generator) (random.Generator, error) {
+	for _, gen := range candidates {
+		if gen.Name() == s.generator {
+			return gen, nil
+		}
+	}
+
+	return nil, fmt.Errorf("generator %s is not available", s.generator)
+}
+
+// WeightedSelector picks a candidate with probability proportional to its
+// weight. Generators without a weight are never picked.
+type WeightedSelector struct {
+	weights map[string]int
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func NewWeightedSelector(weights map[string]int) (*WeightedSelector, error) {
+	total := 0
+	for name, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("generator %s has negative weight %d", name, weight)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return nil, errors.New("weighted generator selection needs at least one positive weight")
+	}
+
+	return &WeightedSelector{
+		weights: weights,
+		rnd:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+func (s *WeightedSelector) Name() string {
+	return SelectionWeighted
+}
+
+func (s *WeightedSelector) Select(_ string, candidates []random.Generator) (random.Generator, error) {
+	total := 0
+	for _, gen := range candidates {
+		total += s.weights[gen.Name()]
+	}
+	if total == 0 {
+		return nil, errors.New("no weighted generator is available")
+	}
+
+	s.mu.Lock()
+	n := s.rnd.Intn(total)
+	s.mu.Unlock()
+
+	for _, gen := range candidates {
+		n -= s.weights[gen.Name()]
+		if n < 0 {
+			return gen, nil
+		}
+	}
+
+	return nil, errors.New("no weighted generator is available")
+}
+
+// RoundRobinSelector cycles through the candidates in order.
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{}
+}
+
+func (s *RoundRobinSelector) Name() string {
+	return SelectionRoundRobin
+}
+
+func (s *RoundRobinSelector) Select(_ string, candidates []random.Generator) (random.Generator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gen := candidates[s.next%uint64(len(candidates))]
+	s.next++
+
+	return gen, nil
+}
+
+// StickySelector keeps each player on the same generator using rendezvous
+// hashing: the candidate with the highest hash of player and generator name
+// wins. When a generator is added or quarantined only the players on it
+// move.
+type StickySelector struct{}
+
+func NewStickySelector() *StickySelector {
+	return &StickySelector{}
+}
+
+func (s *StickySelector) Name() string {
+	return SelectionSticky
+}
+
+func (s *StickySelector) Select(playerID string, candidates []random.Generator) (random.Generator, error) {
+	var (
+		best      random.Generator
+		bestScore uint64
+	)
+	for _, gen := range candidates {
+		h := fnv.New64a()
+		h.Write([]byte(playerID))
+		h.Write([]byte{0})
+		h.Write([]byte(gen.Name()))
+
+		if score := h.Sum64(); best == nil || score > bestScore {
+			best, bestScore = gen, score
+		}
+	}
+
+	return best, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"dice-game/pkg/infrastructure/random"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func namedGenerators(names ...string) []random.Generator {
+	generators := make([]random.Generator, 0, len(names))
+	for _, name := range names {
+		gen := new(MockGenerator)
+		gen.On("Name").Return(name).Maybe()
+		generators = append(generators, gen)
+	}
+	return generators
+}
+
+func TestNewGeneratorSelector(t *testing.T) {
+	tests := []struct {
+		name             string
+		strategy         string
+		defaultGenerator string
+		weights          map[string]int
+		want             string
+		wantErr          bool
+	}{
+		{name: "Empty is random", strategy: "", want: SelectionRandom},
+		{name: "Fixed", strategy: SelectionFixed, defaultGenerator: "crypto", want: SelectionFixed},
+		{name: "Fixed without default", strategy: SelectionFixed, wantErr: true},
+		{name: "Weighted", strategy: SelectionWeighted, weights: map[string]int{"crypto": 1}, want: SelectionWeighted},
+		{name: "Weighted without weights", strategy: SelectionWeighted, wantErr: true},
+		{name: "Weighted with negative weight", strategy: SelectionWeighted, weights: map[string]int{"crypto": 2, "standard": -1}, wantErr: true},
+		{name: "Round robin", strategy: SelectionRoundRobin, want: SelectionRoundRobin},
+		{name: "Sticky", strategy: SelectionSticky, want: SelectionSticky},
+		{name: "Unknown", strategy: "lottery", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			selector, err := NewGeneratorSelector(tt.strategy, tt.defaultGenerator, tt.weights)
+
+			// Assert
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, selector.Name())
+		})
+	}
+}
+
+func TestFixedSelector(t *testing.T) {
+	// Arrange
+	generators := namedGenerators("standard", "crypto")
+	selector := NewFixedSelector("crypto")
+
+	// Act
+	generator, err := selector.Select("player", generators)
+	_, missingErr := selector.Select("player", generators[:1])
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "crypto", generator.Name())
+	assert.EqualError(t, missingErr, "generator crypto is not available")
+}
+
+func TestWeightedSelector(t *testing.T) {
+	// Arrange
+	generators := namedGenerators("standard", "crypto", "vrf")
+	selector, _ := NewWeightedSelector(map[string]int{"standard": 1, "crypto": 3})
+
+	// Act
+	counts := make(map[string]int)
+	for i := 0; i < 4000; i++ {
+		generator, err := selector.Select("player", generators)
+		assert.NoError(t, err)
+		counts[generator.Name()]++
+	}
+	_, err := selector.Select("player", generators[2:])
+
+	// Assert
+	assert.InDelta(t, 1000, counts["standard"], 150)
+	assert.InDelta(t, 3000, counts["crypto"], 150)
+	assert.Zero(t, counts["vrf"])
+	assert.Error(t, err, "only unweighted generators left")
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	// Arrange
+	generators := namedGenerators("standard", "crypto", "vrf")
+	selector := NewRoundRobinSelector()
+
+	// Act
+	var picked []string
+	for i := 0; i < 6; i++ {
+		generator, _ := selector.Select("player", generators)
+		picked = append(picked, generator.Name())
+	}
+
+	// Assert
+	assert.Equal(t, []string{"standard", "crypto", "vrf", "standard", "crypto", "vrf"}, picked)
+}
+
+func TestStickySelector(t *testing.T) {
+	// Arrange
+	generators := namedGenerators("standard", "crypto", "vrf", "provably_fair")
+	selector := NewStickySelector()
+
+	// Act & Assert
+	counts := make(map[string]int)
+	for i := 0; i < 400; i++ {
+		playerID := fmt.Sprintf("player-%d", i)
+		first, _ := selector.Select(playerID, generators)
+		second, _ := selector.Select(playerID, generators)
+		assert.Equal(t, first, second, "same player, same generator")
+		counts[first.Name()]++
+
+		// Removing another generator does not move the player.
+		for j, gen := range generators {
+			if gen == first {
+				continue
+			}
+			rest := append(append([]random.Generator(nil), generators[:j]...), generators[j+1:]...)
+			again, _ := selector.Select(playerID, rest)
+			assert.Equal(t, first, again)
+			break
+		}
+	}
+
+	assert.Len(t, counts, 4, "players spread over every generator")
+}
+
+func TestRandomService_UseSelector(t *testing.T) {
+	// Arrange
+	randomService := NewRandomService(namedGenerators("standard", "crypto"))
+	randomService.UseSelector(NewFixedSelector("vrf"))
+
+	// Act
+	generator, strategy, err := randomService.SelectGenerator("player", "")
+
+	// Assert
+	assert.Nil(t, generator)
+	assert.Empty(t, strategy)
+	assert.EqualError(t, err, "fixed generator selection failed: generator vrf is not available")
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/infrastructure/random"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultHealthWindowSize = 600
+
+	// minExpectedCount is the least number of values per face a full window
+	// must hold for the chi-square approximation to hold.
+	minExpectedCount = 5
+)
+
+// HealthMonitor runs online health tests over a sliding window of each
+// generator's output, one window per range the generator draws from, so
+// games with different dice do not disturb each other's statistics. The
+// repetition count test runs on every value; the runs test, and the
+// chi-square test when the window holds at least five values per face,
+// run on every value once the window is full. A generator that fails any
+// test in any window is quarantined until an operator resets it.
+type HealthMonitor struct {
+	mu           sync.Mutex
+	windowSize   int
+	windows      map[healthKey]*healthWindow
+	quarantined  map[string]bool
+	onQuarantine func(model.GeneratorHealth)
+}
+
+type healthKey struct {
+	generator string
+	min, max  int
+}
+
+type healthWindow struct {
+	min, max int
+	values   []int
+	next     int
+	samples  int
+	// counts is nil when the range has too many faces for the chi-square
+	// test.
+	counts []int
+	last   int
+	health model.GeneratorHealth
+}
+
+// NewHealthMonitor returns a monitor over the last windowSize values of each
+// generator. onQuarantine, if not nil, is called once for every generator
+// that gets quarantined.
+func NewHealthMonitor(windowSize int, onQuarantine func(model.GeneratorHealth)) *HealthMonitor {
+	if windowSize <= 0 {
+		windowSize = DefaultHealthWindowSize
+	}
+
+	return &HealthMonitor{
+		windowSize:   windowSize,
+		windows:      make(map[healthKey]*healthWindow),
+		quarantined:  make(map[string]bool),
+		onQuarantine: onQuarantine,
+	}
+}
+
+// Observe feeds values the generator drew from [min, max] and returns an
+// error if the generator is, or has just become, quarantined. Values drawn
+// by a quarantined generator must not be used.
+func (m *HealthMonitor) Observe(generator string, min, max int, values []int) error {
+	m.mu.Lock()
+
+	if m.quarantined[generator] {
+		m.mu.Unlock()
+		return fmt.Errorf("generator %s is quarantined", generator)
+	}
+
+	w := m.window(generator, min, max)
+	for _, v := range values {
+		w.observe(v)
+		if w.health.Status == model.HealthQuarantined {
+			break
+		}
+	}
+
+	health := w.health
+	if health.Status == model.HealthQuarantined {
+		m.quarantined[generator] = true
+	}
+	m.mu.Unlock()
+
+	if health.Status != model.HealthQuarantined {
+		return nil
+	}
+
+	if m.onQuarantine != nil {
+		m.onQuarantine(health)
+	}
+
+	return fmt.Errorf("generator %s is quarantined: %s", generator, health.Reason)
+}
+
+func (m *HealthMonitor) IsQuarantined(generator string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.quarantined[generator]
+}
+
+// Reset drops every window of a generator and lifts its quarantine, so its
+// health is tested again from scratch. It reports whether the generator was
+// quarantined.
+func (m *HealthMonitor) Reset(generator string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.windows {
+		if key.generator == generator {
+			delete(m.windows, key)
+		}
+	}
+
+	quarantined := m.quarantined[generator]
+	delete(m.quarantined, generator)
+
+	return quarantined
+}
+
+// Health returns the current state of every window of a generator, ordered
+// by range. A generator that has not produced any values yet has a single
+// warming up entry.
+func (m *HealthMonitor) Health(generator string) []model.GeneratorHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var report []model.GeneratorHealth
+	for key, w := range m.windows {
+		if key.generator == generator {
+			report = append(report, w.health)
+		}
+	}
+
+	if len(report) == 0 {
+		return []model.GeneratorHealth{{
+			Generator:  generator,
+			Status:     model.HealthWarmingUp,
+			WindowSize: m.windowSize,
+		}}
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Min != report[j].Min {
+			return report[i].Min < report[j].Min
+		}
+		return report[i].Max < report[j].Max
+	})
+
+	return report
+}
+
+func (m *HealthMonitor) window(generator string, min, max int) *healthWindow {
+	key := healthKey{generator: generator, min: min, max: max}
+
+	w, ok := m.windows[key]
+	if !ok {
+		w = &healthWindow{health: model.GeneratorHealth{Generator: generator}}
+		w.reset(min, max, m.windowSize)
+		m.windows[key] = w
+	}
+	return w
+}
+
+// reset starts a fresh window for the range [min, max].
+func (w *healthWindow) reset(min, max, size int) {
+	categories := max - min + 1
+
+	w.min, w.max = min, max
+	w.values = make([]int, size)
+	w.next = 0
+	w.samples = 0
+	w.counts = nil
+
+	w.health.Min, w.health.Max = min, max
+	w.health.WindowSize = size
+	w.health.Samples = 0
+	w.health.ChiSquare = 0
+	w.health.ChiSquareCritical = 0
+	if categories >= 2 && categories*minExpectedCount <= size {
+		w.counts = make([]int, categories)
+		w.health.ChiSquareCritical = random.ChiSquareCritical(categories-1, random.HealthAlpha)
+	}
+	w.health.RunsZ = 0
+	w.health.RunsCritical = random.RunsCritical(random.HealthAlpha)
+	w.health.Repetition = 0
+	w.health.RepetitionCutoff = random.RepetitionCutoff(categories, random.HealthAlpha)
+	w.health.Status = model.HealthWarmingUp
+}
+
+func (w *healthWindow) observe(v int) {
+	if v < w.min || v > w.max {
+		w.quarantine(fmt.Sprintf("value %d outside [%d, %d]", v, w.min, w.max))
+		return
+	}
+
+	size := len(w.values)
+	if w.samples == size {
+		if w.counts != nil {
+			w.counts[w.values[w.next]-w.min]--
+		}
+	} else {
+		w.samples++
+	}
+	w.values[w.next] = v
+	w.next = (w.next + 1) % size
+	if w.counts != nil {
+		w.counts[v-w.min]++
+	}
+	w.health.Samples = w.samples
+
+	if w.health.Repetition > 0 && v == w.last {
+		w.health.Repetition++
+	} else {
+		w.health.Repetition = 1
+	}
+	w.last = v
+
+	if w.health.Repetition >= w.health.RepetitionCutoff {
+		w.quarantine(fmt.Sprintf("repetition count test: %d equal values in a row", w.health.Repetition))
+		return
+	}
+
+	if w.samples < size || w.min == w.max {
+		return
+	}
+
+	if w.counts != nil {
+		w.health.ChiSquare = random.ChiSquare(w.counts)
+	}
+	w.health.RunsZ = random.RunsZ(w.ordered(), w.min, w.max)
+
+	switch {
+	case w.counts != nil && w.health.ChiSquare > w.health.ChiSquareCritical:
+		w.quarantine(fmt.Sprintf("chi-square test: %.2f > %.2f", w.health.ChiSquare, w.health.ChiSquareCritical))
+	case math.Abs(w.health.RunsZ) > w.health.RunsCritical:
+		w.quarantine(fmt.Sprintf("runs test: |z| = %.2f > %.2f", math.Abs(w.health.RunsZ), w.health.RunsCritical))
+	default:
+		w.health.Status = model.HealthHealthy
+	}
+}
+
+// ordered returns the window oldest value first.
+func (w *healthWindow) ordered() []int {
+	return append(append([]int(nil), w.values[w.next:]...), w.values[:w.next]...)
+}
+
+func (w *healthWindow) quarantine(reason string) {
+	now := time.Now()
+	w.health.Status = model.HealthQuarantined
+	w.health.Reason = reason
+	w.health.QuarantinedAt = &now
+}
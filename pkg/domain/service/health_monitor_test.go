@@ -0,0 +1,173 @@
+package service
+
+import (
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/infrastructure/random"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthMonitor_HealthyGenerator(t *testing.T) {
+	// Arrange
+	monitor := NewHealthMonitor(120, nil)
+	generator := random.NewCryptoGenerator()
+
+	// Act
+	for i := 0; i < 300; i++ {
+		roll, _ := generator.Roll(random.RollRequest{Count: 2, Min: 1, Max: 6})
+		assert.NoError(t, monitor.Observe("crypto", 1, 6, roll.Values))
+	}
+
+	// Assert
+	health := monitor.Health("crypto")[0]
+	assert.Equal(t, model.HealthHealthy, health.Status)
+	assert.Equal(t, 120, health.Samples)
+	assert.Less(t, health.ChiSquare, health.ChiSquareCritical)
+	assert.False(t, monitor.IsQuarantined("crypto"))
+}
+
+func TestHealthMonitor_WarmingUp(t *testing.T) {
+	// Arrange
+	monitor := NewHealthMonitor(120, nil)
+
+	// Act
+	err := monitor.Observe("crypto", 1, 6, []int{1, 2, 3})
+
+	// Assert
+	assert.NoError(t, err)
+	health := monitor.Health("crypto")[0]
+	assert.Equal(t, model.HealthWarmingUp, health.Status)
+	assert.Equal(t, 3, health.Samples)
+	assert.Equal(t, model.HealthWarmingUp, monitor.Health("unused")[0].Status)
+}
+
+func TestHealthMonitor_SeparateWindowPerRange(t *testing.T) {
+	// Arrange
+	monitor := NewHealthMonitor(120, nil)
+	generator := random.NewCryptoGenerator()
+
+	// Act
+	for i := 0; i < 300; i++ {
+		d6, _ := generator.Roll(random.RollRequest{Count: 2, Min: 1, Max: 6})
+		assert.NoError(t, monitor.Observe("crypto", 1, 6, d6.Values))
+		d1000, _ := generator.Roll(random.RollRequest{Count: 2, Min: 1, Max: 1000})
+		assert.NoError(t, monitor.Observe("crypto", 1, 1000, d1000.Values))
+	}
+
+	// Assert
+	health := monitor.Health("crypto")
+	assert.Len(t, health, 2)
+	assert.Equal(t, 6, health[0].Max)
+	assert.Equal(t, model.HealthHealthy, health[0].Status)
+	assert.Greater(t, health[0].ChiSquareCritical, 0.0)
+	assert.Equal(t, 1000, health[1].Max)
+	assert.Equal(t, model.HealthHealthy, health[1].Status)
+	assert.Zero(t, health[1].ChiSquareCritical, "too few values per face for a chi-square test")
+}
+
+func TestHealthMonitor_Quarantine(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern []int
+		reason  string
+	}{
+		{name: "Stuck output", pattern: []int{4}, reason: "repetition count test"},
+		{name: "Low faces only", pattern: []int{1, 2, 3}, reason: "chi-square test"},
+		{name: "Alternating halves", pattern: []int{1, 4, 2, 5, 3, 6}, reason: "runs test"},
+		{name: "Out of range", pattern: []int{7}, reason: "outside [1, 6]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var quarantined []model.GeneratorHealth
+			monitor := NewHealthMonitor(120, func(health model.GeneratorHealth) {
+				quarantined = append(quarantined, health)
+			})
+
+			// Act
+			var err error
+			for i := 0; i < 200 && err == nil; i++ {
+				err = monitor.Observe("broken", 1, 6, []int{tt.pattern[i%len(tt.pattern)]})
+			}
+
+			// Assert
+			assert.Error(t, err)
+			assert.True(t, monitor.IsQuarantined("broken"))
+
+			health := monitor.Health("broken")[0]
+			assert.Equal(t, model.HealthQuarantined, health.Status)
+			assert.Contains(t, health.Reason, tt.reason)
+			assert.NotNil(t, health.QuarantinedAt)
+
+			assert.Error(t, monitor.Observe("broken", 1, 6, []int{1, 2}), "quarantine persists until reset")
+			assert.Len(t, quarantined, 1, "callback runs once")
+		})
+	}
+}
+
+func TestHealthMonitor_Reset(t *testing.T) {
+	// Arrange
+	monitor := NewHealthMonitor(120, nil)
+	require.Error(t, monitor.Observe("broken", 1, 6, make([]int, 20)))
+	require.NoError(t, monitor.Observe("healthy", 1, 6, []int{1, 2, 3}))
+
+	// Act
+	cleared := monitor.Reset("broken")
+
+	// Assert
+	assert.True(t, cleared)
+	assert.False(t, monitor.IsQuarantined("broken"))
+	assert.Equal(t, model.HealthWarmingUp, monitor.Health("broken")[0].Status)
+	assert.Zero(t, monitor.Health("broken")[0].Samples)
+	assert.Equal(t, 3, monitor.Health("healthy")[0].Samples, "other generators keep their windows")
+	assert.NoError(t, monitor.Observe("broken", 1, 6, []int{1, 2}))
+	assert.False(t, monitor.Reset("healthy"))
+}
+
+func TestRandomService_RemoveGeneratorResetsHealth(t *testing.T) {
+	// Arrange
+	standard := random.NewStandardGenerator()
+	randomService := NewRandomService([]random.Generator{standard, random.NewCryptoGenerator()})
+	randomService.UseHealthMonitor(NewHealthMonitor(120, nil))
+	require.Error(t, randomService.ObserveRoll("standard", 1, 6, make([]int, 20)))
+
+	// Act
+	require.NoError(t, randomService.RemoveGenerator("standard"))
+	require.NoError(t, randomService.AddGenerator(standard))
+
+	// Assert
+	for _, info := range randomService.ListGenerators() {
+		assert.False(t, info.Quarantined, info.Name)
+	}
+}
+
+func TestRandomService_SkipsQuarantinedGenerators(t *testing.T) {
+	// Arrange
+	standard := random.NewStandardGenerator()
+	crypto := random.NewCryptoGenerator()
+	randomService := NewRandomService([]random.Generator{standard, crypto})
+	randomService.UseHealthMonitor(NewHealthMonitor(120, nil))
+
+	// Act
+	err := randomService.ObserveRoll("standard", 1, 6, make([]int, 20))
+
+	// Assert
+	assert.Error(t, err)
+	for i := 0; i < 20; i++ {
+		generator, _, err := randomService.SelectGenerator("player", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "crypto", generator.Name())
+	}
+
+	health := randomService.GetGeneratorHealth()
+	assert.Len(t, health, 2)
+	assert.Equal(t, model.HealthQuarantined, health[0].Status)
+	assert.Equal(t, model.HealthWarmingUp, health[1].Status)
+
+	assert.Error(t, randomService.ObserveRoll("crypto", 1, 6, make([]int, 20)))
+	_, _, err = randomService.SelectGenerator("player", "")
+	assert.EqualError(t, err, "every random generator is quarantined")
+}
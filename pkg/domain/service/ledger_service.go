@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+	"dice-game/pkg/infrastructure/merkle"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// sealDelay keeps a day open for games that were played just before
+// midnight but had not been saved yet.
+const sealDelay = 10 * time.Minute
+
+// LedgerService seals each day of game_results under a Merkle root and
+// proves that individual games are part of a sealed day.
+type LedgerService struct {
+	gameRepo   repository.GameRepository
+	merkleRepo repository.MerkleRootRepository
+}
+
+func NewLedgerService(gameRepo repository.GameRepository, merkleRepo repository.MerkleRootRepository) *LedgerService {
+	return &LedgerService{
+		gameRepo:   gameRepo,
+		merkleRepo: merkleRepo,
+	}
+}
+
+// SealPendingDays stores a root for every finished day that has games but
+// no root yet.
+func (s *LedgerService) SealPendingDays(ctx context.Context, now time.Time) ([]*model.DailyMerkleRoot, error) {
+	days, err := s.merkleRepo.GetUnsealedDays(ctx, now.Add(-sealDelay))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unsealed days: %w", err)
+	}
+
+	roots := make([]*model.DailyMerkleRoot, 0, len(days))
+	for _, day := range days {
+		root, err := s.SealDay(ctx, day)
+		if err != nil {
+			return roots, err
+		}
+		roots = append(roots, root)
+	}
+
+	return roots, nil
+}
+
+// SealDay builds the Merkle tree over the games of the UTC day containing
+// day and stores its root.
+func (s *LedgerService) SealDay(ctx context.Context, day time.Time) (*model.DailyMerkleRoot, error) {
+	_, tree, err := s.buildDayTree(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &model.DailyMerkleRoot{
+		Date:      day.UTC().Truncate(24 * time.Hour),
+		RootHash:  hex.EncodeToString(tree.Root()),
+		LeafCount: tree.LeafCount(),
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.merkleRepo.SaveDailyRoot(ctx, root); err != nil {
+		return nil, fmt.Errorf("failed to save merkle root: %w", err)
+	}
+
+	return root, nil
+}
+
+// GetInclusionProof proves that a game is part of its sealed day. The tree
+// is rebuilt from the current rows, so if any game of that day was edited,
+// added or dropped after sealing the proof is refused.
+func (s *LedgerService) GetInclusionProof(ctx context.Context, gameID string) (*model.InclusionProof, error) {
+	result, err := s.gameRepo.GetGameResult(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game result: %w", err)
+	}
+
+	root, err := s.merkleRepo.GetDailyRoot(ctx, result.PlayedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merkle root: %w", err)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("games of %s have not been sealed yet", result.PlayedAt.UTC().Format("2006-01-02"))
+	}
+
+	games, tree, err := s.buildDayTree(ctx, result.PlayedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if rebuilt := hex.EncodeToString(tree.Root()); rebuilt != root.RootHash || tree.LeafCount() != root.LeafCount {
+		return nil, &MismatchError{Field: "merkle_root", Stored: root.RootHash, Computed: rebuilt}
+	}
+
+	index := -1
+	for i, game := range games {
+		if game.GameID == gameID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("game %s is missing from its day", gameID)
+	}
+
+	steps, err := tree.Proof(index)
+	if err != nil {
+		return nil, err
+	}
+
+	leafData, err := CanonicalGameResult(games[index])
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize game result: %w", err)
+	}
+
+	proof := &model.InclusionProof{
+		GameID:    gameID,
+		Date:      root.Date,
+		LeafIndex: index,
+		LeafCount: root.LeafCount,
+		LeafData:  string(leafData),
+		LeafHash:  hex.EncodeToString(merkle.LeafHash(leafData)),
+		RootHash:  root.RootHash,
+		Steps:     make([]model.ProofStep, len(steps)),
+	}
+	for i, step := range steps {
+		proof.Steps[i] = model.ProofStep{Hash: hex.EncodeToString(step.Hash), Left: step.Left}
+	}
+
+	return proof, nil
+}
+
+func (s *LedgerService) buildDayTree(ctx context.Context, day time.Time) ([]*model.GameResult, *merkle.Tree, error) {
+	games, err := s.gameRepo.GetGameResultsByDay(ctx, day)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get game results: %w", err)
+	}
+
+	leaves := make([][]byte, len(games))
+	for i, game := range games {
+		leaves[i], err = CanonicalGameResult(game)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to serialize game result: %w", err)
+		}
+	}
+
+	tree, err := merkle.NewTree(leaves)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build merkle tree for %s: %w", day.UTC().Format("2006-01-02"), err)
+	}
+
+	return games, tree, nil
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"time"
+)
+
+type LedgerServiceInterface interface {
+	SealPendingDays(ctx context.Context, now time.Time) ([]*model.DailyMerkleRoot, error)
+	SealDay(ctx context.Context, day time.Time) (*model.DailyMerkleRoot, error)
+	GetInclusionProof(ctx context.Context, gameID string) (*model.InclusionProof, error)
+}
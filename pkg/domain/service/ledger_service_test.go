@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/rules"
+	"dice-game/pkg/infrastructure/merkle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockMerkleRootRepository struct {
+	mock.Mock
+}
+
+func (m *MockMerkleRootRepository) SaveDailyRoot(ctx context.Context, root *model.DailyMerkleRoot) error {
+	args := m.Called(ctx, root)
+	return args.Error(0)
+}
+
+func (m *MockMerkleRootRepository) GetDailyRoot(ctx context.Context, day time.Time) (*model.DailyMerkleRoot, error) {
+	args := m.Called(ctx, day)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DailyMerkleRoot), args.Error(1)
+}
+
+func (m *MockMerkleRootRepository) GetUnsealedDays(ctx context.Context, before time.Time) ([]time.Time, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+var ledgerDay = time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)
+
+func ledgerGames(n int) []*model.GameResult {
+	games := make([]*model.GameResult, n)
+	for i := range games {
+		games[i] = &model.GameResult{
+			GameID:        fmt.Sprintf("game-%d", i),
+			PlayerID:      "test-player",
+			PlayerDice:    i%6 + 1,
+			ServerDice:    (i+3)%6 + 1,
+			Winner:        model.WinnerDraw,
+			PlayedAt:      ledgerDay.Add(time.Duration(i) * time.Minute),
+			GeneratorUsed: "crypto",
+		}
+	}
+	return games
+}
+
+func sealedRoot(t *testing.T, games []*model.GameResult) *model.DailyMerkleRoot {
+	t.Helper()
+
+	leaves := make([][]byte, len(games))
+	for i, game := range games {
+		leaf, err := CanonicalGameResult(game)
+		require.NoError(t, err)
+		leaves[i] = leaf
+	}
+
+	tree, err := merkle.NewTree(leaves)
+	require.NoError(t, err)
+
+	return &model.DailyMerkleRoot{Date: ledgerDay, RootHash: hex.EncodeToString(tree.Root()), LeafCount: len(games)}
+}
+
+func TestCanonicalGameResult(t *testing.T) {
+	result := &model.GameResult{
+		GameID:           "game-1",
+		PlayerID:         "player",
+		PlayerDice:       4,
+		ServerDice:       2,
+		Winner:           model.WinnerPlayer,
+		PlayedAt:         time.Date(2025, 3, 16, 1, 26, 25, 123456789, time.FixedZone("UTC+4", 4*3600)),
+		GeneratorUsed:    "provably_fair",
+		VerificationKey:  "v3:hash:2:hash",
+		ClientSeed:       "seed",
+		Nonce:            2,
+		AlgorithmVersion: 3,
+	}
+
+	data, err := CanonicalGameResult(result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"game_id":"game-1","player_id":"player","player_dice":4,"server_dice":2,"winner":"PLAYER",`+
+		`"played_at":"2025-03-15T21:26:25.123456Z","generator_used":"provably_fair","verification_key":"v3:hash:2:hash",`+
+		`"client_seed":"seed","nonce":2,"algorithm_version":3}`, string(data))
+
+	result.GeneratorUsed = "beacon"
+	result.BeaconRound = 42
+
+	data, err = CanonicalGameResult(result)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), `"algorithm_version":3,"beacon_round":42}`), string(data))
+
+	result.SelectionStrategy = "sticky"
+
+	data, err = CanonicalGameResult(result)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), `"beacon_round":42,"selection_strategy":"sticky"}`), string(data))
+
+	result.NonProduction = true
+
+	data, err = CanonicalGameResult(result)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), `"selection_strategy":"sticky","non_production":true}`), string(data))
+
+	result.Variant = rules.Classic
+	result.VariantVersion = 1
+	result.Payout = 2
+
+	data, err = CanonicalGameResult(result)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), `"non_production":true}`), string(data))
+
+	result.Variant = rules.LowestWins
+
+	data, err = CanonicalGameResult(result)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), `"non_production":true,"variant":"lowest-wins","variant_version":1}`), string(data))
+
+	result.DrawPolicy = "keep"
+	result.Rounds = []model.GameRound{{PlayerDice: 4, ServerDice: 2}}
+
+	data, err = CanonicalGameResult(result)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), `"variant_version":1}`), string(data))
+
+	result.DrawPolicy = "reroll:3"
+	result.Rounds = []model.GameRound{{PlayerDice: 3, ServerDice: 3}, {PlayerDice: 4, ServerDice: 2}}
+
+	data, err = CanonicalGameResult(result)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), `"variant_version":1,"draw_policy":"reroll:3",`+
+		`"rounds":[{"player_dice":3,"server_dice":3},{"player_dice":4,"server_dice":2}]}`), string(data))
+}
+
+func TestLedgerService_SealDay(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockMerkleRepo := new(MockMerkleRootRepository)
+	games := ledgerGames(5)
+	expected := sealedRoot(t, games)
+
+	mockGameRepo.On("GetGameResultsByDay", mock.Anything, ledgerDay).Return(games, nil)
+	mockMerkleRepo.On("SaveDailyRoot", mock.Anything, mock.MatchedBy(func(root *model.DailyMerkleRoot) bool {
+		return root.Date.Equal(ledgerDay) && root.RootHash == expected.RootHash && root.LeafCount == 5
+	})).Return(nil)
+
+	service := NewLedgerService(mockGameRepo, mockMerkleRepo)
+
+	// Act
+	root, err := service.SealDay(context.Background(), ledgerDay)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected.RootHash, root.RootHash)
+	mockMerkleRepo.AssertExpectations(t)
+}
+
+func TestLedgerService_SealPendingDays(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockMerkleRepo := new(MockMerkleRootRepository)
+	now := ledgerDay.Add(26 * time.Hour)
+	nextDay := ledgerDay.Add(24 * time.Hour)
+
+	mockMerkleRepo.On("GetUnsealedDays", mock.Anything, now.Add(-sealDelay)).Return([]time.Time{ledgerDay, nextDay}, nil)
+	mockGameRepo.On("GetGameResultsByDay", mock.Anything, ledgerDay).Return(ledgerGames(3), nil)
+	mockGameRepo.On("GetGameResultsByDay", mock.Anything, nextDay).Return(ledgerGames(1), nil)
+	mockMerkleRepo.On("SaveDailyRoot", mock.Anything, mock.AnythingOfType("*model.DailyMerkleRoot")).Return(nil)
+
+	service := NewLedgerService(mockGameRepo, mockMerkleRepo)
+
+	// Act
+	roots, err := service.SealPendingDays(context.Background(), now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, roots, 2)
+	mockMerkleRepo.AssertNumberOfCalls(t, "SaveDailyRoot", 2)
+}
+
+func TestLedgerService_GetInclusionProof(t *testing.T) {
+	games := ledgerGames(7)
+	root := sealedRoot(t, games)
+
+	t.Run("Proof verifies against the stored root", func(t *testing.T) {
+		// Arrange
+		mockGameRepo := new(MockGameRepository)
+		mockMerkleRepo := new(MockMerkleRootRepository)
+
+		mockGameRepo.On("GetGameResult", mock.Anything, "game-4").Return(games[4], nil)
+		mockMerkleRepo.On("GetDailyRoot", mock.Anything, games[4].PlayedAt).Return(root, nil)
+		mockGameRepo.On("GetGameResultsByDay", mock.Anything, games[4].PlayedAt).Return(games, nil)
+
+		service := NewLedgerService(mockGameRepo, mockMerkleRepo)
+
+		// Act
+		proof, err := service.GetInclusionProof(context.Background(), "game-4")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 4, proof.LeafIndex)
+		assert.Equal(t, 7, proof.LeafCount)
+
+		steps := make([]merkle.ProofStep, len(proof.Steps))
+		for i, step := range proof.Steps {
+			hash, err := hex.DecodeString(step.Hash)
+			require.NoError(t, err)
+			steps[i] = merkle.ProofStep{Hash: hash, Left: step.Left}
+		}
+		rootHash, err := hex.DecodeString(proof.RootHash)
+		require.NoError(t, err)
+
+		assert.True(t, merkle.VerifyProof([]byte(proof.LeafData), steps, rootHash))
+	})
+
+	t.Run("Edited game is detected", func(t *testing.T) {
+		// Arrange
+		mockGameRepo := new(MockGameRepository)
+		mockMerkleRepo := new(MockMerkleRootRepository)
+
+		edited := ledgerGames(7)
+		edited[2].Winner = model.WinnerPlayer
+
+		mockGameRepo.On("GetGameResult", mock.Anything, "game-4").Return(edited[4], nil)
+		mockMerkleRepo.On("GetDailyRoot", mock.Anything, edited[4].PlayedAt).Return(root, nil)
+		mockGameRepo.On("GetGameResultsByDay", mock.Anything, edited[4].PlayedAt).Return(edited, nil)
+
+		service := NewLedgerService(mockGameRepo, mockMerkleRepo)
+
+		// Act
+		proof, err := service.GetInclusionProof(context.Background(), "game-4")
+
+		// Assert
+		var mismatch *MismatchError
+		assert.ErrorAs(t, err, &mismatch)
+		assert.Equal(t, "merkle_root", mismatch.Field)
+		assert.Nil(t, proof)
+	})
+
+	t.Run("Dropped game is detected", func(t *testing.T) {
+		// Arrange
+		mockGameRepo := new(MockGameRepository)
+		mockMerkleRepo := new(MockMerkleRootRepository)
+
+		dropped := append(append([]*model.GameResult{}, games[:3]...), games[4:]...)
+
+		mockGameRepo.On("GetGameResult", mock.Anything, "game-4").Return(games[4], nil)
+		mockMerkleRepo.On("GetDailyRoot", mock.Anything, games[4].PlayedAt).Return(root, nil)
+		mockGameRepo.On("GetGameResultsByDay", mock.Anything, games[4].PlayedAt).Return(dropped, nil)
+
+		service := NewLedgerService(mockGameRepo, mockMerkleRepo)
+
+		// Act
+		_, err := service.GetInclusionProof(context.Background(), "game-4")
+
+		// Assert
+		var mismatch *MismatchError
+		assert.ErrorAs(t, err, &mismatch)
+	})
+
+	t.Run("Day not sealed yet", func(t *testing.T) {
+		// Arrange
+		mockGameRepo := new(MockGameRepository)
+		mockMerkleRepo := new(MockMerkleRootRepository)
+
+		mockGameRepo.On("GetGameResult", mock.Anything, "game-4").Return(games[4], nil)
+		mockMerkleRepo.On("GetDailyRoot", mock.Anything, games[4].PlayedAt).Return(nil, nil)
+
+		service := NewLedgerService(mockGameRepo, mockMerkleRepo)
+
+		// Act
+		_, err := service.GetInclusionProof(context.Background(), "game-4")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "have not been sealed yet")
+	})
+
+	t.Run("Game not found", func(t *testing.T) {
+		// Arrange
+		mockGameRepo := new(MockGameRepository)
+		mockGameRepo.On("GetGameResult", mock.Anything, "missing").Return(nil, errors.New("game not found"))
+
+		service := NewLedgerService(mockGameRepo, new(MockMerkleRootRepository))
+
+		// Act
+		_, err := service.GetInclusionProof(context.Background(), "missing")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
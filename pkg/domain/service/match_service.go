@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidBestOf is returned for match lengths other than MatchLengths.
+	ErrInvalidBestOf = errors.New("matches are best of 3, 5 or 7")
+	// ErrMatchNotFound is returned for unknown match IDs.
+	ErrMatchNotFound = errors.New("match not found")
+	// ErrMatchFinished is returned by PlayRound once a match is decided.
+	ErrMatchFinished = errors.New("match is already finished")
+)
+
+// MatchLengths are the numbers of games a match may be the best of.
+var MatchLengths = []int{3, 5, 7}
+
+// MatchService plays best-of-N matches as a series of games of the
+// GameService, one game a round.
+type MatchService struct {
+	gameService *GameService
+	matchRepo   repository.MatchRepository
+}
+
+func NewMatchService(gameService *GameService, matchRepo repository.MatchRepository) *MatchService {
+	return &MatchService{
+		gameService: gameService,
+		matchRepo:   matchRepo,
+	}
+}
+
+// StartMatch opens a best-of-bestOf match. The variant, dice expression and
+// generator are checked now and used for every round.
+func (s *MatchService) StartMatch(ctx context.Context, playerID string, bestOf int, generatorName, diceNotation, variantName string) (*model.Match, error) {
+	if !slices.Contains(MatchLengths, bestOf) {
+		return nil, fmt.Errorf("%w, not %d", ErrInvalidBestOf, bestOf)
+	}
+
+	game, err := s.gameService.gameRuleset(variantName, diceNotation)
+	if err != nil {
+		return nil, err
+	}
+
+	match := &model.Match{
+		MatchID:   uuid.New().String(),
+		PlayerID:  playerID,
+		BestOf:    bestOf,
+		Variant:   game.variant.Name(),
+		Generator: generatorName,
+		CreatedAt: time.Now(),
+	}
+	// Only the player's pick is kept; variants with their own dice pick
+	// them again every round.
+	if diceNotation != "" {
+		match.Dice = game.expr.String()
+	}
+
+	if err := s.matchRepo.SaveMatch(ctx, match); err != nil {
+		return nil, fmt.Errorf("failed to save match: %w", err)
+	}
+
+	return match, nil
+}
+
+// PlayRound plays the next game of a match and returns it with the updated
+// match. Concurrent rounds of one match are refused by the repository, as
+// both would be stored as the same round.
+func (s *MatchService) PlayRound(ctx context.Context, matchID, clientSeed string) (*model.Match, *model.GameResult, error) {
+	match, err := s.GetMatch(ctx, matchID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if match.IsFinished() {
+		return nil, nil, ErrMatchFinished
+	}
+
+	game, err := s.gameService.gameRuleset(match.Variant, match.Dice)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	round := &matchRound{matchID: match.MatchID, round: len(match.Games) + 1}
+	result, err := s.gameService.playGame(ctx, match.PlayerID, clientSeed, match.Generator, game, round)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	match.Games = append(match.Games, result)
+	tallyMatch(match, result.PlayedAt)
+
+	if err := s.matchRepo.UpdateMatch(ctx, match); err != nil {
+		return nil, nil, fmt.Errorf("failed to update match: %w", err)
+	}
+
+	return match, result, nil
+}
+
+// PlayMatch starts a match and plays rounds until it is decided. When a
+// round fails the match is left in progress and can be finished with
+// PlayRound.
+func (s *MatchService) PlayMatch(ctx context.Context, playerID, clientSeed string, bestOf int, generatorName, diceNotation, variantName string) (*model.Match, error) {
+	match, err := s.StartMatch(ctx, playerID, bestOf, generatorName, diceNotation, variantName)
+	if err != nil {
+		return nil, err
+	}
+
+	for !match.IsFinished() {
+		played, _, err := s.PlayRound(ctx, match.MatchID, clientSeed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to play round %d of match %s: %w", len(match.Games)+1, match.MatchID, err)
+		}
+		match = played
+	}
+
+	return match, nil
+}
+
+// GetMatch returns a match with its games. The tallies are recounted from
+// the games, so a match whose last update was lost is still reported
+// right.
+func (s *MatchService) GetMatch(ctx context.Context, matchID string) (*model.Match, error) {
+	match, err := s.matchRepo.GetMatch(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match: %w", err)
+	}
+	if match == nil {
+		return nil, ErrMatchNotFound
+	}
+
+	finishedAt := match.CreatedAt
+	if len(match.Games) > 0 {
+		finishedAt = match.Games[len(match.Games)-1].PlayedAt
+	}
+	tallyMatch(match, finishedAt)
+
+	return match, nil
+}
+
+func (s *MatchService) GetMatchStats(ctx context.Context, playerID string) (*model.MatchStats, error) {
+	stats, err := s.matchRepo.GetMatchStats(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// tallyMatch counts the games of a match and decides it once one side has
+// won more games than the other can still catch up with, or every game has
+// been played. A match that ends level is a draw. finishedAt is when a
+// match decided now finished.
+func tallyMatch(match *model.Match, finishedAt time.Time) {
+	match.PlayerWins, match.ServerWins, match.Draws = 0, 0, 0
+	for _, game := range match.Games {
+		switch game.Winner {
+		case model.WinnerPlayer:
+			match.PlayerWins++
+		case model.WinnerServer:
+			match.ServerWins++
+		default:
+			match.Draws++
+		}
+	}
+
+	remaining := match.BestOf - len(match.Games)
+	lead := match.PlayerWins - match.ServerWins
+	if remaining > 0 && lead <= remaining && -lead <= remaining {
+		match.Winner = ""
+		match.FinishedAt = nil
+		return
+	}
+
+	switch {
+	case lead > 0:
+		match.Winner = model.WinnerPlayer
+	case lead < 0:
+		match.Winner = model.WinnerServer
+	default:
+		match.Winner = model.WinnerDraw
+	}
+	if match.FinishedAt == nil {
+		match.FinishedAt = &finishedAt
+	}
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+)
+
+type MatchServiceInterface interface {
+	StartMatch(ctx context.Context, playerID string, bestOf int, generatorName string, diceNotation string, variantName string) (*model.Match, error)
+	PlayRound(ctx context.Context, matchID string, clientSeed string) (*model.Match, *model.GameResult, error)
+	PlayMatch(ctx context.Context, playerID string, clientSeed string, bestOf int, generatorName string, diceNotation string, variantName string) (*model.Match, error)
+	GetMatch(ctx context.Context, matchID string) (*model.Match, error)
+	GetMatchStats(ctx context.Context, playerID string) (*model.MatchStats, error)
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/rules"
+	"dice-game/pkg/infrastructure/random"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockMatchRepository struct {
+	mock.Mock
+}
+
+func (m *MockMatchRepository) SaveMatch(ctx context.Context, match *model.Match) error {
+	args := m.Called(ctx, match)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) GetMatch(ctx context.Context, matchID string) (*model.Match, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) UpdateMatch(ctx context.Context, match *model.Match) error {
+	args := m.Called(ctx, match)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) GetMatchStats(ctx context.Context, playerID string) (*model.MatchStats, error) {
+	args := m.Called(ctx, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MatchStats), args.Error(1)
+}
+
+// matchGameService returns a game service whose every game is rolled from
+// values by a mock generator.
+func matchGameService(values []int) *GameService {
+	mockRandom := new(MockRandomService)
+	mockRepo := new(MockGameRepository)
+	mockGen := new(MockGenerator)
+
+	mockRandom.On("SelectGenerator", "test-player", "").Return(mockGen, SelectionRandom, nil)
+	mockRandom.On("ReleaseGenerator", mock.Anything).Return()
+	mockRandom.On("ObserveRoll", mock.Anything, 1, 6, values).Return(nil)
+	mockRepo.On("NextNonce", mock.Anything, "test-player").Return(int64(1), nil)
+	mockGen.On("Roll", diceRollRequest("client-seed", 1)).Return(&random.Roll{Values: values}, nil)
+	mockGen.On("Name").Return("test_generator")
+	mockRepo.On("SaveGameResult", mock.Anything, mock.AnythingOfType("*model.GameResult")).Return(nil)
+
+	return NewGameService(mockRandom, mockRepo, new(MockSeedRepository), new(MockVerificationRepository))
+}
+
+func TestStartMatch(t *testing.T) {
+	// Arrange
+	mockMatches := new(MockMatchRepository)
+	mockMatches.On("SaveMatch", mock.Anything, mock.AnythingOfType("*model.Match")).Return(nil)
+
+	gameService := matchGameService(nil)
+	require.NoError(t, gameService.OfferDice([]string{"2d20kh1"}))
+	service := NewMatchService(gameService, mockMatches)
+
+	// Act
+	match, err := service.StartMatch(context.Background(), "test-player", 5, "", "2D20KH", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, match.MatchID)
+	assert.Equal(t, 5, match.BestOf)
+	assert.Equal(t, rules.Classic, match.Variant)
+	assert.Equal(t, "2d20kh1", match.Dice)
+	assert.False(t, match.IsFinished())
+	mockMatches.AssertExpectations(t)
+}
+
+func TestStartMatch_InvalidBestOf(t *testing.T) {
+	// Arrange
+	mockMatches := new(MockMatchRepository)
+	service := NewMatchService(matchGameService(nil), mockMatches)
+
+	// Act
+	match, err := service.StartMatch(context.Background(), "test-player", 4, "", "", "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidBestOf)
+	assert.Nil(t, match)
+	mockMatches.AssertNotCalled(t, "SaveMatch", mock.Anything, mock.Anything)
+}
+
+func TestPlayRound_DecidesMatch(t *testing.T) {
+	// Arrange
+	playedAt := time.Now()
+	match := &model.Match{
+		MatchID:  "match-1",
+		PlayerID: "test-player",
+		BestOf:   3,
+		Variant:  rules.Classic,
+		Games:    []*model.GameResult{{GameID: "game-1", Winner: model.WinnerPlayer, PlayedAt: playedAt}},
+	}
+
+	mockMatches := new(MockMatchRepository)
+	mockMatches.On("GetMatch", mock.Anything, "match-1").Return(match, nil)
+	mockMatches.On("UpdateMatch", mock.Anything, match).Return(nil)
+
+	service := NewMatchService(matchGameService([]int{5, 2}), mockMatches)
+
+	// Act
+	played, result, err := service.PlayRound(context.Background(), "match-1", "client-seed")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "match-1", result.MatchID)
+	assert.Equal(t, 2, result.MatchRound)
+	assert.Equal(t, model.WinnerPlayer, played.Winner)
+	assert.Equal(t, 2, played.PlayerWins)
+	assert.Equal(t, result.PlayedAt, *played.FinishedAt)
+	mockMatches.AssertExpectations(t)
+}
+
+func TestPlayRound_MatchFinished(t *testing.T) {
+	// Arrange
+	match := &model.Match{
+		MatchID: "match-1",
+		BestOf:  3,
+		Games: []*model.GameResult{
+			{Winner: model.WinnerServer},
+			{Winner: model.WinnerServer},
+		},
+	}
+
+	mockMatches := new(MockMatchRepository)
+	mockMatches.On("GetMatch", mock.Anything, "match-1").Return(match, nil)
+
+	service := NewMatchService(matchGameService(nil), mockMatches)
+
+	// Act
+	_, _, err := service.PlayRound(context.Background(), "match-1", "client-seed")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrMatchFinished)
+	mockMatches.AssertNotCalled(t, "UpdateMatch", mock.Anything, mock.Anything)
+}
+
+func TestGetMatch_NotFound(t *testing.T) {
+	// Arrange
+	mockMatches := new(MockMatchRepository)
+	mockMatches.On("GetMatch", mock.Anything, "missing").Return(nil, nil)
+
+	service := NewMatchService(matchGameService(nil), mockMatches)
+
+	// Act
+	match, err := service.GetMatch(context.Background(), "missing")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrMatchNotFound)
+	assert.Nil(t, match)
+}
+
+func TestPlayMatch(t *testing.T) {
+	// Arrange
+	mockMatches := new(MockMatchRepository)
+	// The service keeps appending to the match it was handed, so returning
+	// the saved match every time mirrors the stored games.
+	mockMatches.On("SaveMatch", mock.Anything, mock.AnythingOfType("*model.Match")).
+		Run(func(args mock.Arguments) {
+			saved := args.Get(1).(*model.Match)
+			mockMatches.On("GetMatch", mock.Anything, saved.MatchID).Return(saved, nil)
+		}).
+		Return(nil)
+	mockMatches.On("UpdateMatch", mock.Anything, mock.AnythingOfType("*model.Match")).Return(nil)
+
+	service := NewMatchService(matchGameService([]int{3, 3}), mockMatches)
+
+	// Act
+	match, err := service.PlayMatch(context.Background(), "test-player", "client-seed", 3, "", "", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, match.Games, 3)
+	for i, game := range match.Games {
+		assert.Equal(t, match.MatchID, game.MatchID)
+		assert.Equal(t, i+1, game.MatchRound)
+	}
+	assert.Equal(t, 3, match.Draws)
+	assert.Equal(t, model.WinnerDraw, match.Winner)
+	mockMatches.AssertNumberOfCalls(t, "UpdateMatch", 3)
+}
+
+func TestTallyMatch(t *testing.T) {
+	p, s, d := model.WinnerPlayer, model.WinnerServer, model.WinnerDraw
+
+	tests := []struct {
+		name   string
+		bestOf int
+		games  []model.Winner
+		winner model.Winner
+	}{
+		{"Not started", 3, nil, ""},
+		{"One win of three", 3, []model.Winner{p}, ""},
+		{"Two wins of three", 3, []model.Winner{p, p}, p},
+		{"Level after two of three", 3, []model.Winner{p, s}, ""},
+		{"Draws keep it open", 3, []model.Winner{d, p}, ""},
+		{"Decided by the last game", 3, []model.Winner{d, d, s}, s},
+		{"Level after all games", 3, []model.Winner{p, d, s}, d},
+		{"Three wins of five", 5, []model.Winner{s, p, s, s}, s},
+		{"Lead the other can still draw level with", 7, []model.Winner{p, p, p, d}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := &model.Match{BestOf: tt.bestOf}
+			for _, winner := range tt.games {
+				match.Games = append(match.Games, &model.GameResult{Winner: winner})
+			}
+
+			tallyMatch(match, time.Now())
+
+			assert.Equal(t, tt.winner, match.Winner)
+			assert.Equal(t, tt.winner != "", match.FinishedAt != nil)
+		})
+	}
+}
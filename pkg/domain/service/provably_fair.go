@@ -0,0 +1,140 @@
+package service
+
+import (
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/infrastructure/random"
+	"dice-game/pkg/infrastructure/vrf"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MismatchError reports a game whose stored data disagrees with the values
+// recomputed from its seeds.
+type MismatchError struct {
+	Field    string
+	Stored   string
+	Computed string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%s mismatch: stored %s, recomputed %s", e.Field, e.Stored, e.Computed)
+}
+
+// CheckProvablyFair recomputes a provably fair game from its revealed server
+// seed with the scheme recorded on the game. It performs no I/O, so offline
+// tools reach the same verdict as GameService.VerifyGame. A nil error means
+// the game is valid; a *MismatchError means the recomputation disagrees with
+// the stored result.
+func CheckProvablyFair(result *model.GameResult, serverSeed string) error {
+	key, err := random.ParseVerificationKey(result.VerificationKey)
+	if err != nil {
+		return err
+	}
+
+	if key.Version != result.AlgorithmVersion {
+		return &MismatchError{Field: "algorithm_version", Stored: strconv.Itoa(result.AlgorithmVersion), Computed: strconv.Itoa(key.Version)}
+	}
+
+	scheme, err := random.SchemeByVersion(result.AlgorithmVersion)
+	if err != nil {
+		return err
+	}
+
+	if key.Nonce != result.Nonce {
+		return &MismatchError{Field: "nonce", Stored: strconv.FormatInt(result.Nonce, 10), Computed: strconv.FormatInt(key.Nonce, 10)}
+	}
+
+	if seedHash := random.HashServerSeed(serverSeed); seedHash != key.ServerSeedHash {
+		return &MismatchError{Field: "server_seed_hash", Stored: key.ServerSeedHash, Computed: seedHash}
+	}
+
+	r, err := storedRuleset(result)
+	if err != nil {
+		return err
+	}
+
+	plan := r.plan()
+	values, hash, err := random.ProvablyFairValues(scheme, serverSeed, result.PlayerID, result.ClientSeed, result.Nonce, plan.Count, plan.Min, plan.Max)
+	if err != nil {
+		return fmt.Errorf("failed to calculate dice: %w", err)
+	}
+
+	if hash != key.Hash {
+		return &MismatchError{Field: "hash", Stored: key.Hash, Computed: hash}
+	}
+
+	return checkDice(result, r, values)
+}
+
+// CheckBeacon verifies a beacon game: the round must be the one recorded on
+// the game and must have been published after the game was played, the
+// server seed must have been committed at seedCreatedAt before the round was
+// published, and the roll must match the provably fair recomputation with
+// the beacon client seed. A round that was already public when the game was
+// played could have been picked by the server, and a seed committed once the
+// round was public could have been picked to suit it; they are reported as
+// a *MismatchError on field "beacon_published_at".
+func CheckBeacon(result *model.GameResult, serverSeed string, seedCreatedAt time.Time, round *random.BeaconRound) error {
+	if round.Round != result.BeaconRound {
+		return &MismatchError{Field: "beacon_round", Stored: strconv.FormatInt(result.BeaconRound, 10), Computed: strconv.FormatInt(round.Round, 10)}
+	}
+
+	if !round.PublishedAt.After(result.PlayedAt) {
+		return &MismatchError{
+			Field:    "beacon_published_at",
+			Stored:   "after " + result.PlayedAt.UTC().Format(time.RFC3339Nano),
+			Computed: round.PublishedAt.UTC().Format(time.RFC3339Nano),
+		}
+	}
+
+	if !seedCreatedAt.Before(round.PublishedAt) {
+		return &MismatchError{
+			Field:    "beacon_published_at",
+			Stored:   "after server seed created at " + seedCreatedAt.UTC().Format(time.RFC3339Nano),
+			Computed: round.PublishedAt.UTC().Format(time.RFC3339Nano),
+		}
+	}
+
+	mixed := *result
+	mixed.ClientSeed = random.BeaconClientSeed(result.ClientSeed, round)
+
+	return CheckProvablyFair(&mixed, serverSeed)
+}
+
+// CheckVRF verifies the VRF proof of a game against the server's public key
+// and compares the dice it commits to with the stored result. Like
+// CheckProvablyFair it performs no I/O; an invalid proof is reported as a
+// *MismatchError on field "proof".
+func CheckVRF(result *model.GameResult, publicKey []byte) error {
+	proof, err := random.ParseVRFProof(result.VerificationKey)
+	if err != nil {
+		return err
+	}
+
+	if trusted := hex.EncodeToString(publicKey); proof.PublicKey != trusted {
+		return &MismatchError{Field: "public_key", Stored: proof.PublicKey, Computed: trusted}
+	}
+
+	if proof.Nonce != result.Nonce {
+		return &MismatchError{Field: "nonce", Stored: strconv.FormatInt(result.Nonce, 10), Computed: strconv.FormatInt(proof.Nonce, 10)}
+	}
+
+	r, err := storedRuleset(result)
+	if err != nil {
+		return err
+	}
+
+	plan := r.plan()
+	values, err := random.VerifyVRFValues(proof, result.PlayerID, result.ClientSeed, plan.Count, plan.Min, plan.Max)
+	if errors.Is(err, vrf.ErrInvalidProof) {
+		return &MismatchError{Field: "proof", Stored: proof.Proof, Computed: "invalid"}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to verify vrf proof: %w", err)
+	}
+
+	return checkDice(result, r, values)
+}
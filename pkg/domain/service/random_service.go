@@ -1,44 +1,377 @@
 package service
 
 import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
 	"dice-game/pkg/infrastructure/random"
 	"errors"
-	"math/rand"
+	"fmt"
 	"time"
 )
 
 type RandomService struct {
-	generators []random.Generator
-	rnd        *rand.Rand
+	registry *GeneratorRegistry
+	selector GeneratorSelector
+
+	seedChains      repository.SeedChainRepository
+	seedChainLength int
+
+	health *HealthMonitor
+
+	// choosable are the generators players may request by name.
+	choosable map[string]bool
 }
 
+// ErrGeneratorNotAllowed is returned when a player requests a generator
+// that is not on the allow-list.
+var ErrGeneratorNotAllowed = errors.New("generator may not be chosen by players")
+
 func NewRandomService(generators []random.Generator) *RandomService {
 	return &RandomService{
-		generators: generators,
-		rnd:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		registry: NewGeneratorRegistry(generators),
+		selector: NewRandomSelector(),
 	}
 }
 
-func (s *RandomService) GetRandomGenerator() (random.Generator, error) {
-	if len(s.generators) == 0 {
-		return nil, errors.New("no random generators available")
+// SelectGenerator picks the generator of playerID's next game and returns
+// the name of the strategy that picked it. A requested generator is used if
+// players may choose it; otherwise the selection strategy picks among the
+// enabled generators that are not quarantined by the health monitor. The
+// game is in flight on the generator until ReleaseGenerator is called.
+func (s *RandomService) SelectGenerator(playerID, requested string) (random.Generator, string, error) {
+	if requested != "" {
+		generator, err := s.requestedGenerator(requested)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := s.registry.Acquire(generator.Name()); err != nil {
+			return nil, "", err
+		}
+		return generator, SelectionRequested, nil
+	}
+
+	if s.registry.Len() == 0 {
+		return nil, "", errors.New("no random generators available")
+	}
+
+	// The strategy picks under the registry lock, so a generator disabled
+	// or drained meanwhile is never picked and then refused.
+	generator, err := s.registry.AcquireSelected(func(enabled []random.Generator) (random.Generator, error) {
+		return s.pickGenerator(playerID, enabled)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return generator, s.selector.Name(), nil
+}
+
+// ReleaseGenerator ends a game in flight started by SelectGenerator.
+func (s *RandomService) ReleaseGenerator(name string) {
+	s.registry.Release(name)
+}
+
+// pickGenerator lets the selection strategy pick among the enabled
+// generators that are neither quarantined nor unhealthy.
+func (s *RandomService) pickGenerator(playerID string, enabled []random.Generator) (random.Generator, error) {
+	if len(enabled) == 0 {
+		return nil, errors.New("every random generator is disabled")
+	}
+
+	candidates := make([]random.Generator, 0, len(enabled))
+	quarantined := 0
+	for _, gen := range enabled {
+		switch {
+		case s.health != nil && s.health.IsQuarantined(gen.Name()):
+			quarantined++
+		case isUnhealthy(gen):
+		default:
+			candidates = append(candidates, gen)
+		}
+	}
+
+	if len(candidates) == 0 {
+		if quarantined == len(enabled) {
+			return nil, errors.New("every random generator is quarantined")
+		}
+		return nil, errors.New("every random generator is quarantined or unhealthy")
 	}
 
-	idx := s.rnd.Intn(len(s.generators))
+	generator, err := s.selector.Select(playerID, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("%s generator selection failed: %w", s.selector.Name(), err)
+	}
 
-	return s.generators[idx], nil
+	return generator, nil
 }
 
+func (s *RandomService) requestedGenerator(name string) (random.Generator, error) {
+	if !s.choosable[name] {
+		return nil, fmt.Errorf("%w: %s", ErrGeneratorNotAllowed, name)
+	}
+
+	generator, err := s.GetGeneratorByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("generator %s is not enabled", name)
+	}
+
+	if s.health != nil && s.health.IsQuarantined(name) {
+		return nil, fmt.Errorf("generator %s is quarantined", name)
+	}
+
+	if isUnhealthy(generator) {
+		return nil, fmt.Errorf("generator %s is unhealthy", name)
+	}
+
+	return generator, nil
+}
+
+// isUnhealthy reports whether a generator that checks its own health, such
+// as a plugin, currently cannot roll.
+func isUnhealthy(generator random.Generator) bool {
+	reporting, ok := generator.(HealthReportingGenerator)
+	return ok && !reporting.Healthy()
+}
+
+// AllowGeneratorChoice sets the generators players may request by name.
+func (s *RandomService) AllowGeneratorChoice(names []string) {
+	s.choosable = make(map[string]bool, len(names))
+	for _, name := range names {
+		s.choosable[name] = true
+	}
+}
+
+// ListGenerators describes every registered generator in registration
+// order.
+func (s *RandomService) ListGenerators() []model.GeneratorInfo {
+	registered := s.registry.Snapshot()
+	infos := make([]model.GeneratorInfo, 0, len(registered))
+	for _, entry := range registered {
+		gen := entry.Generator
+		info := model.GeneratorInfo{
+			Name:        gen.Name(),
+			Selectable:  s.choosable[gen.Name()],
+			Quarantined: s.health != nil && s.health.IsQuarantined(gen.Name()),
+			State:       entry.State,
+			InFlight:    entry.InFlight,
+		}
+		if verifiable, ok := gen.(VerifiableGenerator); ok {
+			info.Verifiable = true
+			info.Algorithm = verifiable.Algorithm()
+			info.AlgorithmVersion = verifiable.AlgorithmVersion()
+		}
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// UseSelector replaces the default uniform random selection strategy.
+func (s *RandomService) UseSelector(selector GeneratorSelector) {
+	s.selector = selector
+}
+
+// GetGeneratorByName returns a registered generator whether or not it is
+// enabled.
 func (s *RandomService) GetGeneratorByName(name string) (random.Generator, error) {
-	for _, gen := range s.generators {
-		if gen.Name() == name {
-			return gen, nil
+	return s.registry.Get(name)
+}
+
+// AddGenerator registers an enabled generator. Names must be unique.
+func (s *RandomService) AddGenerator(generator random.Generator) error {
+	return s.registry.Register(generator)
+}
+
+// RemoveGenerator unregisters a generator with no games in flight. A
+// generator registered again under the same name starts with a fresh
+// health state.
+func (s *RandomService) RemoveGenerator(name string) error {
+	if err := s.registry.Unregister(name); err != nil {
+		return err
+	}
+
+	s.ResetGeneratorHealth(name)
+	return nil
+}
+
+func (s *RandomService) EnableGenerator(name string) error {
+	return s.registry.Enable(name)
+}
+
+func (s *RandomService) DisableGenerator(name string) error {
+	return s.registry.Disable(name)
+}
+
+// DrainGenerator takes a generator out of rotation and waits until its games
+// in flight finish or ctx is done.
+func (s *RandomService) DrainGenerator(ctx context.Context, name string) error {
+	return s.registry.Drain(ctx, name)
+}
+
+// UseHealthMonitor enables online health tests. Generators the monitor
+// quarantines are no longer picked by SelectGenerator.
+func (s *RandomService) UseHealthMonitor(monitor *HealthMonitor) {
+	s.health = monitor
+}
+
+// ResetGeneratorHealth lifts the generator's quarantine and restarts its
+// health tests. It reports whether the generator was quarantined.
+func (s *RandomService) ResetGeneratorHealth(name string) bool {
+	if s.health == nil {
+		return false
+	}
+
+	return s.health.Reset(name)
+}
+
+// ObserveRoll passes the values of a roll to the health monitor and returns
+// an error if the generator is quarantined, in which case the values must
+// not be used.
+func (s *RandomService) ObserveRoll(generator string, min, max int, values []int) error {
+	if s.health == nil {
+		return nil
+	}
+
+	return s.health.Observe(generator, min, max, values)
+}
+
+// GetGeneratorHealth returns the health state of every registered generator,
+// one entry per range it draws from, or nil when health monitoring is
+// disabled.
+func (s *RandomService) GetGeneratorHealth() []model.GeneratorHealth {
+	if s.health == nil {
+		return nil
+	}
+
+	registered := s.registry.Snapshot()
+	report := make([]model.GeneratorHealth, 0, len(registered))
+	for _, entry := range registered {
+		report = append(report, s.health.Health(entry.Generator.Name())...)
+	}
+
+	return report
+}
+
+// UseSeedChains enables hash chain seeds. When the active chain runs out a
+// new chain of length links is generated.
+func (s *RandomService) UseSeedChains(seedChains repository.SeedChainRepository, length int) {
+	s.seedChains = seedChains
+	s.seedChainLength = length
+}
+
+// CreateSeedChain generates and stores a new chain. Only its terminal hash
+// may be published until the links are consumed.
+func (s *RandomService) CreateSeedChain(ctx context.Context, length int) (*model.SeedChain, error) {
+	if s.seedChains == nil {
+		return nil, errors.New("seed chains are not enabled")
+	}
+
+	seeds, terminalHash, err := random.GenerateSeedChain(length)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate seed chain: %w", err)
+	}
+
+	links := make([]*model.SeedChainLink, len(seeds))
+	for i, seed := range seeds {
+		links[i] = &model.SeedChainLink{
+			Position: i + 1,
+			Seed:     seed,
+			Hash:     random.HashServerSeed(seed),
 		}
 	}
 
-	return nil, errors.New("generator not found")
+	chain := &model.SeedChain{
+		TerminalHash: terminalHash,
+		Length:       length,
+		CreatedAt:    time.Now(),
+	}
+
+	if err := s.seedChains.SaveSeedChain(ctx, chain, links); err != nil {
+		return nil, fmt.Errorf("failed to save seed chain: %w", err)
+	}
+
+	return chain, nil
 }
 
-func (s *RandomService) AddGenerator(generator random.Generator) {
-	s.generators = append(s.generators, generator)
+func (s *RandomService) GetActiveSeedChain(ctx context.Context) (*model.SeedChain, error) {
+	if s.seedChains == nil {
+		return nil, errors.New("seed chains are not enabled")
+	}
+
+	return s.seedChains.GetActiveSeedChain(ctx)
+}
+
+// ConsumeSeedChainLink hands out the next link of the active chain, starting
+// a new chain when every existing one is exhausted.
+func (s *RandomService) ConsumeSeedChainLink(ctx context.Context) (*model.SeedChainLink, error) {
+	if s.seedChains == nil {
+		return nil, errors.New("seed chains are not enabled")
+	}
+
+	link, err := s.seedChains.ConsumeNextLink(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume seed chain link: %w", err)
+	}
+	if link != nil {
+		return link, nil
+	}
+
+	if _, err := s.CreateSeedChain(ctx, s.seedChainLength); err != nil {
+		return nil, err
+	}
+
+	link, err = s.seedChains.ConsumeNextLink(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume seed chain link: %w", err)
+	}
+	if link == nil {
+		return nil, errors.New("seed chain exhausted")
+	}
+
+	return link, nil
+}
+
+// GetSeedChainLink returns a consumed link together with its chain. Links
+// that have not been used by a game stay secret.
+func (s *RandomService) GetSeedChainLink(ctx context.Context, seedHash string) (*model.SeedChainLink, *model.SeedChain, error) {
+	if s.seedChains == nil {
+		return nil, nil, errors.New("seed chains are not enabled")
+	}
+
+	link, err := s.seedChains.GetLinkByHash(ctx, seedHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get seed chain link: %w", err)
+	}
+
+	if !link.IsConsumed() {
+		return nil, nil, errors.New("seed chain link has not been used yet")
+	}
+
+	chain, err := s.seedChains.GetSeedChain(ctx, link.ChainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get seed chain: %w", err)
+	}
+
+	return link, chain, nil
+}
+
+// VerifySeedChainLink returns the consumed link with the given hash after
+// checking that it walks to its chain's terminal hash. A link that does not
+// is reported as a *MismatchError.
+func (s *RandomService) VerifySeedChainLink(ctx context.Context, seedHash string) (*model.SeedChainLink, error) {
+	link, chain, err := s.GetSeedChainLink(ctx, seedHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if random.HashServerSeed(link.Seed) != link.Hash {
+		return nil, &MismatchError{Field: "server_seed_hash", Stored: link.Hash, Computed: random.HashServerSeed(link.Seed)}
+	}
+
+	if walked := random.WalkSeedChain(link.Seed, link.Position); walked != chain.TerminalHash {
+		return nil, &MismatchError{Field: "terminal_hash", Stored: chain.TerminalHash, Computed: walked}
+	}
+
+	return link, nil
 }
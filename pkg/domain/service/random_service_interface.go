@@ -1,16 +1,87 @@
 package service
 
 import (
+	"context"
+	"dice-game/pkg/domain/model"
 	"dice-game/pkg/infrastructure/random"
+	"time"
 )
 
 type RandomServiceInterface interface {
-	GetRandomGenerator() (random.Generator, error)
+	SelectGenerator(playerID, requested string) (random.Generator, string, error)
+	ReleaseGenerator(name string)
 	GetGeneratorByName(name string) (random.Generator, error)
-	AddGenerator(generator random.Generator)
+	AddGenerator(generator random.Generator) error
+	RemoveGenerator(name string) error
+	EnableGenerator(name string) error
+	DisableGenerator(name string) error
+	DrainGenerator(ctx context.Context, name string) error
+	UseSelector(selector GeneratorSelector)
+	AllowGeneratorChoice(names []string)
+	ListGenerators() []model.GeneratorInfo
+	ResetGeneratorHealth(name string) bool
+	ObserveRoll(generator string, min, max int, values []int) error
+	GetGeneratorHealth() []model.GeneratorHealth
+
+	CreateSeedChain(ctx context.Context, length int) (*model.SeedChain, error)
+	GetActiveSeedChain(ctx context.Context) (*model.SeedChain, error)
+	ConsumeSeedChainLink(ctx context.Context) (*model.SeedChainLink, error)
+	GetSeedChainLink(ctx context.Context, seedHash string) (*model.SeedChainLink, *model.SeedChain, error)
+	VerifySeedChainLink(ctx context.Context, seedHash string) (*model.SeedChainLink, error)
+}
+
+type SeedRotatableGenerator interface {
+	random.Generator
+	ServerSeedHash() string
+	RotateServerSeed(serverSeed string) string
+}
+
+// SeedChainGenerator rolls with a server seed taken from a seed chain link
+// for every game.
+type SeedChainGenerator interface {
+	random.Generator
+	RollWithServerSeed(serverSeed string, req random.RollRequest) (*random.Roll, error)
+}
+
+// HealthReportingGenerator is a generator that knows whether it can roll,
+// such as a plugin whose process has crashed. SelectGenerator skips it
+// while it reports itself unhealthy.
+type HealthReportingGenerator interface {
+	random.Generator
+	Healthy() bool
 }
 
+// VerifiableGenerator is a generator whose games players can verify.
+// AlgorithmVersion is the provably fair scheme version, or 0 for algorithms
+// outside the versioned schemes.
 type VerifiableGenerator interface {
 	random.Generator
-	GetVerificationData() string
+	Algorithm() string
+	AlgorithmVersion() int
+}
+
+// NonProductionGenerator is a generator whose outcomes can be known in
+// advance, such as the deterministic replay generator. Its games are
+// flagged as non-production.
+type NonProductionGenerator interface {
+	random.Generator
+	NonProduction() bool
+}
+
+// VRFGenerator proves each roll with a verifiable random function under a
+// published public key.
+type VRFGenerator interface {
+	random.Generator
+	PublicKey() []byte
+}
+
+// BeaconGenerator mixes a randomness beacon round into every roll. Each
+// game waits for the first round published after it was committed, and the
+// round is looked up again when the game is verified.
+type BeaconGenerator interface {
+	random.Generator
+	NextBeaconRound(ctx context.Context, t time.Time) (*random.BeaconRound, error)
+	BeaconRound(ctx context.Context, round int64) (*random.BeaconRound, error)
+	ServerSeed() string
+	RollWithBeacon(serverSeed string, round *random.BeaconRound, req random.RollRequest) (*random.Roll, error)
 }
@@ -1,11 +1,58 @@
 package service
 
 import (
+	"context"
+	"dice-game/pkg/domain/model"
 	"dice-game/pkg/infrastructure/random"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
+type MockSeedChainRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeedChainRepository) SaveSeedChain(ctx context.Context, chain *model.SeedChain, links []*model.SeedChainLink) error {
+	args := m.Called(ctx, chain, links)
+	return args.Error(0)
+}
+
+func (m *MockSeedChainRepository) GetSeedChain(ctx context.Context, chainID int64) (*model.SeedChain, error) {
+	args := m.Called(ctx, chainID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SeedChain), args.Error(1)
+}
+
+func (m *MockSeedChainRepository) GetActiveSeedChain(ctx context.Context) (*model.SeedChain, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SeedChain), args.Error(1)
+}
+
+func (m *MockSeedChainRepository) ConsumeNextLink(ctx context.Context, consumedAt time.Time) (*model.SeedChainLink, error) {
+	args := m.Called(ctx, consumedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SeedChainLink), args.Error(1)
+}
+
+func (m *MockSeedChainRepository) GetLinkByHash(ctx context.Context, seedHash string) (*model.SeedChainLink, error) {
+	args := m.Called(ctx, seedHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SeedChainLink), args.Error(1)
+}
+
 func TestNewRandomService(t *testing.T) {
 	// Arrange
 	gen1 := new(MockGenerator)
@@ -17,11 +64,11 @@ func TestNewRandomService(t *testing.T) {
 
 	// Assert
 	assert.NotNil(t, service)
-	assert.Equal(t, 2, len(service.generators))
-	assert.NotNil(t, service.rnd)
+	assert.Equal(t, 2, service.registry.Len())
+	assert.Equal(t, SelectionRandom, service.selector.Name())
 }
 
-func TestRandomService_GetRandomGenerator(t *testing.T) {
+func TestRandomService_SelectGenerator(t *testing.T) {
 	t.Run("Success with multiple generators", func(t *testing.T) {
 		// Arrange
 		gen1 := new(MockGenerator)
@@ -33,12 +80,13 @@ func TestRandomService_GetRandomGenerator(t *testing.T) {
 		service := NewRandomService(generators)
 
 		// Act
-		generator, err := service.GetRandomGenerator()
+		generator, strategy, err := service.SelectGenerator("player", "")
 
 		// Assert
 		assert.NoError(t, err)
 		assert.NotNil(t, generator)
 		assert.Contains(t, []random.Generator{gen1, gen2}, generator)
+		assert.Equal(t, SelectionRandom, strategy)
 	})
 
 	t.Run("Success with single generator", func(t *testing.T) {
@@ -50,11 +98,12 @@ func TestRandomService_GetRandomGenerator(t *testing.T) {
 		service := NewRandomService(generators)
 
 		// Act
-		generator, err := service.GetRandomGenerator()
+		generator, strategy, err := service.SelectGenerator("player", "")
 
 		// Assert
 		assert.NoError(t, err)
 		assert.Equal(t, gen, generator)
+		assert.Equal(t, SelectionRandom, strategy)
 	})
 
 	t.Run("Error when no generators", func(t *testing.T) {
@@ -62,11 +111,12 @@ func TestRandomService_GetRandomGenerator(t *testing.T) {
 		service := NewRandomService([]random.Generator{})
 
 		// Act
-		generator, err := service.GetRandomGenerator()
+		generator, strategy, err := service.SelectGenerator("player", "")
 
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, generator)
+		assert.Empty(t, strategy)
 		assert.Equal(t, "no random generators available", err.Error())
 	})
 }
@@ -126,17 +176,19 @@ func TestRandomService_AddGenerator(t *testing.T) {
 	gen1 := new(MockGenerator)
 	gen1.On("Name").Return("gen1").Maybe()
 	service := NewRandomService([]random.Generator{gen1})
-	assert.Equal(t, 1, len(service.generators))
+	assert.Equal(t, 1, service.registry.Len())
 
 	gen2 := new(MockGenerator)
 	gen2.On("Name").Return("gen2").Maybe()
 
 	// Act
-	service.AddGenerator(gen2)
+	err := service.AddGenerator(gen2)
 
 	// Assert
-	assert.Equal(t, 2, len(service.generators))
-	assert.Equal(t, gen2, service.generators[1])
+	assert.NoError(t, err)
+	assert.Equal(t, 2, service.registry.Len())
+	assert.Equal(t, gen2, service.registry.Snapshot()[1].Generator)
+	assert.EqualError(t, service.AddGenerator(gen2), "generator gen2 is already registered")
 
 	generator, err := service.GetGeneratorByName("gen2")
 	assert.NoError(t, err)
@@ -168,7 +220,7 @@ func TestRandomService_Distribution(t *testing.T) {
 		counts := make(map[random.Generator]int)
 
 		for i := 0; i < iterations; i++ {
-			gen, err := service.GetRandomGenerator()
+			gen, _, err := service.SelectGenerator("player", "")
 			assert.NoError(t, err)
 			counts[gen]++
 		}
@@ -184,3 +236,282 @@ func TestRandomService_Distribution(t *testing.T) {
 		}
 	})
 }
+
+func TestRandomService_SeedChainsDisabled(t *testing.T) {
+	// Arrange
+	service := NewRandomService(nil)
+
+	// Act
+	link, err := service.ConsumeSeedChainLink(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, link)
+}
+
+func TestRandomService_CreateSeedChain(t *testing.T) {
+	// Arrange
+	repo := new(MockSeedChainRepository)
+	var savedLinks []*model.SeedChainLink
+	repo.On("SaveSeedChain", mock.Anything, mock.AnythingOfType("*model.SeedChain"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			savedLinks = args.Get(2).([]*model.SeedChainLink)
+		}).
+		Return(nil)
+
+	service := NewRandomService(nil)
+	service.UseSeedChains(repo, 100)
+
+	// Act
+	chain, err := service.CreateSeedChain(context.Background(), 4)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, chain.Length)
+	assert.Len(t, savedLinks, 4)
+
+	for i, link := range savedLinks {
+		assert.Equal(t, i+1, link.Position)
+		assert.Equal(t, random.HashServerSeed(link.Seed), link.Hash)
+		assert.Equal(t, chain.TerminalHash, random.WalkSeedChain(link.Seed, link.Position))
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestRandomService_ConsumeSeedChainLink(t *testing.T) {
+	t.Run("Next link of the active chain", func(t *testing.T) {
+		// Arrange
+		repo := new(MockSeedChainRepository)
+		link := &model.SeedChainLink{ChainID: 1, Position: 1, Seed: "seed"}
+		repo.On("ConsumeNextLink", mock.Anything, mock.AnythingOfType("time.Time")).Return(link, nil)
+
+		service := NewRandomService(nil)
+		service.UseSeedChains(repo, 100)
+
+		// Act
+		got, err := service.ConsumeSeedChainLink(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, link, got)
+		repo.AssertNotCalled(t, "SaveSeedChain", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("New chain when exhausted", func(t *testing.T) {
+		// Arrange
+		repo := new(MockSeedChainRepository)
+		link := &model.SeedChainLink{ChainID: 2, Position: 1, Seed: "seed"}
+		repo.On("ConsumeNextLink", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+		repo.On("SaveSeedChain", mock.Anything, mock.MatchedBy(func(chain *model.SeedChain) bool {
+			return chain.Length == 3
+		}), mock.Anything).Return(nil)
+		repo.On("ConsumeNextLink", mock.Anything, mock.AnythingOfType("time.Time")).Return(link, nil).Once()
+
+		service := NewRandomService(nil)
+		service.UseSeedChains(repo, 3)
+
+		// Act
+		got, err := service.ConsumeSeedChainLink(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, link, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		repo := new(MockSeedChainRepository)
+		repo.On("ConsumeNextLink", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, errors.New("database error"))
+
+		service := NewRandomService(nil)
+		service.UseSeedChains(repo, 3)
+
+		// Act
+		got, err := service.ConsumeSeedChainLink(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestRandomService_VerifySeedChainLink(t *testing.T) {
+	seeds, terminalHash, _ := random.GenerateSeedChain(3)
+	consumedAt := time.Now()
+	chain := &model.SeedChain{ID: 1, TerminalHash: terminalHash, Length: 3}
+
+	linkAt := func(position int) *model.SeedChainLink {
+		seed := seeds[position-1]
+		return &model.SeedChainLink{ChainID: 1, Position: position, Seed: seed, Hash: random.HashServerSeed(seed), ConsumedAt: &consumedAt}
+	}
+
+	t.Run("Link on chain", func(t *testing.T) {
+		// Arrange
+		repo := new(MockSeedChainRepository)
+		link := linkAt(2)
+		repo.On("GetLinkByHash", mock.Anything, link.Hash).Return(link, nil)
+		repo.On("GetSeedChain", mock.Anything, int64(1)).Return(chain, nil)
+
+		service := NewRandomService(nil)
+		service.UseSeedChains(repo, 3)
+
+		// Act
+		got, err := service.VerifySeedChainLink(context.Background(), link.Hash)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, link, got)
+	})
+
+	t.Run("Wrong position", func(t *testing.T) {
+		// Arrange
+		repo := new(MockSeedChainRepository)
+		link := linkAt(2)
+		link.Position = 3
+		repo.On("GetLinkByHash", mock.Anything, link.Hash).Return(link, nil)
+		repo.On("GetSeedChain", mock.Anything, int64(1)).Return(chain, nil)
+
+		service := NewRandomService(nil)
+		service.UseSeedChains(repo, 3)
+
+		// Act
+		_, err := service.VerifySeedChainLink(context.Background(), link.Hash)
+
+		// Assert
+		var mismatch *MismatchError
+		assert.ErrorAs(t, err, &mismatch)
+		assert.Equal(t, "terminal_hash", mismatch.Field)
+	})
+
+	t.Run("Unused link stays secret", func(t *testing.T) {
+		// Arrange
+		repo := new(MockSeedChainRepository)
+		link := linkAt(3)
+		link.ConsumedAt = nil
+		repo.On("GetLinkByHash", mock.Anything, link.Hash).Return(link, nil)
+
+		service := NewRandomService(nil)
+		service.UseSeedChains(repo, 3)
+
+		// Act
+		got, err := service.VerifySeedChainLink(context.Background(), link.Hash)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		repo.AssertNotCalled(t, "GetSeedChain", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRandomService_SelectRequestedGenerator(t *testing.T) {
+	// Arrange
+	standard := random.NewStandardGenerator()
+	provablyFair := random.NewProovablyFairGenerator("server-seed", latestScheme(t))
+	randomService := NewRandomService([]random.Generator{standard, provablyFair})
+	randomService.AllowGeneratorChoice([]string{"provably_fair", "vrf"})
+
+	t.Run("Allowed generator", func(t *testing.T) {
+		// Act
+		generator, strategy, err := randomService.SelectGenerator("player", "provably_fair")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, provablyFair, generator)
+		assert.Equal(t, SelectionRequested, strategy)
+	})
+
+	t.Run("Generator not on the allow-list", func(t *testing.T) {
+		// Act
+		_, _, err := randomService.SelectGenerator("player", "standard")
+
+		// Assert
+		assert.True(t, errors.Is(err, ErrGeneratorNotAllowed))
+	})
+
+	t.Run("Allowed generator that is not enabled", func(t *testing.T) {
+		// Act
+		_, _, err := randomService.SelectGenerator("player", "vrf")
+
+		// Assert
+		assert.EqualError(t, err, "generator vrf is not enabled")
+	})
+
+	t.Run("Quarantined generator", func(t *testing.T) {
+		// Arrange
+		randomService.UseHealthMonitor(NewHealthMonitor(120, nil))
+		_ = randomService.ObserveRoll("provably_fair", 1, 6, make([]int, 20))
+
+		// Act
+		_, _, err := randomService.SelectGenerator("player", "provably_fair")
+
+		// Assert
+		assert.EqualError(t, err, "generator provably_fair is quarantined")
+	})
+}
+
+func TestRandomService_SkipsUnhealthyPlugins(t *testing.T) {
+	// Arrange
+	crypto := random.NewCryptoGenerator()
+	plugin := random.NewPluginGenerator(random.PluginConfig{Name: "plugin", Command: "/nonexistent/plugin"})
+	require.Error(t, plugin.Start())
+	randomService := NewRandomService([]random.Generator{plugin, crypto})
+	randomService.AllowGeneratorChoice([]string{"plugin"})
+
+	t.Run("Selection strategy", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			// Act
+			generator, _, err := randomService.SelectGenerator("player", "")
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, crypto, generator)
+			randomService.ReleaseGenerator(generator.Name())
+		}
+	})
+
+	t.Run("Requested by the player", func(t *testing.T) {
+		// Act
+		_, _, err := randomService.SelectGenerator("player", "plugin")
+
+		// Assert
+		assert.EqualError(t, err, "generator plugin is unhealthy")
+	})
+
+	t.Run("No healthy generator left", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, randomService.DisableGenerator("crypto"))
+
+		// Act
+		_, _, err := randomService.SelectGenerator("player", "")
+
+		// Assert
+		assert.EqualError(t, err, "every random generator is quarantined or unhealthy")
+	})
+}
+
+func TestRandomService_ListGenerators(t *testing.T) {
+	// Arrange
+	scheme := latestScheme(t)
+	randomService := NewRandomService([]random.Generator{
+		random.NewCryptoGenerator(),
+		random.NewProovablyFairGenerator("server-seed", scheme),
+	})
+	randomService.AllowGeneratorChoice([]string{"provably_fair"})
+
+	// Act
+	infos := randomService.ListGenerators()
+
+	// Assert
+	assert.Equal(t, []model.GeneratorInfo{
+		{Name: "crypto", State: model.GeneratorEnabled},
+		{
+			Name:             "provably_fair",
+			Verifiable:       true,
+			Algorithm:        scheme.Name(),
+			AlgorithmVersion: scheme.Version(),
+			Selectable:       true,
+			State:            model.GeneratorEnabled,
+		},
+	}, infos)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"dice-game/pkg/domain/model"
+	"fmt"
+)
+
+// ReceiptSigner signs receipt payloads with the current key and lists every
+// key whose signatures should still be accepted.
+type ReceiptSigner interface {
+	Sign(message []byte) (keyID string, signature []byte, err error)
+	PublicKeys() []model.SigningKey
+}
+
+// ReceiptService issues signed receipts for played games. Without a signer
+// receipts are disabled and SignGameResult returns nil.
+type ReceiptService struct {
+	signer ReceiptSigner
+}
+
+func NewReceiptService(signer ReceiptSigner) *ReceiptService {
+	return &ReceiptService{signer: signer}
+}
+
+func (s *ReceiptService) SignGameResult(result *model.GameResult) (*model.GameReceipt, error) {
+	if s.signer == nil {
+		return nil, nil
+	}
+
+	payload, err := CanonicalGameResult(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize game result: %w", err)
+	}
+
+	keyID, signature, err := s.signer.Sign(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign game receipt: %w", err)
+	}
+
+	return &model.GameReceipt{
+		GameID:    result.GameID,
+		Payload:   string(payload),
+		KeyID:     keyID,
+		Signature: signature,
+	}, nil
+}
+
+func (s *ReceiptService) GetSigningKeys() []model.SigningKey {
+	if s.signer == nil {
+		return nil
+	}
+
+	return s.signer.PublicKeys()
+}
@@ -0,0 +1,8 @@
+package service
+
+import "dice-game/pkg/domain/model"
+
+type ReceiptServiceInterface interface {
+	SignGameResult(result *model.GameResult) (*model.GameReceipt, error)
+	GetSigningKeys() []model.SigningKey
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"dice-game/pkg/domain/model"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReceiptSigner struct {
+	mock.Mock
+}
+
+func (m *MockReceiptSigner) Sign(message []byte) (string, []byte, error) {
+	args := m.Called(message)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *MockReceiptSigner) PublicKeys() []model.SigningKey {
+	args := m.Called()
+	return args.Get(0).([]model.SigningKey)
+}
+
+func receiptTestResult() *model.GameResult {
+	return &model.GameResult{
+		GameID:           "game-1",
+		PlayerID:         "player-1",
+		PlayerDice:       5,
+		ServerDice:       2,
+		Winner:           model.WinnerPlayer,
+		PlayedAt:         time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		GeneratorUsed:    "provably_fair",
+		VerificationKey:  "v3:abc:1:def",
+		ClientSeed:       "client",
+		Nonce:            1,
+		AlgorithmVersion: 3,
+	}
+}
+
+func TestReceiptService_SignGameResult(t *testing.T) {
+	t.Run("Signs the canonical encoding", func(t *testing.T) {
+		// Arrange
+		signer := new(MockReceiptSigner)
+		result := receiptTestResult()
+		payload, err := CanonicalGameResult(result)
+		assert.NoError(t, err)
+
+		signer.On("Sign", payload).Return("key-1", []byte("signature"), nil)
+		receiptService := NewReceiptService(signer)
+
+		// Act
+		receipt, err := receiptService.SignGameResult(result)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, &model.GameReceipt{
+			GameID:    "game-1",
+			Payload:   string(payload),
+			KeyID:     "key-1",
+			Signature: []byte("signature"),
+		}, receipt)
+		signer.AssertExpectations(t)
+	})
+
+	t.Run("Signing disabled", func(t *testing.T) {
+		// Arrange
+		receiptService := NewReceiptService(nil)
+
+		// Act
+		receipt, err := receiptService.SignGameResult(receiptTestResult())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, receipt)
+		assert.Empty(t, receiptService.GetSigningKeys())
+	})
+
+	t.Run("Signer error", func(t *testing.T) {
+		// Arrange
+		signer := new(MockReceiptSigner)
+		signer.On("Sign", mock.Anything).Return("", nil, errors.New("no key"))
+		receiptService := NewReceiptService(signer)
+
+		// Act
+		receipt, err := receiptService.SignGameResult(receiptTestResult())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, receipt)
+		assert.Contains(t, err.Error(), "failed to sign game receipt")
+	})
+}
+
+func TestReceiptService_GetSigningKeys(t *testing.T) {
+	// Arrange
+	signer := new(MockReceiptSigner)
+	retiredAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	keys := []model.SigningKey{
+		{ID: "key-1", PublicKey: []byte("old"), RetiredAt: &retiredAt},
+		{ID: "key-2", PublicKey: []byte("new"), Current: true},
+	}
+	signer.On("PublicKeys").Return(keys)
+	receiptService := NewReceiptService(signer)
+
+	// Act
+	result := receiptService.GetSigningKeys()
+
+	// Assert
+	assert.Equal(t, keys, result)
+	signer.AssertExpectations(t)
+}
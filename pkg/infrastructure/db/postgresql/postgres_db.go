@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	_ "github.com/lib/pq"
@@ -26,7 +27,13 @@ type PostgresStore struct {
 	config *config.AppConfig
 	logger zerolog.Logger
 
-	gameRepo *PostgresGameRepository
+	gameRepo         *PostgresGameRepository
+	seedRepo         *PostgresSeedRepository
+	verificationRepo *PostgresVerificationRepository
+	seedChainRepo    *PostgresSeedChainRepository
+	merkleRootRepo   *PostgresMerkleRootRepository
+	entropyRepo      *PostgresEntropyRepository
+	matchRepo        *PostgresMatchRepository
 }
 
 func NewPostgresStore(cfg *config.AppConfig, logger *zerolog.Logger) *PostgresStore {
@@ -81,6 +88,36 @@ func (s *PostgresStore) Connect(ctx context.Context) error {
 		logger: s.logger.With().Str("repository", "game").Logger(),
 	}
 
+	s.seedRepo = &PostgresSeedRepository{
+		pool:   s.pool,
+		logger: s.logger.With().Str("repository", "seed").Logger(),
+	}
+
+	s.verificationRepo = &PostgresVerificationRepository{
+		pool:   s.pool,
+		logger: s.logger.With().Str("repository", "verification").Logger(),
+	}
+
+	s.seedChainRepo = &PostgresSeedChainRepository{
+		pool:   s.pool,
+		logger: s.logger.With().Str("repository", "seed_chain").Logger(),
+	}
+
+	s.merkleRootRepo = &PostgresMerkleRootRepository{
+		pool:   s.pool,
+		logger: s.logger.With().Str("repository", "merkle_root").Logger(),
+	}
+
+	s.entropyRepo = &PostgresEntropyRepository{
+		pool:   s.pool,
+		logger: s.logger.With().Str("repository", "entropy").Logger(),
+	}
+
+	s.matchRepo = &PostgresMatchRepository{
+		pool:   s.pool,
+		logger: s.logger.With().Str("repository", "match").Logger(),
+	}
+
 	s.logger.Info().Msg("Successfully connected to PostgreSQL database")
 	return nil
 }
@@ -198,6 +235,30 @@ func (s *PostgresStore) GetGameRepository() repository.GameRepository {
 	return s.gameRepo
 }
 
+func (s *PostgresStore) GetSeedRepository() repository.SeedRepository {
+	return s.seedRepo
+}
+
+func (s *PostgresStore) GetVerificationRepository() repository.VerificationRepository {
+	return s.verificationRepo
+}
+
+func (s *PostgresStore) GetSeedChainRepository() repository.SeedChainRepository {
+	return s.seedChainRepo
+}
+
+func (s *PostgresStore) GetMerkleRootRepository() repository.MerkleRootRepository {
+	return s.merkleRootRepo
+}
+
+func (s *PostgresStore) GetEntropyRepository() repository.EntropyRepository {
+	return s.entropyRepo
+}
+
+func (s *PostgresStore) GetMatchRepository() repository.MatchRepository {
+	return s.matchRepo
+}
+
 type PostgresGameRepository struct {
 	pool   *pgxpool.Pool
 	logger zerolog.Logger
@@ -210,127 +271,312 @@ func (r *PostgresGameRepository) SaveGameResult(ctx context.Context, result *mod
 		return errors.New("database connection is not initialized")
 	}
 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			r.logger.Error().Err(err).Msg("Failed to rollback game result")
+		}
+	}()
+
+	if err := insertGameResult(ctx, tx, result); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit game result")
+	}
+
+	return nil
+}
+
+// execer is implemented by both the pool and a transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+// insertGameResult saves a game and its rounds. Games without rounds are
+// saved with their dice as the only round. Run it in a transaction.
+func insertGameResult(ctx context.Context, db execer, result *model.GameResult) error {
 	query := `
 		INSERT INTO game_results (
-			game_id, player_id, player_dice, server_dice, 
-			winner, played_at, generator_used, verification_key
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			game_id, player_id, winner, played_at,
+			generator_used, verification_key, client_seed,
+			nonce, algorithm_version, beacon_round,
+			beacon_randomness, beacon_published_at,
+			selection_strategy, non_production, dice,
+			variant, variant_version, payout, draw_policy,
+			match_id, match_round
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 	`
 
-	_, err := r.pool.Exec(
+	// Games played on their own are in no match.
+	var matchID *string
+	var matchRound *int
+	if result.MatchID != "" {
+		matchID = &result.MatchID
+		matchRound = &result.MatchRound
+	}
+
+	// Only beacon games have a round publish time.
+	var beaconPublishedAt *time.Time
+	if !result.BeaconPublishedAt.IsZero() {
+		beaconPublishedAt = &result.BeaconPublishedAt
+	}
+
+	_, err := db.Exec(
 		ctx,
 		query,
 		result.GameID,
 		result.PlayerID,
-		result.PlayerDice,
-		result.ServerDice,
 		string(result.Winner),
 		result.PlayedAt,
 		result.GeneratorUsed,
 		result.VerificationKey,
+		result.ClientSeed,
+		result.Nonce,
+		result.AlgorithmVersion,
+		result.BeaconRound,
+		result.BeaconRandomness,
+		beaconPublishedAt,
+		result.SelectionStrategy,
+		result.NonProduction,
+		result.Dice,
+		result.Variant,
+		result.VariantVersion,
+		result.Payout,
+		result.DrawPolicy,
+		matchID,
+		matchRound,
 	)
 
 	if err != nil {
 		return errors.Wrap(err, "failed to save game result")
 	}
 
-	return nil
-}
-
-func (r *PostgresGameRepository) GetGameResult(ctx context.Context, gameID string) (*model.GameResult, error) {
-	if r.pool == nil {
-		return nil, errors.New("database connection is not initialized")
+	rounds := result.Rounds
+	if len(rounds) == 0 {
+		rounds = []model.GameRound{{
+			PlayerDice:  result.PlayerDice,
+			ServerDice:  result.ServerDice,
+			PlayerRolls: result.PlayerRolls,
+			ServerRolls: result.ServerRolls,
+		}}
 	}
 
-	query := `
-		SELECT 
-			game_id, player_id, player_dice, server_dice, 
-			winner, played_at, generator_used, verification_key
-		FROM game_results
-		WHERE game_id = $1
+	roundQuery := `
+		INSERT INTO game_rounds (
+			game_id, round, player_dice, server_dice,
+			player_rolls, server_rolls
+		) VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
+	for i, round := range rounds {
+		_, err := db.Exec(ctx, roundQuery,
+			result.GameID,
+			i+1,
+			round.PlayerDice,
+			round.ServerDice,
+			round.PlayerRolls,
+			round.ServerRolls,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "failed to save round %d of game result", i+1)
+		}
+	}
+
+	return nil
+}
+
+// gameResultColumns are the game_results columns scanGameResult reads.
+const gameResultColumns = `
+			game_id, player_id, winner, played_at,
+			generator_used, verification_key, client_seed,
+			nonce, algorithm_version, beacon_round,
+			beacon_randomness, beacon_published_at,
+			selection_strategy, non_production, dice,
+			variant, variant_version, payout, draw_policy,
+			COALESCE(match_id, ''), COALESCE(match_round, 0)`
+
+// scanGameResult reads a row of gameResultColumns. The dice are filled in
+// from the game's rounds by loadGameRounds.
+func scanGameResult(row pgx.Row) (*model.GameResult, error) {
 	var result model.GameResult
 	var winner string
-	var playedAt time.Time
+	var beaconPublishedAt *time.Time
 
-	err := r.pool.QueryRow(ctx, query, gameID).Scan(
+	err := row.Scan(
 		&result.GameID,
 		&result.PlayerID,
-		&result.PlayerDice,
-		&result.ServerDice,
 		&winner,
-		&playedAt,
+		&result.PlayedAt,
 		&result.GeneratorUsed,
 		&result.VerificationKey,
+		&result.ClientSeed,
+		&result.Nonce,
+		&result.AlgorithmVersion,
+		&result.BeaconRound,
+		&result.BeaconRandomness,
+		&beaconPublishedAt,
+		&result.SelectionStrategy,
+		&result.NonProduction,
+		&result.Dice,
+		&result.Variant,
+		&result.VariantVersion,
+		&result.Payout,
+		&result.DrawPolicy,
+		&result.MatchID,
+		&result.MatchRound,
 	)
-
 	if err != nil {
-		if err.Error() == "no rows in result set" {
-			return nil, errors.New("game not found")
-		}
-		return nil, errors.Wrap(err, "failed to get game result")
+		return nil, err
 	}
 
 	result.Winner = model.Winner(winner)
-	result.PlayedAt = playedAt
+	if beaconPublishedAt != nil {
+		result.BeaconPublishedAt = *beaconPublishedAt
+	}
 
 	return &result, nil
 }
 
-func (r *PostgresGameRepository) GetGameResultsByPlayer(ctx context.Context, playerID string, limit, offset int) ([]*model.GameResult, error) {
-	if r.pool == nil {
-		return nil, errors.New("database connection is not initialized")
+// queryGameResults runs a query selecting gameResultColumns and returns the
+// games with their rounds.
+func queryGameResults(ctx context.Context, pool *pgxpool.Pool, query string, args ...interface{}) ([]*model.GameResult, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query game results")
+	}
+	defer rows.Close()
+
+	var results []*model.GameResult
+
+	for rows.Next() {
+		result, err := scanGameResult(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan game result")
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error iterating game results")
+	}
+
+	if err := loadGameRounds(ctx, pool, results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// loadGameRounds reads the rounds of results and sets their dice to the
+// last round, which decided the game.
+func loadGameRounds(ctx context.Context, pool *pgxpool.Pool, results []*model.GameResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	gameIDs := make([]string, len(results))
+	byID := make(map[string]*model.GameResult, len(results))
+	for i, result := range results {
+		gameIDs[i] = result.GameID
+		byID[result.GameID] = result
 	}
 
 	query := `
-		SELECT 
-			game_id, player_id, player_dice, server_dice, 
-			winner, played_at, generator_used, verification_key
-		FROM game_results
-		WHERE player_id = $1
-		ORDER BY played_at DESC
-		LIMIT $2 OFFSET $3
+		SELECT game_id, player_dice, server_dice, player_rolls, server_rolls
+		FROM game_rounds
+		WHERE game_id = ANY($1)
+		ORDER BY game_id, round
 	`
 
-	rows, err := r.pool.Query(ctx, query, playerID, limit, offset)
+	rows, err := pool.Query(ctx, query, gameIDs)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to query game results")
+		return errors.Wrap(err, "failed to query game rounds")
 	}
 	defer rows.Close()
 
-	var results []*model.GameResult
-
 	for rows.Next() {
-		var result model.GameResult
-		var winner string
-		var playedAt time.Time
+		var gameID string
+		var round model.GameRound
 
 		err := rows.Scan(
-			&result.GameID,
-			&result.PlayerID,
-			&result.PlayerDice,
-			&result.ServerDice,
-			&winner,
-			&playedAt,
-			&result.GeneratorUsed,
-			&result.VerificationKey,
+			&gameID,
+			&round.PlayerDice,
+			&round.ServerDice,
+			&round.PlayerRolls,
+			&round.ServerRolls,
 		)
-
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to scan game result")
+			return errors.Wrap(err, "failed to scan game round")
 		}
 
-		result.Winner = model.Winner(winner)
-		result.PlayedAt = playedAt
-		results = append(results, &result)
+		result := byID[gameID]
+		result.Rounds = append(result.Rounds, round)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, errors.Wrap(err, "error iterating game results")
+		return errors.Wrap(err, "error iterating game rounds")
 	}
 
-	return results, nil
+	for _, result := range results {
+		if len(result.Rounds) == 0 {
+			return errors.Errorf("game %s has no rounds", result.GameID)
+		}
+
+		last := result.Rounds[len(result.Rounds)-1]
+		result.PlayerDice = last.PlayerDice
+		result.ServerDice = last.ServerDice
+		result.PlayerRolls = last.PlayerRolls
+		result.ServerRolls = last.ServerRolls
+	}
+
+	return nil
+}
+
+func (r *PostgresGameRepository) GetGameResult(ctx context.Context, gameID string) (*model.GameResult, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT ` + gameResultColumns + `
+		FROM game_results
+		WHERE game_id = $1
+	`
+
+	result, err := scanGameResult(r.pool.QueryRow(ctx, query, gameID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrGameNotFound
+		}
+		return nil, errors.Wrap(err, "failed to get game result")
+	}
+
+	if err := loadGameRounds(ctx, r.pool, []*model.GameResult{result}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *PostgresGameRepository) GetGameResultsByPlayer(ctx context.Context, playerID string, limit, offset int) ([]*model.GameResult, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT ` + gameResultColumns + `
+		FROM game_results
+		WHERE player_id = $1
+		ORDER BY played_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	return queryGameResults(ctx, r.pool, query, playerID, limit, offset)
 }
 
 func (r *PostgresGameRepository) GetTotalGames(ctx context.Context) (int, error) {
@@ -348,3 +594,42 @@ func (r *PostgresGameRepository) GetTotalGames(ctx context.Context) (int, error)
 
 	return count, nil
 }
+
+func (r *PostgresGameRepository) GetGameResultsByDay(ctx context.Context, day time.Time) ([]*model.GameResult, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT ` + gameResultColumns + `
+		FROM game_results
+		WHERE played_at >= $1 AND played_at < $2
+		ORDER BY played_at, game_id
+	`
+
+	start := utcDate(day)
+
+	return queryGameResults(ctx, r.pool, query, start, start.Add(24*time.Hour))
+}
+
+func (r *PostgresGameRepository) NextNonce(ctx context.Context, playerID string) (int64, error) {
+	if r.pool == nil {
+		return 0, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		INSERT INTO player_nonces (player_id, nonce)
+		VALUES ($1, 1)
+		ON CONFLICT (player_id) DO UPDATE
+		SET nonce = player_nonces.nonce + 1, updated_at = CURRENT_TIMESTAMP
+		RETURNING nonce
+	`
+
+	var nonce int64
+	err := r.pool.QueryRow(ctx, query, playerID).Scan(&nonce)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get next player nonce")
+	}
+
+	return nonce, nil
+}
@@ -0,0 +1,101 @@
+package postgresql
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type PostgresEntropyRepository struct {
+	pool   *pgxpool.Pool
+	logger zerolog.Logger
+}
+
+var _ repository.EntropyRepository = (*PostgresEntropyRepository)(nil)
+
+func (r *PostgresEntropyRepository) SaveGameResultWithEntropy(ctx context.Context, result *model.GameResult, entropy *model.GameEntropy) error {
+	if r.pool == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			r.logger.Error().Err(err).Msg("Failed to rollback game with entropy")
+		}
+	}()
+
+	if err := insertGameResult(ctx, tx, result); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO game_entropy (
+			game_id, generator, mapping, value_count,
+			min_value, max_value, data, key_id, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		entropy.GameID,
+		entropy.Generator,
+		entropy.Mapping,
+		entropy.Count,
+		entropy.Min,
+		entropy.Max,
+		entropy.Data,
+		entropy.KeyID,
+		entropy.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save game entropy")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit game with entropy")
+	}
+
+	return nil
+}
+
+func (r *PostgresEntropyRepository) GetGameEntropy(ctx context.Context, gameID string) (*model.GameEntropy, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT game_id, generator, mapping, value_count,
+			min_value, max_value, data, key_id, created_at
+		FROM game_entropy
+		WHERE game_id = $1
+	`
+
+	var entropy model.GameEntropy
+	err := r.pool.QueryRow(ctx, query, gameID).Scan(
+		&entropy.GameID,
+		&entropy.Generator,
+		&entropy.Mapping,
+		&entropy.Count,
+		&entropy.Min,
+		&entropy.Max,
+		&entropy.Data,
+		&entropy.KeyID,
+		&entropy.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get game entropy")
+	}
+
+	return &entropy, nil
+}
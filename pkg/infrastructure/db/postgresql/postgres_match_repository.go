@@ -0,0 +1,163 @@
+package postgresql
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type PostgresMatchRepository struct {
+	pool   *pgxpool.Pool
+	logger zerolog.Logger
+}
+
+var _ repository.MatchRepository = (*PostgresMatchRepository)(nil)
+
+func (r *PostgresMatchRepository) SaveMatch(ctx context.Context, match *model.Match) error {
+	if r.pool == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	query := `
+		INSERT INTO matches (
+			match_id, player_id, best_of, variant,
+			dice, generator, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		match.MatchID,
+		match.PlayerID,
+		match.BestOf,
+		match.Variant,
+		match.Dice,
+		match.Generator,
+		match.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save match")
+	}
+
+	return nil
+}
+
+func (r *PostgresMatchRepository) GetMatch(ctx context.Context, matchID string) (*model.Match, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT match_id, player_id, best_of, variant, dice, generator,
+			player_wins, server_wins, draws, winner, created_at, finished_at
+		FROM matches
+		WHERE match_id = $1
+	`
+
+	var match model.Match
+	var winner string
+
+	err := r.pool.QueryRow(ctx, query, matchID).Scan(
+		&match.MatchID,
+		&match.PlayerID,
+		&match.BestOf,
+		&match.Variant,
+		&match.Dice,
+		&match.Generator,
+		&match.PlayerWins,
+		&match.ServerWins,
+		&match.Draws,
+		&winner,
+		&match.CreatedAt,
+		&match.FinishedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get match")
+	}
+
+	match.Winner = model.Winner(winner)
+
+	gamesQuery := `
+		SELECT ` + gameResultColumns + `
+		FROM game_results
+		WHERE match_id = $1
+		ORDER BY match_round
+	`
+
+	match.Games, err = queryGameResults(ctx, r.pool, gamesQuery, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &match, nil
+}
+
+func (r *PostgresMatchRepository) UpdateMatch(ctx context.Context, match *model.Match) error {
+	if r.pool == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	query := `
+		UPDATE matches
+		SET player_wins = $2, server_wins = $3, draws = $4,
+			winner = $5, finished_at = $6
+		WHERE match_id = $1
+	`
+
+	tag, err := r.pool.Exec(ctx, query,
+		match.MatchID,
+		match.PlayerWins,
+		match.ServerWins,
+		match.Draws,
+		string(match.Winner),
+		match.FinishedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to update match")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Errorf("match %s not found", match.MatchID)
+	}
+
+	return nil
+}
+
+func (r *PostgresMatchRepository) GetMatchStats(ctx context.Context, playerID string) (*model.MatchStats, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE winner <> ''),
+			COUNT(*) FILTER (WHERE winner = 'PLAYER'),
+			COUNT(*) FILTER (WHERE winner = 'SERVER'),
+			COUNT(*) FILTER (WHERE winner = 'DRAW'),
+			COUNT(*) FILTER (WHERE winner = ''),
+			COALESCE(SUM(player_wins + server_wins + draws), 0)
+		FROM matches
+		WHERE player_id = $1
+	`
+
+	stats := model.MatchStats{PlayerID: playerID}
+	err := r.pool.QueryRow(ctx, query, playerID).Scan(
+		&stats.Played,
+		&stats.Won,
+		&stats.Lost,
+		&stats.Drawn,
+		&stats.InProgress,
+		&stats.Games,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get match stats")
+	}
+
+	return &stats, nil
+}
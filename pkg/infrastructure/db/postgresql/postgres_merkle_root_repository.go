@@ -0,0 +1,108 @@
+package postgresql
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type PostgresMerkleRootRepository struct {
+	pool   *pgxpool.Pool
+	logger zerolog.Logger
+}
+
+var _ repository.MerkleRootRepository = (*PostgresMerkleRootRepository)(nil)
+
+func (r *PostgresMerkleRootRepository) SaveDailyRoot(ctx context.Context, root *model.DailyMerkleRoot) error {
+	if r.pool == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	query := `
+		INSERT INTO game_merkle_roots (date, root_hash, leaf_count, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (date) DO NOTHING
+	`
+
+	tag, err := r.pool.Exec(ctx, query, utcDate(root.Date), root.RootHash, root.LeafCount, root.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to save merkle root")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Errorf("merkle root for %s already exists", root.Date.UTC().Format("2006-01-02"))
+	}
+
+	return nil
+}
+
+func (r *PostgresMerkleRootRepository) GetDailyRoot(ctx context.Context, day time.Time) (*model.DailyMerkleRoot, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT date, root_hash, leaf_count, created_at
+		FROM game_merkle_roots
+		WHERE date = $1
+	`
+
+	var root model.DailyMerkleRoot
+	err := r.pool.QueryRow(ctx, query, utcDate(day)).Scan(&root.Date, &root.RootHash, &root.LeafCount, &root.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get merkle root")
+	}
+
+	return &root, nil
+}
+
+func (r *PostgresMerkleRootRepository) GetUnsealedDays(ctx context.Context, before time.Time) ([]time.Time, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT DISTINCT (g.played_at AT TIME ZONE 'UTC')::date AS day
+		FROM game_results g
+		WHERE g.played_at < $1
+			AND NOT EXISTS (
+				SELECT 1 FROM game_merkle_roots m
+				WHERE m.date = (g.played_at AT TIME ZONE 'UTC')::date
+			)
+		ORDER BY day
+	`
+
+	rows, err := r.pool.Query(ctx, query, utcDate(before))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query unsealed days")
+	}
+	defer rows.Close()
+
+	var days []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, errors.Wrap(err, "failed to scan unsealed day")
+		}
+		days = append(days, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error iterating unsealed days")
+	}
+
+	return days, nil
+}
+
+// utcDate truncates t to the start of its UTC day.
+func utcDate(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,189 @@
+package postgresql
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type PostgresSeedChainRepository struct {
+	pool   *pgxpool.Pool
+	logger zerolog.Logger
+}
+
+var _ repository.SeedChainRepository = (*PostgresSeedChainRepository)(nil)
+
+func (r *PostgresSeedChainRepository) SaveSeedChain(ctx context.Context, chain *model.SeedChain, links []*model.SeedChainLink) error {
+	if r.pool == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			r.logger.Error().Err(err).Msg("Failed to rollback seed chain creation")
+		}
+	}()
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO seed_chains (terminal_hash, length, created_at) VALUES ($1, $2, $3) RETURNING id`,
+		chain.TerminalHash, chain.Length, chain.CreatedAt,
+	).Scan(&chain.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to save seed chain")
+	}
+
+	rows := make([][]interface{}, len(links))
+	for i, link := range links {
+		link.ChainID = chain.ID
+		rows[i] = []interface{}{link.ChainID, link.Position, link.Seed, link.Hash}
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"seed_chain_links"},
+		[]string{"chain_id", "position", "seed", "seed_hash"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save seed chain links")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit seed chain")
+	}
+
+	return nil
+}
+
+func (r *PostgresSeedChainRepository) GetSeedChain(ctx context.Context, chainID int64) (*model.SeedChain, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT c.id, c.terminal_hash, c.length, c.created_at,
+			(SELECT COUNT(*) FROM seed_chain_links l WHERE l.chain_id = c.id AND l.consumed_at IS NOT NULL)
+		FROM seed_chains c
+		WHERE c.id = $1
+	`
+
+	chain, err := scanSeedChain(r.pool.QueryRow(ctx, query, chainID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("seed chain not found")
+		}
+		return nil, errors.Wrap(err, "failed to get seed chain")
+	}
+
+	return chain, nil
+}
+
+func (r *PostgresSeedChainRepository) GetActiveSeedChain(ctx context.Context) (*model.SeedChain, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT c.id, c.terminal_hash, c.length, c.created_at,
+			(SELECT COUNT(*) FROM seed_chain_links l WHERE l.chain_id = c.id AND l.consumed_at IS NOT NULL)
+		FROM seed_chains c
+		WHERE EXISTS (
+			SELECT 1 FROM seed_chain_links l WHERE l.chain_id = c.id AND l.consumed_at IS NULL
+		)
+		ORDER BY c.id
+		LIMIT 1
+	`
+
+	chain, err := scanSeedChain(r.pool.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get active seed chain")
+	}
+
+	return chain, nil
+}
+
+func (r *PostgresSeedChainRepository) ConsumeNextLink(ctx context.Context, consumedAt time.Time) (*model.SeedChainLink, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		UPDATE seed_chain_links
+		SET consumed_at = $1
+		WHERE (chain_id, position) = (
+			SELECT chain_id, position
+			FROM seed_chain_links
+			WHERE consumed_at IS NULL
+			ORDER BY chain_id, position
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING chain_id, position, seed, seed_hash, consumed_at
+	`
+
+	link, err := scanSeedChainLink(r.pool.QueryRow(ctx, query, consumedAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to consume seed chain link")
+	}
+
+	return link, nil
+}
+
+func (r *PostgresSeedChainRepository) GetLinkByHash(ctx context.Context, seedHash string) (*model.SeedChainLink, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT chain_id, position, seed, seed_hash, consumed_at
+		FROM seed_chain_links
+		WHERE seed_hash = $1
+	`
+
+	link, err := scanSeedChainLink(r.pool.QueryRow(ctx, query, seedHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("seed chain link not found")
+		}
+		return nil, errors.Wrap(err, "failed to get seed chain link")
+	}
+
+	return link, nil
+}
+
+func scanSeedChain(row pgx.Row) (*model.SeedChain, error) {
+	var chain model.SeedChain
+
+	if err := row.Scan(&chain.ID, &chain.TerminalHash, &chain.Length, &chain.CreatedAt, &chain.Consumed); err != nil {
+		return nil, err
+	}
+
+	return &chain, nil
+}
+
+func scanSeedChainLink(row pgx.Row) (*model.SeedChainLink, error) {
+	var link model.SeedChainLink
+	var consumedAt *time.Time
+
+	if err := row.Scan(&link.ChainID, &link.Position, &link.Seed, &link.Hash, &consumedAt); err != nil {
+		return nil, err
+	}
+
+	link.ConsumedAt = consumedAt
+	return &link, nil
+}
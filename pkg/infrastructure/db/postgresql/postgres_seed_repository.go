@@ -0,0 +1,137 @@
+package postgresql
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type PostgresSeedRepository struct {
+	pool   *pgxpool.Pool
+	logger zerolog.Logger
+}
+
+var _ repository.SeedRepository = (*PostgresSeedRepository)(nil)
+
+func (r *PostgresSeedRepository) SaveServerSeed(ctx context.Context, seed *model.ServerSeed) error {
+	if r.pool == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	query := `
+		INSERT INTO server_seeds (seed_hash, seed, created_at, revealed_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.pool.Exec(ctx, query, seed.Hash, seed.Seed, seed.CreatedAt, seed.RevealedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to save server seed")
+	}
+
+	return nil
+}
+
+func (r *PostgresSeedRepository) GetServerSeed(ctx context.Context, seedHash string) (*model.ServerSeed, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT seed_hash, seed, created_at, revealed_at
+		FROM server_seeds
+		WHERE seed_hash = $1
+	`
+
+	seed, err := scanServerSeed(r.pool.QueryRow(ctx, query, seedHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("server seed not found")
+		}
+		return nil, errors.Wrap(err, "failed to get server seed")
+	}
+
+	return seed, nil
+}
+
+func (r *PostgresSeedRepository) GetActiveServerSeed(ctx context.Context) (*model.ServerSeed, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT seed_hash, seed, created_at, revealed_at
+		FROM server_seeds
+		WHERE revealed_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	seed, err := scanServerSeed(r.pool.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get active server seed")
+	}
+
+	return seed, nil
+}
+
+func (r *PostgresSeedRepository) RotateServerSeed(ctx context.Context, revealHash string, revealedAt time.Time, next *model.ServerSeed) error {
+	if r.pool == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			r.logger.Error().Err(err).Msg("Failed to rollback seed rotation")
+		}
+	}()
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE server_seeds SET revealed_at = $2 WHERE seed_hash = $1 AND revealed_at IS NULL`,
+		revealHash, revealedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to reveal server seed")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("active server seed not found")
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO server_seeds (seed_hash, seed, created_at) VALUES ($1, $2, $3)`,
+		next.Hash, next.Seed, next.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save next server seed")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit seed rotation")
+	}
+
+	return nil
+}
+
+func scanServerSeed(row pgx.Row) (*model.ServerSeed, error) {
+	var seed model.ServerSeed
+	var revealedAt *time.Time
+
+	if err := row.Scan(&seed.Hash, &seed.Seed, &seed.CreatedAt, &revealedAt); err != nil {
+		return nil, err
+	}
+
+	seed.RevealedAt = revealedAt
+	return &seed, nil
+}
@@ -0,0 +1,129 @@
+package postgresql
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/repository"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type PostgresVerificationRepository struct {
+	pool   *pgxpool.Pool
+	logger zerolog.Logger
+}
+
+var _ repository.VerificationRepository = (*PostgresVerificationRepository)(nil)
+
+func (r *PostgresVerificationRepository) SaveVerificationRecord(ctx context.Context, record *model.VerificationRecord) error {
+	if r.pool == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	query := `
+		INSERT INTO verification_records (
+			game_id, verification_data, is_valid, verified_at, requested_by, error
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		record.GameID,
+		record.VerificationData,
+		record.IsValid,
+		record.VerifiedAt,
+		record.RequestedBy,
+		record.Error,
+	).Scan(&record.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to save verification record")
+	}
+
+	return nil
+}
+
+func (r *PostgresVerificationRepository) GetVerificationRecordsByGame(ctx context.Context, gameID string, limit, offset int) ([]*model.VerificationRecord, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT
+			v.id, v.game_id, g.player_id, v.requested_by,
+			v.verification_data, v.is_valid, v.error, v.verified_at
+		FROM verification_records v
+		JOIN game_results g ON g.game_id = v.game_id
+		WHERE v.game_id = $1
+		ORDER BY v.verified_at DESC, v.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, gameID, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query verification records")
+	}
+	defer rows.Close()
+
+	return scanVerificationRecords(rows)
+}
+
+func (r *PostgresVerificationRepository) GetVerificationRecordsByPlayer(ctx context.Context, playerID string, limit, offset int) ([]*model.VerificationRecord, error) {
+	if r.pool == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := `
+		SELECT
+			v.id, v.game_id, g.player_id, v.requested_by,
+			v.verification_data, v.is_valid, v.error, v.verified_at
+		FROM verification_records v
+		JOIN game_results g ON g.game_id = v.game_id
+		WHERE g.player_id = $1
+		ORDER BY v.verified_at DESC, v.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, playerID, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query verification records")
+	}
+	defer rows.Close()
+
+	return scanVerificationRecords(rows)
+}
+
+func scanVerificationRecords(rows pgx.Rows) ([]*model.VerificationRecord, error) {
+	var records []*model.VerificationRecord
+
+	for rows.Next() {
+		var record model.VerificationRecord
+
+		err := rows.Scan(
+			&record.ID,
+			&record.GameID,
+			&record.PlayerID,
+			&record.RequestedBy,
+			&record.VerificationData,
+			&record.IsValid,
+			&record.Error,
+			&record.VerifiedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan verification record")
+		}
+
+		records = append(records, &record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error iterating verification records")
+	}
+
+	return records, nil
+}
@@ -2,15 +2,30 @@ package grpc
 
 import (
 	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/rules"
+	"dice-game/pkg/domain/service"
 	"dice-game/pkg/usecase"
 	pb "dice-game/proto/gen"
+	"encoding/base64"
+	"errors"
 	"github.com/rs/zerolog"
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
+const maxClientSeedLength = 64
+
+const (
+	// PlayTimeout bounds a single game, in Play and PlayRound.
+	PlayTimeout = 5 * time.Second
+	// MatchTimeout bounds PlayMatch, which plays up to seven games.
+	MatchTimeout = 30 * time.Second
+)
+
 type DiceGameService struct {
 	pb.UnimplementedDiceGameServiceServer
 	gameUseCase usecase.GameUseCaseInterface
@@ -30,23 +45,34 @@ func (s *DiceGameService) Play(ctx context.Context, req *pb.PlayRequest) (*pb.Pl
 	if ctx.Err() != nil {
 		return nil, status.Error(codes.DeadlineExceeded, "client context already done")
 	}
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	if len(req.GetClientSeed()) > maxClientSeedLength {
+		return nil, status.Errorf(codes.InvalidArgument, "client seed must be at most %d characters", maxClientSeedLength)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, PlayTimeout)
 	defer cancel()
 
-	result, err := s.gameUseCase.PlayGame(ctx, req.GetPlayerId())
+	result, err := s.gameUseCase.PlayGame(ctx, req.GetPlayerId(), req.GetClientSeed(), req.GetGenerator(), req.GetDice(), req.GetVariant())
+	if errors.Is(err, service.ErrGeneratorNotAllowed) {
+		s.logger.Warn().Str("generator", req.GetGenerator()).Msg("Requested generator may not be chosen by players")
+		return nil, status.Errorf(codes.InvalidArgument, "generator %q may not be requested", req.GetGenerator())
+	}
+	if errors.Is(err, rules.ErrUnknownVariant) {
+		s.logger.Warn().Str("variant", req.GetVariant()).Msg("Requested game variant does not exist")
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if errors.Is(err, service.ErrDiceNotOffered) {
+		s.logger.Warn().Str("dice", req.GetDice()).Msg("Requested dice expression is not offered")
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to process play request")
 		return nil, status.Errorf(codes.Internal, "failed to process play request: %v", err)
 	}
 
-	response := &pb.PlayResponse{
-		GameId:          result.GameID,
-		PlayerDice:      int32(result.PlayerDice),
-		ServerDice:      int32(result.ServerDice),
-		Winner:          string(result.Winner),
-		PlayedAt:        result.PlayedAt.Format(time.RFC3339),
-		GeneratorUsed:   result.GeneratorUsed,
-		VerificationKey: result.VerificationKey,
+	response, err := s.playResponse(result)
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.Info().
@@ -54,21 +80,74 @@ func (s *DiceGameService) Play(ctx context.Context, req *pb.PlayRequest) (*pb.Pl
 		Int("server_dice", result.ServerDice).
 		Str("winner", string(result.Winner)).
 		Str("game_id", result.GameID).
+		Str("generator", result.GeneratorUsed).
+		Str("selection_strategy", result.SelectionStrategy).
+		Str("dice", result.Dice).
+		Str("variant", result.Variant).
+		Str("draw_policy", result.DrawPolicy).
+		Int("rounds", len(result.Rounds)).
 		Msg("Game completed successfully")
 
 	return response, nil
 }
 
+// playResponse converts a game to its response, signing its receipt when
+// receipts are enabled.
+func (s *DiceGameService) playResponse(result *model.GameResult) (*pb.PlayResponse, error) {
+	response := &pb.PlayResponse{
+		GameId:            result.GameID,
+		PlayerDice:        int32(result.PlayerDice),
+		ServerDice:        int32(result.ServerDice),
+		Winner:            string(result.Winner),
+		PlayedAt:          result.PlayedAt.Format(time.RFC3339),
+		GeneratorUsed:     result.GeneratorUsed,
+		VerificationKey:   result.VerificationKey,
+		ClientSeed:        result.ClientSeed,
+		Nonce:             result.Nonce,
+		AlgorithmVersion:  int32(result.AlgorithmVersion),
+		BeaconRound:       result.BeaconRound,
+		SelectionStrategy: result.SelectionStrategy,
+		NonProduction:     result.NonProduction,
+		Dice:              result.Dice,
+		PlayerRolls:       toInt32s(result.PlayerRolls),
+		ServerRolls:       toInt32s(result.ServerRolls),
+		Variant:           result.Variant,
+		VariantVersion:    int32(result.VariantVersion),
+		Payout:            result.Payout,
+		DrawPolicy:        result.DrawPolicy,
+		Rounds:            toGameRounds(result.Rounds),
+	}
+
+	receipt, err := s.gameUseCase.SignGameResult(result)
+	if err != nil {
+		s.logger.Error().Err(err).Str("game_id", result.GameID).Msg("Failed to sign game receipt")
+		return nil, status.Errorf(codes.Internal, "failed to sign game receipt: %v", err)
+	}
+	if receipt != nil {
+		response.Receipt = receipt.Payload
+		response.Signature = base64.StdEncoding.EncodeToString(receipt.Signature)
+		response.SigningKeyId = receipt.KeyID
+	}
+
+	return response, nil
+}
+
 func (s *DiceGameService) Verify(ctx context.Context, req *pb.VerifyRequest) (*pb.VerifyResponse, error) {
 	s.logger.Info().Str("game_id", req.GetGameId()).Msg("Received Verify request")
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	isValid, err := s.gameUseCase.VerifyGame(ctx, req.GetGameId(), req.GetVerificationData())
+	requestedBy := req.GetRequestedBy()
+	if requestedBy == "" {
+		if p, ok := peer.FromContext(ctx); ok {
+			requestedBy = p.Addr.String()
+		}
+	}
+
+	isValid, err := s.gameUseCase.VerifyGame(ctx, req.GetGameId(), req.GetVerificationData(), requestedBy)
 	if err != nil {
-		s.logger.Error().Err(err).Str("game_id", req.GetGameId()).Msg("Failed to verify game")
-		return nil, status.Errorf(codes.Internal, "failed to verify game: %v", err)
+		return nil, s.verifyError(err, req.GetGameId())
 	}
 
 	response := &pb.VerifyResponse{
@@ -83,3 +162,433 @@ func (s *DiceGameService) Verify(ctx context.Context, req *pb.VerifyRequest) (*p
 
 	return response, nil
 }
+
+func (s *DiceGameService) ListVerifications(ctx context.Context, req *pb.ListVerificationsRequest) (*pb.ListVerificationsResponse, error) {
+	s.logger.Info().
+		Str("game_id", req.GetGameId()).
+		Str("player_id", req.GetPlayerId()).
+		Msg("Received ListVerifications request")
+
+	if (req.GetGameId() == "") == (req.GetPlayerId() == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of game_id or player_id is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	records, err := s.gameUseCase.ListVerifications(ctx, req.GetGameId(), req.GetPlayerId(), int(req.GetLimit()), int(req.GetOffset()))
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to list verifications")
+		return nil, status.Errorf(codes.Internal, "failed to list verifications: %v", err)
+	}
+
+	response := &pb.ListVerificationsResponse{
+		Verifications: make([]*pb.VerificationRecord, 0, len(records)),
+	}
+
+	for _, record := range records {
+		response.Verifications = append(response.Verifications, &pb.VerificationRecord{
+			Id:               record.ID,
+			GameId:           record.GameID,
+			PlayerId:         record.PlayerID,
+			RequestedBy:      record.RequestedBy,
+			VerificationData: record.VerificationData,
+			IsValid:          record.IsValid,
+			Error:            record.Error,
+			VerifiedAt:       record.VerifiedAt.Format(time.RFC3339),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *DiceGameService) GetSeedChain(ctx context.Context, req *pb.GetSeedChainRequest) (*pb.GetSeedChainResponse, error) {
+	s.logger.Info().Str("game_id", req.GetGameId()).Msg("Received GetSeedChain request")
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	chain, link, err := s.gameUseCase.GetSeedChain(ctx, req.GetGameId())
+	if err != nil {
+		s.logger.Error().Err(err).Str("game_id", req.GetGameId()).Msg("Failed to get seed chain")
+		return nil, status.Errorf(codes.Internal, "failed to get seed chain: %v", err)
+	}
+
+	response := &pb.GetSeedChainResponse{
+		ChainId:      chain.ID,
+		TerminalHash: chain.TerminalHash,
+		Length:       int32(chain.Length),
+		Consumed:     int32(chain.Consumed),
+		CreatedAt:    chain.CreatedAt.Format(time.RFC3339),
+	}
+
+	if link != nil {
+		response.Position = int32(link.Position)
+		response.ServerSeed = link.Seed
+		response.ServerSeedHash = link.Hash
+	}
+
+	return response, nil
+}
+
+func (s *DiceGameService) GetInclusionProof(ctx context.Context, req *pb.GetInclusionProofRequest) (*pb.GetInclusionProofResponse, error) {
+	s.logger.Info().Str("game_id", req.GetGameId()).Msg("Received GetInclusionProof request")
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	proof, err := s.gameUseCase.GetInclusionProof(ctx, req.GetGameId())
+	if err != nil {
+		s.logger.Error().Err(err).Str("game_id", req.GetGameId()).Msg("Failed to get inclusion proof")
+		return nil, status.Errorf(codes.Internal, "failed to get inclusion proof: %v", err)
+	}
+
+	response := &pb.GetInclusionProofResponse{
+		GameId:    proof.GameID,
+		Date:      proof.Date.Format("2006-01-02"),
+		LeafIndex: int32(proof.LeafIndex),
+		LeafCount: int32(proof.LeafCount),
+		LeafData:  proof.LeafData,
+		LeafHash:  proof.LeafHash,
+		RootHash:  proof.RootHash,
+		Steps:     make([]*pb.ProofStep, 0, len(proof.Steps)),
+	}
+
+	for _, step := range proof.Steps {
+		response.Steps = append(response.Steps, &pb.ProofStep{Hash: step.Hash, Left: step.Left})
+	}
+
+	return response, nil
+}
+
+func (s *DiceGameService) GetSigningKeys(_ context.Context, _ *pb.GetSigningKeysRequest) (*pb.GetSigningKeysResponse, error) {
+	s.logger.Info().Msg("Received GetSigningKeys request")
+
+	keys := s.gameUseCase.GetSigningKeys()
+	response := &pb.GetSigningKeysResponse{
+		Keys: make([]*pb.SigningKey, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		signingKey := &pb.SigningKey{
+			KeyId:     key.ID,
+			PublicKey: base64.StdEncoding.EncodeToString(key.PublicKey),
+			Algorithm: "ed25519",
+			Status:    "retired",
+		}
+		if key.Current {
+			signingKey.Status = "current"
+		}
+		if key.RetiredAt != nil {
+			signingKey.RetiredAt = key.RetiredAt.Format(time.RFC3339)
+		}
+		response.Keys = append(response.Keys, signingKey)
+	}
+
+	return response, nil
+}
+
+func (s *DiceGameService) GetGeneratorHealth(_ context.Context, _ *pb.GetGeneratorHealthRequest) (*pb.GetGeneratorHealthResponse, error) {
+	s.logger.Info().Msg("Received GetGeneratorHealth request")
+
+	generators := s.gameUseCase.GetGeneratorHealth()
+	response := &pb.GetGeneratorHealthResponse{
+		Enabled:    generators != nil,
+		Generators: make([]*pb.GeneratorHealth, 0, len(generators)),
+	}
+
+	for _, health := range generators {
+		generator := &pb.GeneratorHealth{
+			Generator:         health.Generator,
+			Status:            string(health.Status),
+			Samples:           int32(health.Samples),
+			WindowSize:        int32(health.WindowSize),
+			ChiSquare:         health.ChiSquare,
+			ChiSquareCritical: health.ChiSquareCritical,
+			RunsZ:             health.RunsZ,
+			RunsCritical:      health.RunsCritical,
+			Repetition:        int32(health.Repetition),
+			RepetitionCutoff:  int32(health.RepetitionCutoff),
+			Reason:            health.Reason,
+			Min:               int32(health.Min),
+			Max:               int32(health.Max),
+		}
+		if health.QuarantinedAt != nil {
+			generator.QuarantinedAt = health.QuarantinedAt.Format(time.RFC3339)
+		}
+		response.Generators = append(response.Generators, generator)
+	}
+
+	return response, nil
+}
+
+func (s *DiceGameService) ListGenerators(_ context.Context, _ *pb.ListGeneratorsRequest) (*pb.ListGeneratorsResponse, error) {
+	s.logger.Info().Msg("Received ListGenerators request")
+
+	generators := s.gameUseCase.ListGenerators()
+	response := &pb.ListGeneratorsResponse{
+		Generators: make([]*pb.GeneratorInfo, 0, len(generators)),
+	}
+
+	for _, info := range generators {
+		response.Generators = append(response.Generators, generatorInfoToPB(info))
+	}
+
+	return response, nil
+}
+
+func (s *DiceGameService) ListDiceTables(_ context.Context, _ *pb.ListDiceTablesRequest) (*pb.ListDiceTablesResponse, error) {
+	s.logger.Info().Msg("Received ListDiceTables request")
+
+	return &pb.ListDiceTablesResponse{Tables: s.gameUseCase.ListDiceTables()}, nil
+}
+
+func (s *DiceGameService) ListVariants(_ context.Context, _ *pb.ListVariantsRequest) (*pb.ListVariantsResponse, error) {
+	s.logger.Info().Msg("Received ListVariants request")
+
+	variants := s.gameUseCase.ListVariants()
+	response := &pb.ListVariantsResponse{
+		Variants: make([]*pb.GameVariant, 0, len(variants)),
+	}
+
+	for _, variant := range variants {
+		response.Variants = append(response.Variants, &pb.GameVariant{
+			Name:       variant.Name,
+			Version:    int32(variant.Version),
+			DrawPolicy: variant.DrawPolicy,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *DiceGameService) StartMatch(ctx context.Context, req *pb.StartMatchRequest) (*pb.Match, error) {
+	s.logger.Info().
+		Str("player_id", req.GetPlayerId()).
+		Int32("best_of", req.GetBestOf()).
+		Msg("Received StartMatch request")
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	match, err := s.gameUseCase.StartMatch(ctx, req.GetPlayerId(), int(req.GetBestOf()), req.GetGenerator(), req.GetDice(), req.GetVariant())
+	if err != nil {
+		return nil, s.matchError(err, "start match")
+	}
+
+	s.logger.Info().
+		Str("match_id", match.MatchID).
+		Int("best_of", match.BestOf).
+		Str("variant", match.Variant).
+		Msg("Match started")
+
+	return s.matchResponse(match)
+}
+
+func (s *DiceGameService) PlayRound(ctx context.Context, req *pb.PlayRoundRequest) (*pb.PlayRoundResponse, error) {
+	s.logger.Info().Str("match_id", req.GetMatchId()).Msg("Received PlayRound request")
+
+	if len(req.GetClientSeed()) > maxClientSeedLength {
+		return nil, status.Errorf(codes.InvalidArgument, "client seed must be at most %d characters", maxClientSeedLength)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, PlayTimeout)
+	defer cancel()
+
+	match, result, err := s.gameUseCase.PlayRound(ctx, req.GetMatchId(), req.GetClientSeed())
+	if err != nil {
+		return nil, s.matchError(err, "play match round")
+	}
+
+	game, err := s.playResponse(result)
+	if err != nil {
+		return nil, err
+	}
+	matchResponse, err := s.matchResponse(match)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("match_id", match.MatchID).
+		Int("round", result.MatchRound).
+		Str("game_id", result.GameID).
+		Str("winner", string(result.Winner)).
+		Str("match_winner", string(match.Winner)).
+		Msg("Match round completed")
+
+	return &pb.PlayRoundResponse{Game: game, Match: matchResponse}, nil
+}
+
+func (s *DiceGameService) PlayMatch(ctx context.Context, req *pb.PlayMatchRequest) (*pb.Match, error) {
+	s.logger.Info().
+		Str("player_id", req.GetPlayerId()).
+		Int32("best_of", req.GetBestOf()).
+		Msg("Received PlayMatch request")
+
+	if len(req.GetClientSeed()) > maxClientSeedLength {
+		return nil, status.Errorf(codes.InvalidArgument, "client seed must be at most %d characters", maxClientSeedLength)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, MatchTimeout)
+	defer cancel()
+
+	match, err := s.gameUseCase.PlayMatch(ctx, req.GetPlayerId(), req.GetClientSeed(), int(req.GetBestOf()), req.GetGenerator(), req.GetDice(), req.GetVariant())
+	if err != nil {
+		return nil, s.matchError(err, "play match")
+	}
+
+	s.logger.Info().
+		Str("match_id", match.MatchID).
+		Int("games", len(match.Games)).
+		Str("winner", string(match.Winner)).
+		Msg("Match completed")
+
+	return s.matchResponse(match)
+}
+
+func (s *DiceGameService) GetMatch(ctx context.Context, req *pb.GetMatchRequest) (*pb.Match, error) {
+	s.logger.Info().Str("match_id", req.GetMatchId()).Msg("Received GetMatch request")
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	match, err := s.gameUseCase.GetMatch(ctx, req.GetMatchId())
+	if err != nil {
+		return nil, s.matchError(err, "get match")
+	}
+
+	return s.matchResponse(match)
+}
+
+func (s *DiceGameService) GetMatchStats(ctx context.Context, req *pb.GetMatchStatsRequest) (*pb.GetMatchStatsResponse, error) {
+	s.logger.Info().Str("player_id", req.GetPlayerId()).Msg("Received GetMatchStats request")
+
+	if req.GetPlayerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "player_id is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	stats, err := s.gameUseCase.GetMatchStats(ctx, req.GetPlayerId())
+	if err != nil {
+		s.logger.Error().Err(err).Str("player_id", req.GetPlayerId()).Msg("Failed to get match stats")
+		return nil, status.Errorf(codes.Internal, "failed to get match stats: %v", err)
+	}
+
+	return &pb.GetMatchStatsResponse{
+		PlayerId:   stats.PlayerID,
+		Played:     int64(stats.Played),
+		Won:        int64(stats.Won),
+		Lost:       int64(stats.Lost),
+		Drawn:      int64(stats.Drawn),
+		InProgress: int64(stats.InProgress),
+		Games:      int64(stats.Games),
+	}, nil
+}
+
+// verifyError logs a failed Verify call and converts its error to a status.
+func (s *DiceGameService) verifyError(err error, gameID string) error {
+	switch {
+	case errors.Is(err, service.ErrGameNotFound):
+		return status.Errorf(codes.NotFound, "failed to verify game: %v", err)
+	case errors.Is(err, service.ErrSeedNotRevealed):
+		s.logger.Warn().Err(err).Str("game_id", gameID).Msg("Failed to verify game")
+		return status.Errorf(codes.FailedPrecondition, "failed to verify game: %v", err)
+	case errors.Is(err, service.ErrNotVerifiable):
+		s.logger.Warn().Err(err).Str("game_id", gameID).Msg("Failed to verify game")
+		return status.Errorf(codes.InvalidArgument, "failed to verify game: %v", err)
+	default:
+		s.logger.Error().Err(err).Str("game_id", gameID).Msg("Failed to verify game")
+		return status.Errorf(codes.Internal, "failed to verify game: %v", err)
+	}
+}
+
+// matchError logs a failed match call, described by action, and converts
+// its error to a status.
+func (s *DiceGameService) matchError(err error, action string) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidBestOf),
+		errors.Is(err, service.ErrGeneratorNotAllowed),
+		errors.Is(err, service.ErrDiceNotOffered),
+		errors.Is(err, rules.ErrUnknownVariant):
+		s.logger.Warn().Err(err).Msg("Failed to " + action)
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrMatchNotFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, service.ErrMatchFinished):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	default:
+		s.logger.Error().Err(err).Msg("Failed to " + action)
+		return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+	}
+}
+
+func (s *DiceGameService) matchResponse(match *model.Match) (*pb.Match, error) {
+	response := &pb.Match{
+		MatchId:    match.MatchID,
+		PlayerId:   match.PlayerID,
+		BestOf:     int32(match.BestOf),
+		Variant:    match.Variant,
+		Dice:       match.Dice,
+		Generator:  match.Generator,
+		PlayerWins: int32(match.PlayerWins),
+		ServerWins: int32(match.ServerWins),
+		Draws:      int32(match.Draws),
+		Winner:     string(match.Winner),
+		CreatedAt:  match.CreatedAt.Format(time.RFC3339),
+		Games:      make([]*pb.PlayResponse, 0, len(match.Games)),
+	}
+	if match.FinishedAt != nil {
+		response.FinishedAt = match.FinishedAt.Format(time.RFC3339)
+	}
+
+	for _, result := range match.Games {
+		game, err := s.playResponse(result)
+		if err != nil {
+			return nil, err
+		}
+		response.Games = append(response.Games, game)
+	}
+
+	return response, nil
+}
+
+func toGameRounds(rounds []model.GameRound) []*pb.GameRound {
+	result := make([]*pb.GameRound, len(rounds))
+	for i, round := range rounds {
+		result[i] = &pb.GameRound{
+			PlayerDice:  int32(round.PlayerDice),
+			ServerDice:  int32(round.ServerDice),
+			PlayerRolls: toInt32s(round.PlayerRolls),
+			ServerRolls: toInt32s(round.ServerRolls),
+		}
+	}
+	return result
+}
+
+func toInt32s(values []int) []int32 {
+	if values == nil {
+		return nil
+	}
+
+	result := make([]int32, len(values))
+	for i, v := range values {
+		result[i] = int32(v)
+	}
+	return result
+}
+
+func generatorInfoToPB(info model.GeneratorInfo) *pb.GeneratorInfo {
+	return &pb.GeneratorInfo{
+		Name:             info.Name,
+		Verifiable:       info.Verifiable,
+		Algorithm:        info.Algorithm,
+		AlgorithmVersion: int32(info.AlgorithmVersion),
+		Selectable:       info.Selectable,
+		Quarantined:      info.Quarantined,
+		State:            string(info.State),
+		InFlight:         int32(info.InFlight),
+	}
+}
@@ -0,0 +1,218 @@
+package grpc
+
+import (
+	"context"
+	"dice-game/pkg/domain/service"
+	"dice-game/pkg/usecase"
+	pb "dice-game/proto/gen"
+	"errors"
+	"github.com/rs/zerolog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+type GeneratorAdminService struct {
+	pb.UnimplementedGeneratorAdminServiceServer
+	adminUseCase usecase.AdminUseCaseInterface
+	logger       zerolog.Logger
+}
+
+func NewGeneratorAdminService(adminUseCase usecase.AdminUseCaseInterface, logger zerolog.Logger) *GeneratorAdminService {
+	return &GeneratorAdminService{
+		adminUseCase: adminUseCase,
+		logger:       logger.With().Str("component", "generator_admin_grpc_service").Logger(),
+	}
+}
+
+func (s *GeneratorAdminService) ListGenerators(_ context.Context, _ *pb.ListGeneratorsRequest) (*pb.ListGeneratorsResponse, error) {
+	s.logger.Info().Msg("Received admin ListGenerators request")
+
+	generators := s.adminUseCase.ListGenerators()
+	response := &pb.ListGeneratorsResponse{
+		Generators: make([]*pb.GeneratorInfo, 0, len(generators)),
+	}
+
+	for _, info := range generators {
+		response.Generators = append(response.Generators, generatorInfoToPB(info))
+	}
+
+	return response, nil
+}
+
+func (s *GeneratorAdminService) RegisterGenerator(_ context.Context, req *pb.GeneratorRequest) (*pb.GeneratorResponse, error) {
+	s.logger.Info().Str("generator", req.GetName()).Msg("Received RegisterGenerator request")
+
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "generator name is required")
+	}
+
+	info, err := s.adminUseCase.RegisterGenerator(req.GetName())
+	if err != nil {
+		s.logger.Error().Err(err).Str("generator", req.GetName()).Msg("Failed to register generator")
+		return nil, adminError("failed to register generator", err)
+	}
+
+	s.logger.Warn().Str("generator", info.Name).Msg("Generator registered by admin")
+
+	return &pb.GeneratorResponse{Generator: generatorInfoToPB(*info)}, nil
+}
+
+func (s *GeneratorAdminService) UnregisterGenerator(_ context.Context, req *pb.GeneratorRequest) (*pb.UnregisterGeneratorResponse, error) {
+	s.logger.Info().Str("generator", req.GetName()).Msg("Received UnregisterGenerator request")
+
+	if err := s.adminUseCase.UnregisterGenerator(req.GetName()); err != nil {
+		s.logger.Error().Err(err).Str("generator", req.GetName()).Msg("Failed to unregister generator")
+		return nil, adminError("failed to unregister generator", err)
+	}
+
+	s.logger.Warn().Str("generator", req.GetName()).Msg("Generator unregistered by admin")
+
+	return &pb.UnregisterGeneratorResponse{}, nil
+}
+
+func (s *GeneratorAdminService) EnableGenerator(_ context.Context, req *pb.GeneratorRequest) (*pb.GeneratorResponse, error) {
+	s.logger.Info().Str("generator", req.GetName()).Msg("Received EnableGenerator request")
+
+	info, cleared, err := s.adminUseCase.EnableGenerator(req.GetName())
+	if err != nil {
+		s.logger.Error().Err(err).Str("generator", req.GetName()).Msg("Failed to enable generator")
+		return nil, adminError("failed to enable generator", err)
+	}
+
+	s.logger.Warn().Str("generator", info.Name).Msg("Generator enabled by admin")
+	if cleared {
+		s.logger.Warn().Str("generator", info.Name).Msg("Health quarantine cleared by operator override")
+	}
+
+	return &pb.GeneratorResponse{Generator: generatorInfoToPB(*info)}, nil
+}
+
+func (s *GeneratorAdminService) DisableGenerator(_ context.Context, req *pb.GeneratorRequest) (*pb.GeneratorResponse, error) {
+	s.logger.Info().Str("generator", req.GetName()).Msg("Received DisableGenerator request")
+
+	info, err := s.adminUseCase.DisableGenerator(req.GetName())
+	if err != nil {
+		s.logger.Error().Err(err).Str("generator", req.GetName()).Msg("Failed to disable generator")
+		return nil, adminError("failed to disable generator", err)
+	}
+
+	s.logger.Warn().
+		Str("generator", info.Name).
+		Int("in_flight", info.InFlight).
+		Msg("Generator disabled by admin")
+
+	return &pb.GeneratorResponse{Generator: generatorInfoToPB(*info)}, nil
+}
+
+func (s *GeneratorAdminService) DrainGenerator(ctx context.Context, req *pb.DrainGeneratorRequest) (*pb.GeneratorResponse, error) {
+	s.logger.Info().
+		Str("generator", req.GetName()).
+		Int32("timeout_seconds", req.GetTimeoutSeconds()).
+		Msg("Received DrainGenerator request")
+
+	timeout := defaultDrainTimeout
+	if req.GetTimeoutSeconds() > 0 {
+		timeout = time.Duration(req.GetTimeoutSeconds()) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	info, err := s.adminUseCase.DrainGenerator(ctx, req.GetName())
+	if err != nil {
+		s.logger.Error().Err(err).Str("generator", req.GetName()).Msg("Failed to drain generator")
+		return nil, adminError("failed to drain generator", err)
+	}
+
+	s.logger.Warn().Str("generator", info.Name).Msg("Generator drained by admin")
+
+	return &pb.GeneratorResponse{Generator: generatorInfoToPB(*info)}, nil
+}
+
+func (s *GeneratorAdminService) RotateSeed(ctx context.Context, _ *pb.RotateSeedRequest) (*pb.RotateSeedResponse, error) {
+	s.logger.Info().Msg("Received RotateSeed request")
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	revealed, next, err := s.adminUseCase.RotateSeed(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to rotate server seed")
+		return nil, status.Errorf(codes.Internal, "failed to rotate server seed: %v", err)
+	}
+
+	response := &pb.RotateSeedResponse{
+		RevealedServerSeed:     revealed.Seed,
+		RevealedServerSeedHash: revealed.Hash,
+		RevealedAt:             revealed.RevealedAt.Format(time.RFC3339),
+		NextServerSeedHash:     next.Hash,
+	}
+
+	s.logger.Warn().
+		Str("revealed_server_seed_hash", revealed.Hash).
+		Str("next_server_seed_hash", next.Hash).
+		Msg("Server seed rotated by admin")
+
+	return response, nil
+}
+
+func (s *GeneratorAdminService) AuditGameEntropy(ctx context.Context, req *pb.AuditGameEntropyRequest) (*pb.AuditGameEntropyResponse, error) {
+	s.logger.Info().Str("game_id", req.GetGameId()).Msg("Received AuditGameEntropy request")
+
+	if req.GetGameId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "game ID is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	audit, err := s.adminUseCase.AuditGameEntropy(ctx, req.GetGameId())
+	if err != nil {
+		s.logger.Error().Err(err).Str("game_id", req.GetGameId()).Msg("Failed to audit game entropy")
+		return nil, adminError("failed to audit game entropy", err)
+	}
+
+	if !audit.Valid {
+		s.logger.Warn().
+			Str("game_id", req.GetGameId()).
+			Str("reason", audit.Reason).
+			Msg("Recorded entropy does not match the game")
+	}
+
+	entropy := audit.Entropy
+	return &pb.AuditGameEntropyResponse{
+		GameId:     entropy.GameID,
+		Generator:  entropy.Generator,
+		Mapping:    entropy.Mapping,
+		Count:      int32(entropy.Count),
+		Min:        int32(entropy.Min),
+		Max:        int32(entropy.Max),
+		Entropy:    entropy.Data,
+		KeyId:      entropy.KeyID,
+		RecordedAt: entropy.CreatedAt.Format(time.RFC3339),
+		Values:     toInt32s(audit.Values),
+		Valid:      audit.Valid,
+		Reason:     audit.Reason,
+	}, nil
+}
+
+func adminError(msg string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrEntropyNotRecorded):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, service.ErrGeneratorNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, usecase.ErrUnknownGenerator):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, "%s: %v", msg, err)
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, "%s: %v", msg, err)
+	default:
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	}
+}
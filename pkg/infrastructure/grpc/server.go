@@ -2,28 +2,39 @@ package grpc
 
 import (
 	"context"
+	"crypto/subtle"
 	"dice-game/pkg/usecase"
 	pb "dice-game/proto/gen"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"net"
+	"strings"
 )
 
+const adminMethodPrefix = "/dice_game.GeneratorAdminService/"
+
 type Server struct {
 	address     string
 	logger      zerolog.Logger
 	server      *grpc.Server
 	gameUseCase usecase.GameUseCaseInterface
+	// adminUseCase is served as GeneratorAdminService to callers presenting
+	// adminToken; without a token the service is not registered.
+	adminUseCase usecase.AdminUseCaseInterface
+	adminToken   string
 }
 
-func NewServer(address string, logger *zerolog.Logger, gameUseCase usecase.GameUseCaseInterface) *Server {
+func NewServer(address string, logger *zerolog.Logger, gameUseCase usecase.GameUseCaseInterface, adminUseCase usecase.AdminUseCaseInterface, adminToken string) *Server {
 	return &Server{
-		address:     address,
-		logger:      logger.With().Str("component", "grpc_server").Logger(),
-		gameUseCase: gameUseCase,
+		address:      address,
+		logger:       logger.With().Str("component", "grpc_server").Logger(),
+		gameUseCase:  gameUseCase,
+		adminUseCase: adminUseCase,
+		adminToken:   adminToken,
 	}
 }
 
@@ -35,13 +46,20 @@ func (s *Server) Start() error {
 	}
 
 	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(s.panicRecoveryInterceptor()),
+		grpc.ChainUnaryInterceptor(s.panicRecoveryInterceptor(), s.adminAuthInterceptor()),
 	}
 	s.server = grpc.NewServer(opts...)
 
 	diceGameService := NewDiceGameService(s.gameUseCase, s.logger)
 	pb.RegisterDiceGameServiceServer(s.server, diceGameService)
 
+	if s.adminToken != "" {
+		adminService := NewGeneratorAdminService(s.adminUseCase, s.logger)
+		pb.RegisterGeneratorAdminServiceServer(s.server, adminService)
+	} else {
+		s.logger.Info().Msg("No admin token configured, generator admin service disabled")
+	}
+
 	reflection.Register(s.server)
 
 	s.logger.Info().Str("address", s.address).Msg("gRPC server starting")
@@ -66,6 +84,43 @@ func (s *Server) panicRecoveryInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// adminAuthInterceptor rejects admin calls without the admin token in
+// "authorization: Bearer <token>" metadata.
+func (s *Server) adminAuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, adminMethodPrefix) {
+			return handler(ctx, req)
+		}
+
+		if !s.isAdmin(ctx) {
+			s.logger.Warn().Str("method", info.FullMethod).Msg("Rejected unauthenticated admin call")
+			return nil, status.Errorf(codes.Unauthenticated, "invalid admin token")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (s *Server) isAdmin(ctx context.Context) bool {
+	if s.adminToken == "" {
+		return false
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, value := range md.Get("authorization") {
+		token, found := strings.CutPrefix(value, "Bearer ")
+		if found && subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *Server) Stop() {
 	if s.server != nil {
 		s.logger.Info().Msg("Gracefully stopping gRPC server")
@@ -0,0 +1,112 @@
+// Package merkle builds binary SHA-256 Merkle trees and inclusion proofs.
+//
+// Leaves and inner nodes are hashed with different prefixes (0x00 and 0x01,
+// as in RFC 6962) so an inner node can never be passed off as a leaf. When
+// a level has an odd number of nodes the last one is carried up unchanged
+// instead of being paired with itself.
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// ProofStep is one sibling on the path from a leaf to the root. Left is true
+// when the sibling is the left operand of the parent hash.
+type ProofStep struct {
+	Hash []byte
+	Left bool
+}
+
+type Tree struct {
+	// levels[0] holds the leaf hashes, the last level holds the root.
+	levels [][][]byte
+}
+
+func LeafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// NewTree builds a tree over the given leaf data in order.
+func NewTree(leaves [][]byte) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, fmt.Errorf("merkle tree needs at least one leaf")
+	}
+
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = LeafHash(leaf)
+	}
+
+	levels := [][][]byte{level}
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, nodeHash(level[i], level[i+1]))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	return &Tree{levels: levels}, nil
+}
+
+func (t *Tree) Root() []byte {
+	return t.levels[len(t.levels)-1][0]
+}
+
+func (t *Tree) LeafCount() int {
+	return len(t.levels[0])
+}
+
+// Proof returns the siblings needed to recompute the root from leaf index.
+func (t *Tree) Proof(index int) ([]ProofStep, error) {
+	if index < 0 || index >= t.LeafCount() {
+		return nil, fmt.Errorf("leaf index %d out of range [0, %d)", index, t.LeafCount())
+	}
+
+	var steps []ProofStep
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			steps = append(steps, ProofStep{Hash: level[sibling], Left: sibling < index})
+		}
+		index /= 2
+	}
+
+	return steps, nil
+}
+
+// VerifyProof reports whether leafData hashes up to root along steps.
+func VerifyProof(leafData []byte, steps []ProofStep, root []byte) bool {
+	hash := LeafHash(leafData)
+	for _, step := range steps {
+		if step.Left {
+			hash = nodeHash(step.Hash, hash)
+		} else {
+			hash = nodeHash(hash, step.Hash)
+		}
+	}
+
+	return string(hash) == string(root)
+}
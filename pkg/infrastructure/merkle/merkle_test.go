@@ -0,0 +1,102 @@
+package merkle
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func leaves(n int) [][]byte {
+	data := make([][]byte, n)
+	for i := range data {
+		data[i] = []byte(fmt.Sprintf("leaf-%d", i))
+	}
+	return data
+}
+
+func TestNewTree_Root(t *testing.T) {
+	tests := []struct {
+		name   string
+		leaves [][]byte
+		root   string
+	}{
+		{
+			name:   "Single leaf",
+			leaves: [][]byte{[]byte("a")},
+			root:   "022a6979e6dab7aa5ae4c3e5e45f7e977112a7e63593820dbec1ec738a24f93c",
+		},
+		{
+			name:   "Odd leaf is carried up",
+			leaves: [][]byte{[]byte("a"), []byte("b"), []byte("c")},
+			root:   "36642e73c2540ab121e3a6bf9545b0a24982cd830eb13d3cd19de3ce6c021ec1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := NewTree(tt.leaves)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.root, hex.EncodeToString(tree.Root()))
+			assert.Equal(t, len(tt.leaves), tree.LeafCount())
+		})
+	}
+}
+
+func TestNewTree_Empty(t *testing.T) {
+	tree, err := NewTree(nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, tree)
+}
+
+func TestTree_Proof(t *testing.T) {
+	for n := 1; n <= 17; n++ {
+		data := leaves(n)
+		tree, err := NewTree(data)
+		require.NoError(t, err)
+
+		for i := range data {
+			steps, err := tree.Proof(i)
+			require.NoError(t, err)
+
+			assert.True(t, VerifyProof(data[i], steps, tree.Root()), "leaf %d of %d", i, n)
+			assert.False(t, VerifyProof([]byte("edited"), steps, tree.Root()), "edited leaf %d of %d", i, n)
+		}
+	}
+}
+
+func TestTree_ProofRejectsWrongPosition(t *testing.T) {
+	data := leaves(4)
+	tree, err := NewTree(data)
+	require.NoError(t, err)
+
+	steps, err := tree.Proof(1)
+	require.NoError(t, err)
+
+	assert.False(t, VerifyProof(data[2], steps, tree.Root()))
+}
+
+func TestTree_ProofOutOfRange(t *testing.T) {
+	tree, err := NewTree(leaves(3))
+	require.NoError(t, err)
+
+	_, err = tree.Proof(3)
+	assert.Error(t, err)
+	_, err = tree.Proof(-1)
+	assert.Error(t, err)
+}
+
+func TestVerifyProof_InnerNodeIsNotALeaf(t *testing.T) {
+	data := leaves(4)
+	tree, err := NewTree(data)
+	require.NoError(t, err)
+
+	inner := append(append([]byte{}, LeafHash(data[0])...), LeafHash(data[1])...)
+	steps := []ProofStep{{Hash: nodeHash(LeafHash(data[2]), LeafHash(data[3])), Left: false}}
+
+	assert.False(t, VerifyProof(inner, steps, tree.Root()))
+}
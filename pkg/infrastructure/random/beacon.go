@@ -0,0 +1,379 @@
+package random
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// beaconPollInterval is how often a beacon is asked again for a round that
+// is due but not served yet.
+const beaconPollInterval = 500 * time.Millisecond
+
+// BeaconRound is one round of a public randomness beacon. Randomness is hex
+// encoded, as published by drand. PublishedAt is when the round became
+// public; no one knew its randomness before then.
+type BeaconRound struct {
+	Round       int64     `json:"round"`
+	Randomness  string    `json:"randomness"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func (r *BeaconRound) validate() error {
+	if r.Round < 1 {
+		return fmt.Errorf("invalid beacon round %d", r.Round)
+	}
+	if _, err := hex.DecodeString(r.Randomness); err != nil || r.Randomness == "" {
+		return fmt.Errorf("invalid randomness in beacon round %d", r.Round)
+	}
+	return nil
+}
+
+// BeaconSource reads rounds of a public randomness beacon. Past rounds must
+// stay available so games can be verified later.
+type BeaconSource interface {
+	// RoundAfter returns the first round published strictly after t,
+	// waiting until it is out when t is recent.
+	RoundAfter(ctx context.Context, t time.Time) (*BeaconRound, error)
+	Round(ctx context.Context, round int64) (*BeaconRound, error)
+}
+
+// HTTPBeacon reads a drand-compatible HTTP API: GET {baseURL}/info for the
+// chain's genesis_time and period, and GET {baseURL}/public/{round} for a
+// JSON object that holds at least round and randomness. drand publishes
+// round r at genesis_time + (r-1)*period, which is where PublishedAt comes
+// from.
+type HTTPBeacon struct {
+	baseURL string
+	client  *http.Client
+
+	mu   sync.Mutex
+	info *beaconChainInfo
+}
+
+// beaconChainInfo is the part of drand's /info answer that dates rounds.
+type beaconChainInfo struct {
+	Period      int64 `json:"period"`
+	GenesisTime int64 `json:"genesis_time"`
+}
+
+func (i *beaconChainInfo) publishedAt(round int64) time.Time {
+	return time.Unix(i.GenesisTime+(round-1)*i.Period, 0)
+}
+
+// roundAfter returns the first round published strictly after t.
+func (i *beaconChainInfo) roundAfter(t time.Time) int64 {
+	genesis := time.Unix(i.GenesisTime, 0)
+	if t.Before(genesis) {
+		return 1
+	}
+	return int64(t.Sub(genesis)/(time.Duration(i.Period)*time.Second)) + 2
+}
+
+func NewHTTPBeacon(baseURL string) *HTTPBeacon {
+	return &HTTPBeacon{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (b *HTTPBeacon) RoundAfter(ctx context.Context, t time.Time) (*BeaconRound, error) {
+	info, err := b.chainInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	round := info.roundAfter(t)
+	if err := sleepUntil(ctx, info.publishedAt(round)); err != nil {
+		return nil, err
+	}
+
+	// Nodes serve a round a moment after its scheduled time, so a round
+	// that is due is asked for a few times before giving up.
+	for attempt := 1; ; attempt++ {
+		result, err := b.Round(ctx, round)
+		if err == nil || attempt == 5 {
+			return result, err
+		}
+		if err := sleepUntil(ctx, time.Now().Add(beaconPollInterval)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (b *HTTPBeacon) Round(ctx context.Context, round int64) (*BeaconRound, error) {
+	info, err := b.chainInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := b.fetch(ctx, strconv.FormatInt(round, 10))
+	if err != nil {
+		return nil, err
+	}
+	if result.Round != round {
+		return nil, fmt.Errorf("beacon returned round %d, requested %d", result.Round, round)
+	}
+
+	result.PublishedAt = info.publishedAt(round)
+	return result, nil
+}
+
+// Period returns how often the chain publishes a round, which is the
+// longest RoundAfter waits for one.
+func (b *HTTPBeacon) Period(ctx context.Context) (time.Duration, error) {
+	info, err := b.chainInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(info.Period) * time.Second, nil
+}
+
+// chainInfo fetches the chain's genesis time and period once; they never
+// change for a drand chain.
+func (b *HTTPBeacon) chainInfo(ctx context.Context) (*beaconChainInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.info != nil {
+		return b.info, nil
+	}
+
+	var info beaconChainInfo
+	if err := b.get(ctx, "/info", &info); err != nil {
+		return nil, fmt.Errorf("failed to fetch beacon chain info: %w", err)
+	}
+	if info.Period < 1 || info.GenesisTime < 1 {
+		return nil, fmt.Errorf("invalid beacon chain info: period %d, genesis time %d", info.Period, info.GenesisTime)
+	}
+
+	b.info = &info
+	return b.info, nil
+}
+
+func (b *HTTPBeacon) fetch(ctx context.Context, path string) (*BeaconRound, error) {
+	var round BeaconRound
+	if err := b.get(ctx, "/public/"+path, &round); err != nil {
+		return nil, fmt.Errorf("failed to fetch beacon round %s: %w", path, err)
+	}
+
+	if err := round.validate(); err != nil {
+		return nil, err
+	}
+
+	return &round, nil
+}
+
+func (b *HTTPBeacon) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build beacon request: %w", err)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("beacon returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode beacon response: %w", err)
+	}
+
+	return nil
+}
+
+// sleepUntil waits until t or until ctx is done.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	wait := time.Until(t)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// errNoBeaconRoundYet is returned by FileBeacon.nextRound while no round in
+// the file is published after the requested time.
+var errNoBeaconRoundYet = errors.New("no beacon round published yet")
+
+// FileBeacon serves rounds from a JSON array of {round, randomness,
+// published_at} objects. The file is read on every call so rounds can be
+// appended while the server runs. It stands in for a real beacon in tests
+// and local setups.
+type FileBeacon struct {
+	path string
+}
+
+func NewFileBeacon(path string) *FileBeacon {
+	return &FileBeacon{path: path}
+}
+
+// RoundAfter re-reads the file until a round published after t appears in
+// it or ctx is done.
+func (b *FileBeacon) RoundAfter(ctx context.Context, t time.Time) (*BeaconRound, error) {
+	for {
+		round, err := b.nextRound(t)
+		if !errors.Is(err, errNoBeaconRoundYet) {
+			return round, err
+		}
+
+		if err := sleepUntil(ctx, time.Now().Add(beaconPollInterval)); err != nil {
+			return nil, fmt.Errorf("no beacon round after %s in %s: %w", t.Format(time.RFC3339Nano), b.path, err)
+		}
+	}
+}
+
+// nextRound returns the round with the lowest number among those published
+// after t. A round dated in the future is not served before its time.
+func (b *FileBeacon) nextRound(t time.Time) (*BeaconRound, error) {
+	rounds, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var next *BeaconRound
+	for _, round := range rounds {
+		if round.PublishedAt.After(t) && (next == nil || round.Round < next.Round) {
+			next = round
+		}
+	}
+
+	if next == nil || next.PublishedAt.After(time.Now()) {
+		return nil, errNoBeaconRoundYet
+	}
+
+	return next, nil
+}
+
+func (b *FileBeacon) Round(_ context.Context, round int64) (*BeaconRound, error) {
+	rounds, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rounds {
+		if r.Round == round {
+			return r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("beacon round %d not found", round)
+}
+
+func (b *FileBeacon) read() ([]*BeaconRound, error) {
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read beacon file: %w", err)
+	}
+
+	var rounds []*BeaconRound
+	if err := json.Unmarshal(data, &rounds); err != nil {
+		return nil, fmt.Errorf("failed to parse beacon file: %w", err)
+	}
+
+	for _, round := range rounds {
+		if err := round.validate(); err != nil {
+			return nil, err
+		}
+		if round.PublishedAt.IsZero() {
+			return nil, fmt.Errorf("beacon round %d has no publish time", round.Round)
+		}
+	}
+
+	return rounds, nil
+}
+
+// BeaconClientSeed is the client seed a beacon game is rolled with:
+// "clientSeed:round:randomness". The rest of the roll is the provably fair
+// scheme, so a beacon game verifies like any other once this seed is used.
+func BeaconClientSeed(clientSeed string, round *BeaconRound) string {
+	return fmt.Sprintf("%s:%d:%s", clientSeed, round.Round, round.Randomness)
+}
+
+// BeaconGenerator mixes the committed server seed of a provably fair
+// generator, the player's client seed and a beacon round that is published
+// only after the game's nonce is reserved, so neither the server nor the
+// player alone controls the outcome. The round is fetched per game, so
+// rolls go through RollWithBeacon; Generate and Roll fail without one.
+type BeaconGenerator struct {
+	seeds  *ProovablyFairGenerator
+	source BeaconSource
+}
+
+// NewBeaconGenerator shares the server seed of seeds, so rotating that
+// generator's seed also rotates the beacon generator's.
+func NewBeaconGenerator(seeds *ProovablyFairGenerator, source BeaconSource) *BeaconGenerator {
+	return &BeaconGenerator{seeds: seeds, source: source}
+}
+
+func (g *BeaconGenerator) Generate(min, max int) (int, error) {
+	return 0, fmt.Errorf("beacon generator needs a beacon round for every roll")
+}
+
+func (g *BeaconGenerator) Roll(req RollRequest) (*Roll, error) {
+	return nil, fmt.Errorf("beacon generator needs a beacon round for every roll")
+}
+
+// ServerSeed returns the committed server seed a game is rolled with. Games
+// take it before waiting for their beacon round, so the seed is fixed
+// before the round's randomness is public; a seed rotated meanwhile is not
+// used.
+func (g *BeaconGenerator) ServerSeed() string {
+	return g.seeds.currentServerSeed()
+}
+
+// RollWithBeacon rolls with serverSeed, taken from ServerSeed before the
+// round was published.
+func (g *BeaconGenerator) RollWithBeacon(serverSeed string, round *BeaconRound, req RollRequest) (*Roll, error) {
+	req.ClientSeed = BeaconClientSeed(req.ClientSeed, round)
+	return provablyFairRoll(g.seeds.scheme, serverSeed, req)
+}
+
+// NextBeaconRound waits for the first round published after t.
+func (g *BeaconGenerator) NextBeaconRound(ctx context.Context, t time.Time) (*BeaconRound, error) {
+	round, err := g.source.RoundAfter(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	if !round.PublishedAt.After(t) {
+		return nil, fmt.Errorf("beacon round %d was published at %s, not after %s", round.Round, round.PublishedAt.Format(time.RFC3339Nano), t.Format(time.RFC3339Nano))
+	}
+	return round, nil
+}
+
+func (g *BeaconGenerator) BeaconRound(ctx context.Context, round int64) (*BeaconRound, error) {
+	return g.source.Round(ctx, round)
+}
+
+func (g *BeaconGenerator) Name() string {
+	return "beacon"
+}
+
+func (g *BeaconGenerator) Algorithm() string {
+	return g.seeds.Algorithm()
+}
+
+func (g *BeaconGenerator) AlgorithmVersion() int {
+	return g.seeds.AlgorithmVersion()
+}
@@ -0,0 +1,192 @@
+package random
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRandomness1 = "101297f1ca7dc44ef6088d94ad5fb7ba03455dc33d53ddb412bbc4564ed986ec"
+	testRandomness2 = "a57fcb9a97c8bd2cd5adb4a9b2ecc8ef9a2c4b5d1e8f7a6b5c4d3e2f1a0b9c8d"
+)
+
+func writeBeaconFile(t *testing.T, rounds []*BeaconRound) string {
+	t.Helper()
+
+	data, err := json.Marshal(rounds)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "beacon.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestFileBeacon(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := writeBeaconFile(t, []*BeaconRound{
+		{Round: 2, Randomness: testRandomness2, PublishedAt: first.Add(30 * time.Second)},
+		{Round: 1, Randomness: testRandomness1, PublishedAt: first},
+	})
+	beacon := NewFileBeacon(path)
+
+	next, err := beacon.RoundAfter(context.Background(), first.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), next.Round)
+
+	next, err = beacon.RoundAfter(context.Background(), first)
+	require.NoError(t, err)
+	assert.Equal(t, &BeaconRound{Round: 2, Randomness: testRandomness2, PublishedAt: first.Add(30 * time.Second)}, next)
+
+	round, err := beacon.Round(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, testRandomness1, round.Randomness)
+
+	_, err = beacon.Round(context.Background(), 3)
+	assert.Error(t, err)
+}
+
+func TestFileBeacon_RoundAfterWaits(t *testing.T) {
+	played := time.Now()
+	path := writeBeaconFile(t, []*BeaconRound{
+		{Round: 1, Randomness: testRandomness1, PublishedAt: played.Add(-time.Second)},
+		{Round: 2, Randomness: testRandomness2, PublishedAt: played.Add(100 * time.Millisecond)},
+	})
+
+	round, err := NewFileBeacon(path).RoundAfter(context.Background(), played)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), round.Round)
+	assert.False(t, time.Now().Before(round.PublishedAt), "a round is not served before its publish time")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = NewFileBeacon(path).RoundAfter(ctx, played.Add(time.Second))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFileBeacon_Invalid(t *testing.T) {
+	now := time.Now()
+
+	_, err := NewFileBeacon(writeBeaconFile(t, []*BeaconRound{{Round: 1, Randomness: "not-hex", PublishedAt: now}})).Round(context.Background(), 1)
+	assert.Error(t, err)
+
+	_, err = NewFileBeacon(writeBeaconFile(t, []*BeaconRound{{Round: 1, Randomness: testRandomness1}})).Round(context.Background(), 1)
+	assert.ErrorContains(t, err, "no publish time")
+
+	_, err = NewFileBeacon(filepath.Join(t.TempDir(), "missing.json")).RoundAfter(context.Background(), now)
+	assert.Error(t, err)
+}
+
+func TestHTTPBeacon(t *testing.T) {
+	genesis := time.Now().Add(-time.Minute).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/info":
+			fmt.Fprintf(w, `{"period":30,"genesis_time":%d,"hash":"ab"}`, genesis.Unix())
+		case "/public/2":
+			w.Write([]byte(`{"round":2,"randomness":"` + testRandomness2 + `","signature":"ab"}`))
+		case "/public/3":
+			w.Write([]byte(`{"round":3,"randomness":"` + testRandomness1 + `","signature":"ab"}`))
+		case "/public/5":
+			w.Write([]byte(`{"round":4,"randomness":"` + testRandomness1 + `"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	beacon := NewHTTPBeacon(server.URL + "/")
+
+	period, err := beacon.Period(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, period)
+
+	round, err := beacon.Round(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, &BeaconRound{Round: 2, Randomness: testRandomness2, PublishedAt: genesis.Add(30 * time.Second)}, round)
+
+	next, err := beacon.RoundAfter(context.Background(), genesis.Add(45*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), next.Round)
+	assert.Equal(t, genesis.Add(time.Minute), next.PublishedAt)
+
+	next, err = beacon.RoundAfter(context.Background(), genesis.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), next.Round, "a round published exactly at t is not after t")
+
+	_, err = beacon.Round(context.Background(), 5)
+	assert.ErrorContains(t, err, "requested 5")
+
+	_, err = beacon.Round(context.Background(), 7)
+	assert.ErrorContains(t, err, "status 404")
+}
+
+func TestBeaconGenerator_NextBeaconRound(t *testing.T) {
+	played := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &staleBeacon{round: &BeaconRound{Round: 1, Randomness: testRandomness1, PublishedAt: played}}
+	g := NewBeaconGenerator(nil, source)
+
+	_, err := g.NextBeaconRound(context.Background(), played)
+	assert.ErrorContains(t, err, "not after")
+
+	source.round.PublishedAt = played.Add(time.Nanosecond)
+	round, err := g.NextBeaconRound(context.Background(), played)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), round.Round)
+}
+
+// staleBeacon answers RoundAfter with a fixed round whatever the time.
+type staleBeacon struct {
+	round *BeaconRound
+}
+
+func (b *staleBeacon) RoundAfter(context.Context, time.Time) (*BeaconRound, error) {
+	return b.round, nil
+}
+
+func (b *staleBeacon) Round(context.Context, int64) (*BeaconRound, error) {
+	return b.round, nil
+}
+
+func TestBeaconGenerator_RollWithBeacon(t *testing.T) {
+	scheme := schemes[LatestSchemeVersion]
+	seeds := NewProovablyFairGenerator("server-seed", scheme)
+	g := NewBeaconGenerator(seeds, NewFileBeacon(""))
+	round := &BeaconRound{Round: 7, Randomness: testRandomness1}
+	req := RollRequest{ClientSeed: "client", Nonce: 3, Count: 2, Min: 1, Max: 6}
+
+	roll, err := g.RollWithBeacon(g.ServerSeed(), round, req)
+	require.NoError(t, err)
+
+	values, hash, err := ProvablyFairValues(scheme, "server-seed", "", "client:7:"+testRandomness1, 3, 2, 1, 6)
+	require.NoError(t, err)
+	assert.Equal(t, values, roll.Values)
+
+	key, err := ParseVerificationKey(roll.Proof)
+	require.NoError(t, err)
+	assert.Equal(t, hash, key.Hash)
+	assert.Equal(t, HashServerSeed("server-seed"), key.ServerSeedHash)
+
+	other, err := g.RollWithBeacon("server-seed", &BeaconRound{Round: 8, Randomness: testRandomness2}, req)
+	require.NoError(t, err)
+	assert.NotEqual(t, roll.Proof, other.Proof)
+
+	seeds.RotateServerSeed("next-seed")
+	assert.Equal(t, "next-seed", g.ServerSeed(), "the beacon generator follows seed rotation")
+	committed, err := g.RollWithBeacon("server-seed", round, req)
+	require.NoError(t, err)
+	assert.Equal(t, roll.Proof, committed.Proof, "a game keeps the seed it took before the rotation")
+
+	_, err = g.Roll(req)
+	assert.Error(t, err)
+	assert.Equal(t, "beacon", g.Name())
+}
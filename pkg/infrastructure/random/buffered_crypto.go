@@ -0,0 +1,184 @@
+package random
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+const (
+	// bufferedBlockSize is the number of output bytes a shard produces per
+	// refill.
+	bufferedBlockSize = 4096
+
+	DefaultReseedInterval = time.Minute
+)
+
+// BufferedCryptoGenerator is a faster CryptoGenerator for high game rates.
+// Each shard is an AES-256-CTR generator keyed from crypto/rand that fills a
+// 4 KiB buffer at a time; the first 32 bytes of every refill replace the key
+// (fast key erasure), so a captured shard does not reveal earlier output.
+// Shards are taken from a sync.Pool, so concurrent games do not contend on a
+// lock, and reseed from crypto/rand once reseedInterval has passed. Values
+// are drawn without modulo bias and without big.Int.
+type BufferedCryptoGenerator struct {
+	entropy        io.Reader
+	reseedInterval time.Duration
+	shards         sync.Pool
+}
+
+type cryptoShard struct {
+	key      [32]byte
+	seededAt time.Time
+	block    [32 + bufferedBlockSize]byte
+	buf      []byte
+}
+
+func NewBufferedCryptoGenerator(reseedInterval time.Duration) *BufferedCryptoGenerator {
+	return newBufferedCryptoGenerator(rand.Reader, reseedInterval)
+}
+
+func newBufferedCryptoGenerator(entropy io.Reader, reseedInterval time.Duration) *BufferedCryptoGenerator {
+	if reseedInterval <= 0 {
+		reseedInterval = DefaultReseedInterval
+	}
+
+	g := &BufferedCryptoGenerator{
+		entropy:        entropy,
+		reseedInterval: reseedInterval,
+	}
+	g.shards.New = func() any {
+		return &cryptoShard{}
+	}
+
+	return g
+}
+
+func (g *BufferedCryptoGenerator) Generate(min, max int) (int, error) {
+	if min > max {
+		min, max = max, min
+	}
+
+	shard := g.shards.Get().(*cryptoShard)
+	defer g.shards.Put(shard)
+
+	return g.intn(shard, min, max, nil)
+}
+
+func (g *BufferedCryptoGenerator) Roll(req RollRequest) (*Roll, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	min, max := req.Min, req.Max
+	if min > max {
+		min, max = max, min
+	}
+
+	shard := g.shards.Get().(*cryptoShard)
+	defer g.shards.Put(shard)
+
+	// Recorded words are kept with the game, outside the erased buffer.
+	var entropy *[]byte
+	if req.RecordEntropy {
+		entropy = new([]byte)
+	}
+
+	values := make([]int, req.Count)
+	for i := range values {
+		value, err := g.intn(shard, min, max, entropy)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	roll := &Roll{Values: values}
+	if entropy != nil {
+		roll.Entropy = *entropy
+		roll.EntropyMapping = EntropyLemire
+	}
+
+	return roll, nil
+}
+
+func (g *BufferedCryptoGenerator) Name() string {
+	return "crypto_buffered"
+}
+
+// intn returns a uniform value in [min, max] using Lemire's multiply and
+// reject method. Every word it reads is appended to entropy unless it is
+// nil.
+func (g *BufferedCryptoGenerator) intn(shard *cryptoShard, min, max int, entropy *[]byte) (int, error) {
+	n := uint64(max-min) + 1
+	if n == 0 {
+		// [min, max] spans every uint64.
+		x, err := g.uint64(shard, entropy)
+		return min + int(x), err
+	}
+
+	x, err := g.uint64(shard, entropy)
+	if err != nil {
+		return 0, err
+	}
+
+	hi, lo := bits.Mul64(x, n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			if x, err = g.uint64(shard, entropy); err != nil {
+				return 0, err
+			}
+			hi, lo = bits.Mul64(x, n)
+		}
+	}
+
+	return min + int(hi), nil
+}
+
+func (g *BufferedCryptoGenerator) uint64(shard *cryptoShard, entropy *[]byte) (uint64, error) {
+	if len(shard.buf) < 8 {
+		if err := g.refill(shard); err != nil {
+			return 0, err
+		}
+	}
+
+	x := binary.LittleEndian.Uint64(shard.buf)
+	if entropy != nil {
+		*entropy = append(*entropy, shard.buf[:8]...)
+	}
+	clear(shard.buf[:8])
+	shard.buf = shard.buf[8:]
+
+	return x, nil
+}
+
+func (g *BufferedCryptoGenerator) refill(shard *cryptoShard) error {
+	if shard.seededAt.IsZero() || time.Since(shard.seededAt) >= g.reseedInterval {
+		if _, err := io.ReadFull(g.entropy, shard.key[:]); err != nil {
+			return fmt.Errorf("failed to reseed buffered crypto generator: %w", err)
+		}
+		shard.seededAt = time.Now()
+	}
+
+	block, err := aes.NewCipher(shard.key[:])
+	if err != nil {
+		return err
+	}
+
+	var iv [aes.BlockSize]byte
+	clear(shard.block[:])
+	cipher.NewCTR(block, iv[:]).XORKeyStream(shard.block[:], shard.block[:])
+
+	copy(shard.key[:], shard.block[:32])
+	clear(shard.block[:32])
+	shard.buf = shard.block[32:]
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package random
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingReader struct {
+	reader io.Reader
+	reads  int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads++
+	return r.reader.Read(p)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy source unavailable")
+}
+
+func TestBufferedCryptoGenerator_Generate(t *testing.T) {
+	g := NewBufferedCryptoGenerator(0)
+
+	for i := 0; i < 10000; i++ {
+		val, err := g.Generate(1, 6)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, val, 1)
+		assert.LessOrEqual(t, val, 6)
+	}
+
+	val, err := g.Generate(6, 1)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, val, 1)
+	assert.LessOrEqual(t, val, 6)
+
+	val, err = g.Generate(4, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, val)
+
+	_, err = g.Generate(math.MinInt, math.MaxInt)
+	assert.NoError(t, err)
+}
+
+// TestBufferedCryptoGenerator_MatchesCryptoGenerator checks that both
+// generators pass the same goodness-of-fit and runs tests, for a die and for
+// a range that is not a power of two.
+func TestBufferedCryptoGenerator_MatchesCryptoGenerator(t *testing.T) {
+	const samples = 60000
+	alpha := 1e-6
+
+	for _, g := range []Generator{NewCryptoGenerator(), NewBufferedCryptoGenerator(0)} {
+		for _, max := range []int{6, 37} {
+			roll, err := g.Roll(RollRequest{Count: samples, Min: 1, Max: max})
+			assert.NoError(t, err)
+
+			counts := make([]int, max)
+			for _, v := range roll.Values {
+				counts[v-1]++
+			}
+
+			assert.Less(t, ChiSquare(counts), ChiSquareCritical(max-1, alpha), "%s chi-square over [1, %d]", g.Name(), max)
+			assert.Less(t, math.Abs(RunsZ(roll.Values, 1, max)), RunsCritical(alpha), "%s runs over [1, %d]", g.Name(), max)
+		}
+	}
+}
+
+func TestBufferedCryptoGenerator_Reseed(t *testing.T) {
+	t.Run("Reseeds once per interval", func(t *testing.T) {
+		entropy := &countingReader{reader: rand.Reader}
+		g := newBufferedCryptoGenerator(entropy, time.Hour)
+		shard := &cryptoShard{}
+
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, g.refill(shard))
+		}
+		assert.Equal(t, 1, entropy.reads)
+
+		shard.seededAt = shard.seededAt.Add(-time.Hour)
+		assert.NoError(t, g.refill(shard))
+		assert.Equal(t, 2, entropy.reads)
+	})
+
+	t.Run("Refills never repeat", func(t *testing.T) {
+		g := newBufferedCryptoGenerator(rand.Reader, time.Hour)
+		shard := &cryptoShard{}
+
+		assert.NoError(t, g.refill(shard))
+		first := append([]byte(nil), shard.buf...)
+		assert.NoError(t, g.refill(shard))
+
+		assert.NotEqual(t, first, shard.buf)
+	})
+
+	t.Run("Entropy failure", func(t *testing.T) {
+		g := newBufferedCryptoGenerator(failingReader{}, time.Hour)
+
+		_, err := g.Generate(1, 6)
+
+		assert.ErrorContains(t, err, "failed to reseed buffered crypto generator")
+	})
+}
+
+func benchmarkRoll(b *testing.B, g Generator) {
+	req := RollRequest{Count: 2, Min: 1, Max: 6}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.Roll(req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkRollParallel(b *testing.B, g Generator) {
+	req := RollRequest{Count: 2, Min: 1, Max: 6}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := g.Roll(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkCryptoGenerator_Roll(b *testing.B) {
+	benchmarkRoll(b, NewCryptoGenerator())
+}
+
+func BenchmarkBufferedCryptoGenerator_Roll(b *testing.B) {
+	benchmarkRoll(b, NewBufferedCryptoGenerator(0))
+}
+
+func BenchmarkCryptoGenerator_RollParallel(b *testing.B) {
+	benchmarkRollParallel(b, NewCryptoGenerator())
+}
+
+func BenchmarkBufferedCryptoGenerator_RollParallel(b *testing.B) {
+	benchmarkRollParallel(b, NewBufferedCryptoGenerator(0))
+}
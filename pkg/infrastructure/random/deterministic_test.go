@@ -0,0 +1,54 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeterministicGenerator_Replay(t *testing.T) {
+	req := RollRequest{ClientSeed: "ignored", Nonce: 99, Count: 2, Min: 1, Max: 6}
+
+	first := NewDeterministicGenerator("qa-seed", 1)
+	var rolls []*Roll
+	for i := 0; i < 20; i++ {
+		roll, err := first.Roll(req)
+		assert.NoError(t, err)
+		rolls = append(rolls, roll)
+	}
+
+	// A second run started at sequence 11 repeats the tail of the first.
+	second := NewDeterministicGenerator("qa-seed", 11)
+	for i := 10; i < 20; i++ {
+		roll, err := second.Roll(RollRequest{ClientSeed: "other", Count: 2, Min: 1, Max: 6})
+		assert.NoError(t, err)
+		assert.Equal(t, rolls[i].Values, roll.Values)
+		assert.Equal(t, rolls[i].Proof, roll.Proof)
+	}
+
+	replayed, err := first.RollAt(5, req)
+	assert.NoError(t, err)
+	assert.Equal(t, rolls[4].Values, replayed.Values)
+
+	other, err := NewDeterministicGenerator("another-seed", 5).Roll(req)
+	assert.NoError(t, err)
+	assert.NotEqual(t, replayed.Proof, other.Proof)
+}
+
+func TestDeterministicGenerator_Proof(t *testing.T) {
+	g := NewDeterministicGenerator("qa-seed", 42)
+
+	roll, err := g.Roll(RollRequest{Count: 2, Min: 1, Max: 6})
+	assert.NoError(t, err)
+	assert.Zero(t, roll.AlgorithmVersion)
+	assert.True(t, g.NonProduction())
+
+	key, err := ParseReplayKey(roll.Proof)
+	assert.NoError(t, err)
+	assert.Equal(t, ReplayKey{SeedHash: HashServerSeed("qa-seed"), Sequence: 42}, key)
+
+	_, err = ParseReplayKey("v3:hash:1:hash")
+	assert.Error(t, err)
+	_, err = ParseReplayKey("replay:hash:x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// Entropy mappings name how the raw bytes of a Roll map onto its values.
+const (
+	// EntropyWords is the mapping of HashStream: big-endian uint32 words,
+	// each either rejected or mapped onto one value.
+	EntropyWords = "u32be-reject"
+	// EntropyLemire is the mapping of the buffered crypto generator:
+	// little-endian uint64 words mapped with Lemire's multiply and reject
+	// method.
+	EntropyLemire = "u64le-lemire"
+	// EntropyValues is used by generators whose raw material is the values
+	// themselves, such as plugins: one big-endian int64 per value.
+	EntropyValues = "i64be-values"
+)
+
+// ValuesFromEntropy maps the raw bytes of a roll of count values in
+// [min, max] back onto the values, so an auditor can confirm stored
+// entropy produced a game's dice. It fails if the bytes run out before
+// count values or are left over after them.
+func ValuesFromEntropy(mapping string, entropy []byte, count, min, max int) ([]int, error) {
+	if min > max {
+		return nil, fmt.Errorf("min cannot be greater than max")
+	}
+	rangeSize := uint64(max-min) + 1
+
+	var (
+		wordSize int
+		value    func(word []byte) (int, bool)
+	)
+	switch mapping {
+	case EntropyWords:
+		if rangeSize > 1<<31 {
+			return nil, fmt.Errorf("range size %d is too large", rangeSize)
+		}
+		wordSize = 4
+		value = func(word []byte) (int, bool) {
+			return wordValue(binary.BigEndian.Uint32(word), min, rangeSize)
+		}
+	case EntropyLemire:
+		wordSize = 8
+		value = func(word []byte) (int, bool) {
+			return lemireValue(binary.LittleEndian.Uint64(word), min, rangeSize)
+		}
+	case EntropyValues:
+		wordSize = 8
+		value = func(word []byte) (int, bool) {
+			v := int64(binary.BigEndian.Uint64(word))
+			return int(v), v >= int64(min) && v <= int64(max)
+		}
+	default:
+		return nil, fmt.Errorf("unknown entropy mapping %q", mapping)
+	}
+
+	if len(entropy)%wordSize != 0 {
+		return nil, fmt.Errorf("%s entropy of %d bytes is not a whole number of words", mapping, len(entropy))
+	}
+
+	values := make([]int, 0, count)
+	for len(entropy) > 0 {
+		if len(values) == count {
+			return nil, fmt.Errorf("%d bytes of entropy left after %d values", len(entropy), count)
+		}
+		v, ok := value(entropy[:wordSize])
+		entropy = entropy[wordSize:]
+		switch {
+		case ok:
+			values = append(values, v)
+		case mapping == EntropyValues:
+			return nil, fmt.Errorf("value %d outside [%d, %d]", v, min, max)
+		}
+	}
+
+	if len(values) != count {
+		return nil, fmt.Errorf("entropy ran out after %d of %d values", len(values), count)
+	}
+
+	return values, nil
+}
+
+// lemireValue maps x onto [min, min+n-1] with Lemire's method, or rejects
+// it.
+func lemireValue(x uint64, min int, n uint64) (int, bool) {
+	hi, lo := bits.Mul64(x, n)
+	if lo < -n%n {
+		return 0, false
+	}
+	return min + int(hi), true
+}
+
+// streamRoll draws the values of req from stream and, when req asks for
+// it, records the words they were drawn from.
+func streamRoll(req RollRequest, stream *HashStream) (*Roll, error) {
+	if req.RecordEntropy {
+		stream.Record()
+	}
+
+	values, err := stream.Values(req.Count, req.Min, req.Max)
+	if err != nil {
+		return nil, err
+	}
+
+	roll := &Roll{Values: values}
+	if req.RecordEntropy {
+		roll.Entropy = stream.Consumed()
+		roll.EntropyMapping = EntropyWords
+	}
+
+	return roll, nil
+}
@@ -0,0 +1,111 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoll_RecordedEntropyMapsOntoValues(t *testing.T) {
+	vrfGenerator, err := NewVRFGenerator(testVRFSeed)
+	require.NoError(t, err)
+	chain := NewHashChainGenerator(schemes[LatestSchemeVersion])
+
+	tests := []struct {
+		name    string
+		mapping string
+		roll    func(req RollRequest) (*Roll, error)
+	}{
+		{"standard", EntropyWords, NewStandardGenerator().Roll},
+		{"crypto", EntropyWords, NewCryptoGenerator().Roll},
+		{"crypto_buffered", EntropyLemire, NewBufferedCryptoGenerator(0).Roll},
+		{"provably_fair", EntropyWords, NewProovablyFairGenerator("server-seed", schemes[LatestSchemeVersion]).Roll},
+		{"hash_chain", EntropyWords, func(req RollRequest) (*Roll, error) { return chain.RollWithServerSeed("link-seed", req) }},
+		{"deterministic", EntropyWords, NewDeterministicGenerator("replay-seed", 1).Roll},
+		{"vrf", EntropyWords, vrfGenerator.Roll},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: a range that rejects often enough to be recorded.
+			req := RollRequest{ClientSeed: "client", Nonce: 3, Count: 200, Min: 1, Max: 3 << 29, RecordEntropy: true}
+
+			// Act
+			roll, err := tt.roll(req)
+			require.NoError(t, err)
+			values, mapErr := ValuesFromEntropy(roll.EntropyMapping, roll.Entropy, req.Count, req.Min, req.Max)
+
+			// Assert
+			require.NoError(t, mapErr)
+			assert.Equal(t, tt.mapping, roll.EntropyMapping)
+			assert.Equal(t, roll.Values, values)
+		})
+	}
+}
+
+func TestRoll_EntropyOnlyWhenRequested(t *testing.T) {
+	roll, err := NewCryptoGenerator().Roll(RollRequest{Count: 2, Min: 1, Max: 6})
+
+	require.NoError(t, err)
+	assert.Nil(t, roll.Entropy)
+	assert.Empty(t, roll.EntropyMapping)
+}
+
+func TestRoll_ProvablyFairEntropyIsTheHashStream(t *testing.T) {
+	// Arrange
+	scheme := schemes[LatestSchemeVersion]
+	g := NewProovablyFairGenerator("server-seed", scheme)
+
+	// Act
+	roll, err := g.Roll(RollRequest{ClientSeed: "client", Nonce: 1, Count: 2, Min: 1, Max: 6, RecordEntropy: true})
+
+	// Assert
+	require.NoError(t, err)
+	block := scheme.Block("server-seed", "", "client", 1, 0)
+	assert.Equal(t, block[:len(roll.Entropy)], roll.Entropy)
+}
+
+func TestValuesFromEntropy(t *testing.T) {
+	tests := []struct {
+		name     string
+		mapping  string
+		entropy  []byte
+		count    int
+		expected []int
+		err      string
+	}{
+		{
+			name:     "Words with a rejected word",
+			mapping:  EntropyWords,
+			entropy:  []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 7},
+			count:    1,
+			expected: []int{2},
+		},
+		{
+			name:     "Values",
+			mapping:  EntropyValues,
+			entropy:  []byte{0, 0, 0, 0, 0, 0, 0, 6, 0, 0, 0, 0, 0, 0, 0, 1},
+			count:    2,
+			expected: []int{6, 1},
+		},
+		{name: "Value out of range", mapping: EntropyValues, entropy: []byte{0, 0, 0, 0, 0, 0, 0, 7}, count: 1, err: "outside [1, 6]"},
+		{name: "Left over", mapping: EntropyWords, entropy: []byte{0, 0, 0, 1, 0, 0, 0, 2}, count: 1, err: "left after 1 values"},
+		{name: "Ran out", mapping: EntropyWords, entropy: []byte{0, 0, 0, 1}, count: 2, err: "ran out after 1 of 2"},
+		{name: "Partial word", mapping: EntropyLemire, entropy: []byte{1, 2, 3}, count: 1, err: "not a whole number of words"},
+		{name: "Unknown mapping", mapping: "xor", entropy: []byte{1}, count: 1, err: "unknown entropy mapping"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := ValuesFromEntropy(tt.mapping, tt.entropy, tt.count, 1, 6)
+
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package random
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -13,9 +14,59 @@ import (
 
 type Generator interface {
 	Generate(min, max int) (int, error)
+	// Roll draws every value of a game at once and returns them together
+	// with the proof that binds them, so the two can never drift apart.
+	Roll(req RollRequest) (*Roll, error)
 	Name() string
 }
 
+// RollRequest describes the values drawn for a single game.
+type RollRequest struct {
+	// PlayerID is bound into the roll by schemes that support it, so two
+	// players never share a roll.
+	PlayerID   string
+	ClientSeed string
+	Nonce      int64
+	Count      int
+	Min        int
+	Max        int
+	// RecordEntropy asks the generator to return the raw bytes it drew the
+	// values from.
+	RecordEntropy bool
+}
+
+// Roll is the outcome of a RollRequest. Proof is empty for generators whose
+// output cannot be verified; AlgorithmVersion is only set for provably fair
+// schemes. Entropy holds the raw bytes behind Values, rejected ones
+// included, when the request asked for them; EntropyMapping names how they
+// map onto Values, see ValuesFromEntropy.
+type Roll struct {
+	Values           []int
+	Proof            string
+	AlgorithmVersion int
+	Entropy          []byte
+	EntropyMapping   string
+}
+
+func (r RollRequest) validate() error {
+	if r.Count < 1 {
+		return fmt.Errorf("roll count must be positive, got %d", r.Count)
+	}
+	return nil
+}
+
+// ordered swaps Min and Max when they are reversed, as Generate does.
+func (r RollRequest) ordered() RollRequest {
+	if r.Min > r.Max {
+		r.Min, r.Max = r.Max, r.Min
+	}
+	return r
+}
+
+// streamBlockSize is the size of the blocks generators without a hash
+// scheme feed their HashStream with.
+const streamBlockSize = 64
+
 type StandardGenerator struct {
 	source *mathrand.Rand
 	mu     sync.Mutex
@@ -38,6 +89,25 @@ func (g *StandardGenerator) Generate(min, max int) (int, error) {
 	return g.source.Intn(max-min+1) + min, nil
 }
 
+// Roll draws words from the math/rand source through a HashStream, so the
+// bytes behind a roll can be recorded like those of the other generators.
+func (g *StandardGenerator) Roll(req RollRequest) (*Roll, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	return streamRoll(req.ordered(), NewHashStream(func(int) []byte {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		block := make([]byte, streamBlockSize)
+		for i := 0; i < len(block); i += 4 {
+			binary.BigEndian.PutUint32(block[i:], g.source.Uint32())
+		}
+		return block
+	}))
+}
+
 func (g *StandardGenerator) Name() string {
 	return "standard"
 }
@@ -63,67 +133,165 @@ func (g *CryptoGenerator) Generate(min, max int) (int, error) {
 	return int(n.Int64()) + min, nil
 }
 
+// Roll reads blocks from crypto/rand through a HashStream, so the bytes
+// behind a roll can be recorded. crypto/rand.Read never fails.
+func (g *CryptoGenerator) Roll(req RollRequest) (*Roll, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	return streamRoll(req.ordered(), NewHashStream(func(int) []byte {
+		block := make([]byte, streamBlockSize)
+		rand.Read(block)
+		return block
+	}))
+}
+
 func (g *CryptoGenerator) Name() string {
 	return "crypto"
 }
 
+// NewServerSeed returns a fresh 256-bit server seed encoded as hex.
+func NewServerSeed() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to read server seed entropy: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// HashServerSeed returns the SHA-256 commitment published for a server seed.
+func HashServerSeed(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewClientSeed returns a random client seed for players that did not supply one.
+func NewClientSeed() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to read client seed entropy: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 type ProovablyFairGenerator struct {
-	serverSeed     string
-	clientSeedFunc func() string
-	nonce          int
+	mu         sync.RWMutex
+	serverSeed string
+	nonce      int64
+	scheme     Scheme
 }
 
-func NewProovablyFairGenerator(serverSeed string, clientSeedFunc func() string) *ProovablyFairGenerator {
+func NewProovablyFairGenerator(serverSeed string, scheme Scheme) *ProovablyFairGenerator {
 	return &ProovablyFairGenerator{
-		serverSeed:     serverSeed,
-		clientSeedFunc: clientSeedFunc,
-		nonce:          0,
+		serverSeed: serverSeed,
+		nonce:      0,
+		scheme:     scheme,
 	}
 }
 
+// Generate rolls with an empty client seed and the generator's own nonce.
+// Games use Roll so the player's seed and nonce are bound in.
 func (g *ProovablyFairGenerator) Generate(min, max int) (int, error) {
-	if min > max {
-		return 0, fmt.Errorf("min cannot be greater than max")
+	g.mu.Lock()
+	g.nonce++
+	nonce := g.nonce
+	g.mu.Unlock()
+
+	roll, err := g.Roll(RollRequest{Nonce: nonce, Count: 1, Min: min, Max: max})
+	if err != nil {
+		return 0, err
 	}
 
-	clientSeed := g.clientSeedFunc()
-	g.nonce++
+	return roll.Values[0], nil
+}
+
+// Roll derives every value with ProvablyFairValues and returns the proof as
+// a versioned VerificationKey. The server seed is read once so a concurrent
+// rotation cannot split a roll.
+func (g *ProovablyFairGenerator) Roll(req RollRequest) (*Roll, error) {
+	return provablyFairRoll(g.scheme, g.currentServerSeed(), req)
+}
+
+func (g *ProovablyFairGenerator) Name() string {
+	return "provably_fair"
+}
+
+func (g *ProovablyFairGenerator) Algorithm() string {
+	return g.scheme.Name()
+}
 
-	combined := fmt.Sprintf("%s:%s:%d", g.serverSeed, clientSeed, g.nonce)
+func (g *ProovablyFairGenerator) AlgorithmVersion() int {
+	return g.scheme.Version()
+}
 
-	hasher := sha256.New()
-	hasher.Write([]byte(combined))
-	hash := hex.EncodeToString(hasher.Sum(nil))
+func (g *ProovablyFairGenerator) ServerSeedHash() string {
+	return HashServerSeed(g.currentServerSeed())
+}
 
-	hexPart := hash[:8]
-	num, err := hex.DecodeString(hexPart)
-	if err != nil {
-		return 0, fmt.Errorf("failed to decode hex: %w", err)
+// RotateServerSeed switches to serverSeed and returns the previous seed.
+func (g *ProovablyFairGenerator) RotateServerSeed(serverSeed string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	previous := g.serverSeed
+	g.serverSeed = serverSeed
+
+	return previous
+}
+
+func (g *ProovablyFairGenerator) currentServerSeed() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.serverSeed
+}
+
+func provablyFairRoll(scheme Scheme, serverSeed string, req RollRequest) (*Roll, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
 	}
 
-	var value int = 0
-	for _, b := range num {
-		value = (value << 8) | int(b)
+	stream, first := provablyFairStream(scheme, serverSeed, req.PlayerID, req.ClientSeed, req.Nonce)
+	roll, err := streamRoll(req, stream)
+	if err != nil {
+		return nil, err
 	}
 
-	rangeSize := max - min + 1
-	result := (value % rangeSize) + min
+	proof := VerificationKey{
+		Version:        scheme.Version(),
+		ServerSeedHash: HashServerSeed(serverSeed),
+		Nonce:          req.Nonce,
+		Hash:           hex.EncodeToString(first),
+	}
+	roll.Proof = proof.String()
+	roll.AlgorithmVersion = scheme.Version()
 
-	return result, nil
+	return roll, nil
 }
 
-func (g *ProovablyFairGenerator) Name() string {
-	return "provably_fair"
-}
+// ProvablyFairValues derives count values in [min, max] for a game from the
+// HashStream of the given scheme and returns them with the hex encoding of
+// block 0, which is published as the proof hash.
+func ProvablyFairValues(scheme Scheme, serverSeed, playerID, clientSeed string, nonce int64, count, min, max int) ([]int, string, error) {
+	stream, first := provablyFairStream(scheme, serverSeed, playerID, clientSeed, nonce)
 
-func (g *ProovablyFairGenerator) GetVerificationData() string {
-	clientSeed := g.clientSeedFunc()
+	values, err := stream.Values(count, min, max)
+	if err != nil {
+		return nil, "", err
+	}
 
-	combined := fmt.Sprintf("%s:%s:%d", g.serverSeed, clientSeed, g.nonce)
+	return values, hex.EncodeToString(first), nil
+}
 
-	hasher := sha256.New()
-	hasher.Write([]byte(combined))
-	hash := hex.EncodeToString(hasher.Sum(nil))
+// provablyFairStream returns the HashStream of a game and its block 0.
+func provablyFairStream(scheme Scheme, serverSeed, playerID, clientSeed string, nonce int64) (*HashStream, []byte) {
+	first := scheme.Block(serverSeed, playerID, clientSeed, nonce, 0)
 
-	return fmt.Sprintf("%s:%d:%s", g.serverSeed, g.nonce, hash)
+	return NewHashStream(func(cursor int) []byte {
+		if cursor == 0 {
+			return first
+		}
+		return scheme.Block(serverSeed, playerID, clientSeed, nonce, cursor)
+	}), first
 }
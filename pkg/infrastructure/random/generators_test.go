@@ -1,7 +1,9 @@
 package random
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"sync"
 	"testing"
 )
@@ -39,9 +41,7 @@ func TestCryptoGenerator_Generate(t *testing.T) {
 }
 
 func TestProovablyFairGenerator_Generate(t *testing.T) {
-	g := NewProovablyFairGenerator("serverSeed", func() string {
-		return "clientSeed"
-	})
+	g := NewProovablyFairGenerator("serverSeed", schemes[LatestSchemeVersion])
 
 	for i := 0; i < 1000; i++ {
 		val, err := g.Generate(1, 6)
@@ -55,28 +55,152 @@ func TestProovablyFairGenerator_Generate(t *testing.T) {
 	assert.Contains(t, err.Error(), "min cannot be greater than max")
 }
 
-func TestProovablyFairGenerator_GetVerificationData(t *testing.T) {
+func TestProovablyFairGenerator_Roll(t *testing.T) {
 	serverSeed := "test-server-seed"
-	clientSeed := "test-client-seed"
+	g := NewProovablyFairGenerator(serverSeed, schemes[LatestSchemeVersion])
+	req := RollRequest{ClientSeed: "test-client-seed", Nonce: 1, Count: 2, Min: 1, Max: 6}
 
-	g := NewProovablyFairGenerator(serverSeed, func() string {
-		return clientSeed
-	})
+	roll, err := g.Roll(req)
+	assert.NoError(t, err)
+	assert.Len(t, roll.Values, 2)
+	assert.NotContains(t, roll.Proof, serverSeed)
+	assert.Equal(t, LatestSchemeVersion, roll.AlgorithmVersion)
+	assert.Contains(t, roll.Proof, fmt.Sprintf("v%d:%s:1:", LatestSchemeVersion, HashServerSeed(serverSeed)))
 
-	_, err := g.Generate(1, 6)
+	again, err := g.Roll(req)
 	assert.NoError(t, err)
+	assert.Equal(t, roll, again)
 
-	verificationData := g.GetVerificationData()
+	req.Nonce = 2
+	next, err := g.Roll(req)
+	assert.NoError(t, err)
+	assert.Contains(t, next.Proof, ":2:")
+	assert.NotEqual(t, roll.Proof, next.Proof)
 
-	assert.Contains(t, verificationData, serverSeed)
-	assert.Contains(t, verificationData, ":1:")
+	many, err := g.Roll(RollRequest{Count: 20, Min: 1, Max: 6})
+	assert.NoError(t, err)
+	assert.Len(t, many.Values, 20)
 
-	_, err = g.Generate(1, 6)
+	_, err = g.Roll(RollRequest{Count: 0, Min: 1, Max: 6})
+	assert.Error(t, err)
+}
+
+func TestProovablyFairGenerator_RollBindsPlayer(t *testing.T) {
+	g := NewProovablyFairGenerator("shared-server-seed", schemes[LatestSchemeVersion])
+	req := RollRequest{PlayerID: "alice", ClientSeed: "same-client-seed", Nonce: 1, Count: 10, Min: 1, Max: 6}
+
+	alice, err := g.Roll(req)
+	assert.NoError(t, err)
+
+	req.PlayerID = "bob"
+	bob, err := g.Roll(req)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, alice.Values, bob.Values)
+	assert.NotEqual(t, alice.Proof, bob.Proof)
+
+	// A separator moved between player and client seed is still a
+	// different game.
+	joined, err := g.Roll(RollRequest{PlayerID: "a", ClientSeed: "b:c", Nonce: 1, Count: 10, Min: 1, Max: 6})
+	assert.NoError(t, err)
+	moved, err := g.Roll(RollRequest{PlayerID: "a:b", ClientSeed: "c", Nonce: 1, Count: 10, Min: 1, Max: 6})
+	assert.NoError(t, err)
+	assert.NotEqual(t, joined.Proof, moved.Proof)
+
+	// Earlier schemes cannot bind the player.
+	v3 := NewProovablyFairGenerator("shared-server-seed", schemes[3])
+	old, err := v3.Roll(RollRequest{PlayerID: "alice", ClientSeed: "same-client-seed", Nonce: 1, Count: 10, Min: 1, Max: 6})
+	assert.NoError(t, err)
+	oldBob, err := v3.Roll(RollRequest{PlayerID: "bob", ClientSeed: "same-client-seed", Nonce: 1, Count: 10, Min: 1, Max: 6})
+	assert.NoError(t, err)
+	assert.Equal(t, old.Values, oldBob.Values)
+}
+
+func TestProovablyFairGenerator_ConcurrentRollProofMatchesValues(t *testing.T) {
+	g := NewProovablyFairGenerator("seed-0", schemes[LatestSchemeVersion])
+
+	const goroutines = 10
+	const iterations = 100
+
+	seedsByHash := make(map[string]string)
+	for i := 0; i <= iterations; i++ {
+		seed := fmt.Sprintf("seed-%d", i)
+		seedsByHash[HashServerSeed(seed)] = seed
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines + 1)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < iterations; i++ {
+			g.RotateServerSeed(fmt.Sprintf("seed-%d", i+1))
+		}
+	}()
+
+	for i := 0; i < goroutines; i++ {
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				roll, err := g.Roll(RollRequest{
+					ClientSeed: fmt.Sprintf("client-%d", worker),
+					Nonce:      int64(j),
+					Count:      2,
+					Min:        1,
+					Max:        6,
+				})
+				assert.NoError(t, err)
+
+				parts := strings.Split(roll.Proof, ":")
+				assert.Len(t, parts, 4)
+				values, hash, err := ProvablyFairValues(schemes[LatestSchemeVersion], seedsByHash[parts[1]], "", fmt.Sprintf("client-%d", worker), int64(j), 2, 1, 6)
+				assert.NoError(t, err)
+				assert.Equal(t, parts[3], hash)
+				assert.Equal(t, values, roll.Values)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestGenerators_Roll(t *testing.T) {
+	for _, g := range []Generator{NewStandardGenerator(), NewCryptoGenerator()} {
+		roll, err := g.Roll(RollRequest{Count: 3, Min: 1, Max: 6})
+		assert.NoError(t, err)
+		assert.Len(t, roll.Values, 3)
+		assert.Empty(t, roll.Proof)
+		for _, val := range roll.Values {
+			assert.GreaterOrEqual(t, val, 1)
+			assert.LessOrEqual(t, val, 6)
+		}
+
+		_, err = g.Roll(RollRequest{Count: 0, Min: 1, Max: 6})
+		assert.Error(t, err)
+	}
+}
+
+func TestProovablyFairGenerator_RotateServerSeed(t *testing.T) {
+	g := NewProovablyFairGenerator("first-seed", schemes[LatestSchemeVersion])
+	assert.Equal(t, HashServerSeed("first-seed"), g.ServerSeedHash())
+
+	previous := g.RotateServerSeed("second-seed")
+
+	assert.Equal(t, "first-seed", previous)
+	assert.Equal(t, HashServerSeed("second-seed"), g.ServerSeedHash())
+}
+
+func TestNewServerSeed(t *testing.T) {
+	first, err := NewServerSeed()
 	assert.NoError(t, err)
+	assert.Len(t, first, 64)
 
-	newVerificationData := g.GetVerificationData()
-	assert.Contains(t, newVerificationData, ":2:")
-	assert.NotEqual(t, verificationData, newVerificationData)
+	second, err := NewServerSeed()
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	assert.Len(t, HashServerSeed(first), 64)
+	assert.NotEqual(t, first, HashServerSeed(first))
 }
 
 func TestGenerators_Concurrency(t *testing.T) {
@@ -110,6 +234,44 @@ func TestGenerators_Name(t *testing.T) {
 	cryptoGen := NewCryptoGenerator()
 	assert.Equal(t, "crypto", cryptoGen.Name())
 
-	provablyFairGen := NewProovablyFairGenerator("seed", func() string { return "client" })
+	provablyFairGen := NewProovablyFairGenerator("seed", schemes[LatestSchemeVersion])
 	assert.Equal(t, "provably_fair", provablyFairGen.Name())
 }
+
+func TestGenerateSeedChain(t *testing.T) {
+	seeds, terminalHash, err := GenerateSeedChain(5)
+
+	assert.NoError(t, err)
+	assert.Len(t, seeds, 5)
+	assert.Equal(t, terminalHash, HashServerSeed(seeds[0]))
+
+	for i := 1; i < len(seeds); i++ {
+		assert.Equal(t, seeds[i-1], HashServerSeed(seeds[i]), "link %d must hash to the previous link", i+1)
+	}
+
+	for i, seed := range seeds {
+		assert.Equal(t, terminalHash, WalkSeedChain(seed, i+1))
+	}
+
+	_, _, err = GenerateSeedChain(0)
+	assert.Error(t, err)
+}
+
+func TestHashChainGenerator_RollWithServerSeed(t *testing.T) {
+	scheme := schemes[LatestSchemeVersion]
+	g := NewHashChainGenerator(scheme)
+	req := RollRequest{ClientSeed: "client-seed", Nonce: 3, Count: 2, Min: 1, Max: 6}
+
+	roll, err := g.RollWithServerSeed("link-seed", req)
+	assert.NoError(t, err)
+
+	expected, err := NewProovablyFairGenerator("link-seed", scheme).Roll(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, roll)
+
+	_, err = g.Roll(req)
+	assert.Error(t, err)
+	_, err = g.Generate(1, 6)
+	assert.Error(t, err)
+	assert.Equal(t, "hash_chain", g.Name())
+}
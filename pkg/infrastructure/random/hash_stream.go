@@ -0,0 +1,111 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// maxRejections bounds the number of words Intn may discard. With a range of
+// at most 2^31 values each word is rejected with probability below 1/2, so
+// hitting the bound is practically impossible.
+const maxRejections = 1024
+
+// HashStream maps hash output onto integer ranges without modulo bias.
+//
+// The stream reads big-endian uint32 words from a sequence of hash blocks.
+// Block 0 is produced with cursor 0; whenever the current block runs out the
+// next block is produced with the cursor incremented by one; trailing bytes
+// shorter than a word are skipped. A word w is
+// mapped onto [min, max] by rejection sampling: with n = max-min+1 and
+// limit = 2^32 - (2^32 mod n), words w >= limit are discarded and the next
+// word is read, otherwise the value is min + w mod n.
+//
+// The provably fair generator and the game verifier both derive dice through
+// this type, so a player can reproduce every value from the revealed seeds.
+type HashStream struct {
+	block  func(cursor int) []byte
+	cursor int
+	buf    []byte
+
+	// consumed holds every word read so far while recording.
+	record   bool
+	consumed []byte
+}
+
+func NewHashStream(block func(cursor int) []byte) *HashStream {
+	return &HashStream{
+		block: block,
+		buf:   block(0),
+	}
+}
+
+// Intn returns the next value in [min, max].
+func (s *HashStream) Intn(min, max int) (int, error) {
+	if min > max {
+		return 0, fmt.Errorf("min cannot be greater than max")
+	}
+
+	rangeSize := uint64(max-min) + 1
+	if rangeSize > 1<<31 {
+		return 0, fmt.Errorf("range size %d is too large", rangeSize)
+	}
+
+	for i := 0; i < maxRejections; i++ {
+		if value, ok := wordValue(s.nextWord(), min, rangeSize); ok {
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no value in range after %d rejected words", maxRejections)
+}
+
+// Values returns the next count values in [min, max].
+func (s *HashStream) Values(count, min, max int) ([]int, error) {
+	values := make([]int, count)
+	for i := range values {
+		value, err := s.Intn(min, max)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// Record makes the stream keep every word it reads from now on, so the raw
+// bytes behind its values can be stored; see Consumed.
+func (s *HashStream) Record() {
+	s.record = true
+}
+
+// Consumed returns the words read while recording, in order, including
+// rejected ones. ValuesFromEntropy with EntropyWords maps them back onto
+// the values.
+func (s *HashStream) Consumed() []byte {
+	return s.consumed
+}
+
+// wordValue maps word onto [min, min+rangeSize-1], or rejects it.
+func wordValue(word uint32, min int, rangeSize uint64) (int, bool) {
+	limit := (1 << 32) - (1<<32)%rangeSize
+	if uint64(word) >= limit {
+		return 0, false
+	}
+	return min + int(uint64(word)%rangeSize), true
+}
+
+func (s *HashStream) nextWord() uint32 {
+	for len(s.buf) < 4 {
+		s.cursor++
+		s.buf = s.block(s.cursor)
+	}
+
+	word := binary.BigEndian.Uint32(s.buf[:4])
+	if s.record {
+		s.consumed = append(s.consumed, s.buf[:4]...)
+	}
+	s.buf = s.buf[4:]
+
+	return word
+}
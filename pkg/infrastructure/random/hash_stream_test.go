@@ -0,0 +1,225 @@
+package random
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestProvablyFairValues_GoldenVectors(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    int
+		serverSeed string
+		playerID   string
+		clientSeed string
+		nonce      int64
+		count      int
+		min        int
+		max        int
+		values     []int
+		hash       string
+	}{
+		{
+			name:       "Two dice",
+			version:    1,
+			serverSeed: "server-seed",
+			clientSeed: "client-seed",
+			nonce:      1,
+			count:      2,
+			min:        1,
+			max:        6,
+			values:     []int{6, 5},
+			hash:       "74ce0c3d0cb67d4e55e4d1ae60b691f5aac65dd947eef9a573a226bce70b4cf3",
+		},
+		{
+			name:       "Next nonce",
+			version:    1,
+			serverSeed: "server-seed",
+			clientSeed: "client-seed",
+			nonce:      2,
+			count:      2,
+			min:        1,
+			max:        6,
+			values:     []int{6, 5},
+			hash:       "c8ea6df5b7d4c116d4ed673d45bf6075dcc77025ef055d55480db0a524f37a61",
+		},
+		{
+			name:       "Empty client seed d20",
+			version:    1,
+			serverSeed: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			clientSeed: "",
+			nonce:      0,
+			count:      10,
+			min:        1,
+			max:        20,
+			values:     []int{8, 19, 4, 10, 12, 18, 8, 10, 18, 9},
+			hash:       "1762f78b324c003e73623c4bb1820c7ddc2bded3a4737a0184b77b130903c0b9",
+		},
+		{
+			name:       "Cursor extension past the first block",
+			version:    1,
+			serverSeed: "server-seed",
+			clientSeed: "client-seed",
+			nonce:      1,
+			count:      12,
+			min:        1,
+			max:        100,
+			values:     []int{6, 23, 47, 54, 74, 14, 89, 92, 76, 71, 62, 65},
+			hash:       "74ce0c3d0cb67d4e55e4d1ae60b691f5aac65dd947eef9a573a226bce70b4cf3",
+		},
+		{
+			name:       "HMAC-SHA256 two dice",
+			version:    2,
+			serverSeed: "server-seed",
+			clientSeed: "client-seed",
+			nonce:      1,
+			count:      2,
+			min:        1,
+			max:        6,
+			values:     []int{2, 3},
+			hash:       "aa236da731df39ccc141fec9ba3d5dde8564def0e4b5d5339ceb77be9625dbec",
+		},
+		{
+			name:       "HMAC-SHA256 cursor extension",
+			version:    2,
+			serverSeed: "server-seed",
+			clientSeed: "client-seed",
+			nonce:      1,
+			count:      20,
+			min:        1,
+			max:        100,
+			values:     []int{52, 9, 54, 3, 1, 44, 11, 33, 36, 46, 94, 44, 23, 93, 70, 2, 52, 1, 1, 16},
+			hash:       "aa236da731df39ccc141fec9ba3d5dde8564def0e4b5d5339ceb77be9625dbec",
+		},
+		{
+			name:       "HMAC-SHA512 two dice",
+			version:    3,
+			serverSeed: "server-seed",
+			clientSeed: "client-seed",
+			nonce:      1,
+			count:      2,
+			min:        1,
+			max:        6,
+			values:     []int{4, 5},
+			hash:       "38695dfd36e002baabb7b7ea954a0df31e2102306179d1a4373dbfd740d5e8de8205fe8ad9b70e2d01c3396f809f4cc975d53d611311bcfaad5ec38feeb1e516",
+		},
+		{
+			name:       "HMAC-SHA512 cursor extension",
+			version:    3,
+			serverSeed: "server-seed",
+			clientSeed: "client-seed",
+			nonce:      1,
+			count:      20,
+			min:        1,
+			max:        100,
+			values:     []int{38, 27, 7, 20, 29, 77, 88, 7, 23, 90, 40, 30, 54, 95, 16, 27, 91, 83, 62, 83},
+			hash:       "38695dfd36e002baabb7b7ea954a0df31e2102306179d1a4373dbfd740d5e8de8205fe8ad9b70e2d01c3396f809f4cc975d53d611311bcfaad5ec38feeb1e516",
+		},
+		{
+			name:       "HMAC-SHA512 bound to the player",
+			version:    4,
+			serverSeed: "server-seed",
+			playerID:   "player-1",
+			clientSeed: "client-seed",
+			nonce:      1,
+			count:      2,
+			min:        1,
+			max:        6,
+			values:     []int{4, 4},
+			hash:       "bbf6daf3ca1ff241ecfdd984a0fb609e147082d4414a4f939af23e2fd77ece74a0d897da708169177c9fc276f7e03adf9c859fe0facb2b9772f3bda8691c32e0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme, err := SchemeByVersion(tt.version)
+			assert.NoError(t, err)
+
+			values, hash, err := ProvablyFairValues(scheme, tt.serverSeed, tt.playerID, tt.clientSeed, tt.nonce, tt.count, tt.min, tt.max)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.values, values)
+			assert.Equal(t, tt.hash, hash)
+		})
+	}
+}
+
+func TestHashStream_RejectsBiasedWords(t *testing.T) {
+	// 2^32 mod 6 = 4, so the four largest words must be rejected.
+	blocks := [][]byte{
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfc},
+		{0x00, 0x00, 0x00, 0x0b},
+	}
+	var requested []int
+
+	stream := NewHashStream(func(cursor int) []byte {
+		requested = append(requested, cursor)
+		return blocks[cursor]
+	})
+
+	value, err := stream.Intn(1, 6)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1+11%6, value)
+	assert.Equal(t, []int{0, 1}, requested)
+}
+
+func TestHashStream_AcceptsLargestUnbiasedWord(t *testing.T) {
+	stream := NewHashStream(func(cursor int) []byte {
+		return []byte{0xff, 0xff, 0xff, 0xfb}
+	})
+
+	value, err := stream.Intn(1, 6)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1+0xfffffffb%6, value)
+}
+
+func TestHashStream_SkipsTrailingBytes(t *testing.T) {
+	blocks := [][]byte{
+		{0x00, 0x00, 0x00, 0x02, 0xaa, 0xbb},
+		{0x00, 0x00, 0x00, 0x03},
+	}
+
+	stream := NewHashStream(func(cursor int) []byte {
+		return blocks[cursor]
+	})
+
+	first, err := stream.Intn(0, 9)
+	assert.NoError(t, err)
+	second, err := stream.Intn(0, 9)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, first)
+	assert.Equal(t, 3, second)
+}
+
+func TestHashStream_InvalidRange(t *testing.T) {
+	stream := NewHashStream(func(cursor int) []byte {
+		return make([]byte, 32)
+	})
+
+	_, err := stream.Intn(6, 1)
+	assert.Error(t, err)
+
+	_, err = stream.Intn(0, 1<<31)
+	assert.Error(t, err)
+}
+
+func TestHashStream_Uniformity(t *testing.T) {
+	counts := make(map[int]int)
+	const iterations = 60000
+
+	for nonce := int64(0); nonce < iterations/6; nonce++ {
+		values, _, err := ProvablyFairValues(schemes[1], "server-seed", "", "client-seed", nonce, 6, 1, 6)
+		assert.NoError(t, err)
+		for _, v := range values {
+			counts[v]++
+		}
+	}
+
+	expected := iterations / 6
+	for face := 1; face <= 6; face++ {
+		assert.InDelta(t, expected, counts[face], float64(expected)*0.05)
+	}
+}
@@ -0,0 +1,112 @@
+package random
+
+import "math"
+
+// HealthAlpha is the false alarm probability of a single health test
+// evaluation: 2^-40, the strictest bound NIST SP 800-90B suggests. The tests
+// run after every observation, so it is kept far below the usual 0.001 to
+// make spurious quarantines practically impossible; a broken source still
+// fails within one window.
+const HealthAlpha = 1.0 / (1 << 40)
+
+// ChiSquare returns Pearson's goodness-of-fit statistic of counts against a
+// uniform distribution over len(counts) categories.
+func ChiSquare(counts []int) float64 {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 || len(counts) == 0 {
+		return 0
+	}
+
+	expected := float64(total) / float64(len(counts))
+	stat := 0.0
+	for _, c := range counts {
+		diff := float64(c) - expected
+		stat += diff * diff / expected
+	}
+
+	return stat
+}
+
+// ChiSquareCritical returns the value a chi-square statistic with df degrees
+// of freedom exceeds with probability alpha, using the Wilson-Hilferty
+// approximation.
+func ChiSquareCritical(df int, alpha float64) float64 {
+	if df < 1 {
+		return math.Inf(1)
+	}
+
+	k := float64(df)
+	z := normalQuantile(1 - alpha)
+	term := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+
+	return k * term * term * term
+}
+
+// RunsZ returns the Wald-Wolfowitz runs test z-score of values around the
+// midpoint of [min, max]: each value is high or low, values on the midpoint
+// are skipped, and the number of runs of equal classes is compared with its
+// expectation for a random sequence. It returns 0 when either class is
+// empty.
+func RunsZ(values []int, min, max int) float64 {
+	mid := float64(min+max) / 2
+
+	var n1, n2, runs int
+	prev := 0
+	for _, v := range values {
+		class := 0
+		switch {
+		case float64(v) > mid:
+			class = 1
+			n1++
+		case float64(v) < mid:
+			class = -1
+			n2++
+		default:
+			continue
+		}
+
+		if class != prev {
+			runs++
+			prev = class
+		}
+	}
+
+	if n1 == 0 || n2 == 0 {
+		return 0
+	}
+
+	a, b := float64(n1), float64(n2)
+	n := a + b
+	mean := 2*a*b/n + 1
+	variance := 2 * a * b * (2*a*b - n) / (n * n * (n - 1))
+	if variance <= 0 {
+		return 0
+	}
+
+	return (float64(runs) - mean) / math.Sqrt(variance)
+}
+
+// RunsCritical returns the two-sided z-score bound for the runs test.
+func RunsCritical(alpha float64) float64 {
+	return normalQuantile(1 - alpha/2)
+}
+
+// RepetitionCutoff is the repetition count test bound of NIST SP 800-90B,
+// section 4.4.1, for a source that should be uniform over categories values:
+// C = 1 + ceil(-log2(alpha) / H) with H = log2(categories). A run of C equal
+// values in a row fails the test.
+func RepetitionCutoff(categories int, alpha float64) int {
+	if categories < 2 {
+		return math.MaxInt
+	}
+
+	entropy := math.Log2(float64(categories))
+	return 1 + int(math.Ceil(-math.Log2(alpha)/entropy))
+}
+
+func normalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
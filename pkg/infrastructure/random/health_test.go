@@ -0,0 +1,39 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChiSquare(t *testing.T) {
+	assert.Equal(t, 0.0, ChiSquare([]int{10, 10, 10, 10, 10, 10}))
+	assert.InDelta(t, 120.0, ChiSquare([]int{40, 40, 40, 0, 0, 0}), 1e-9)
+	assert.Equal(t, 0.0, ChiSquare([]int{0, 0}))
+}
+
+func TestChiSquareCritical(t *testing.T) {
+	// Tabulated chi-square quantiles; Wilson-Hilferty is within a few percent.
+	assert.InDelta(t, 20.52, ChiSquareCritical(5, 0.001), 0.5)
+	assert.InDelta(t, 11.07, ChiSquareCritical(5, 0.05), 0.1)
+	assert.Greater(t, ChiSquareCritical(5, HealthAlpha), ChiSquareCritical(5, 0.001))
+}
+
+func TestRunsZ(t *testing.T) {
+	alternating := make([]int, 100)
+	blocks := make([]int, 100)
+	for i := range alternating {
+		alternating[i] = 1 + 5*(i%2)
+		blocks[i] = 1 + 5*(i/50)
+	}
+
+	assert.Greater(t, RunsZ(alternating, 1, 6), 9.0, "too many runs")
+	assert.Less(t, RunsZ(blocks, 1, 6), -9.0, "too few runs")
+	assert.Equal(t, 0.0, RunsZ([]int{1, 2, 3}, 1, 6), "one class only")
+}
+
+func TestRepetitionCutoff(t *testing.T) {
+	assert.Equal(t, 17, RepetitionCutoff(6, HealthAlpha))
+	assert.Equal(t, 41, RepetitionCutoff(2, HealthAlpha))
+	assert.InDelta(t, 3.29, RunsCritical(0.001), 0.01)
+}
@@ -0,0 +1,564 @@
+package random
+
+import (
+	pb "dice-game/proto/gen"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultPluginTimeout        = 2 * time.Second
+	DefaultPluginStartTimeout   = 5 * time.Second
+	DefaultPluginHealthInterval = 10 * time.Second
+
+	// PluginSocketEnv tells a plugin started in socket mode where to listen.
+	PluginSocketEnv = "DICE_PLUGIN_SOCKET"
+
+	pluginMaxMessageSize    = 1 << 20
+	pluginMinRestartBackoff = 100 * time.Millisecond
+	pluginMaxRestartBackoff = 30 * time.Second
+	pluginStopGracePeriod   = time.Second
+)
+
+var ErrPluginClosed = errors.New("plugin generator is closed")
+
+// PluginConfig describes an external generator process.
+type PluginConfig struct {
+	// Name is the generator name; it must differ from every other
+	// generator.
+	Name    string
+	Command string
+	Args    []string
+	// Env is added to the server's environment for the plugin.
+	Env []string
+	// Socket, when set, is the Unix socket the plugin listens on instead of
+	// talking over stdin/stdout. The plugin finds the path in
+	// PluginSocketEnv. Without Command the plugin is managed outside the
+	// server and is only dialled.
+	Socket string
+	// Timeout bounds a single request, StartTimeout the start of the
+	// process up to its first health check.
+	Timeout        time.Duration
+	StartTimeout   time.Duration
+	HealthInterval time.Duration
+	// Stderr receives the plugin's stderr, and its stdout in socket mode;
+	// os.Stderr when nil.
+	Stderr io.Writer
+	// OnFailure, when set, is called whenever the plugin fails and is
+	// stopped, or fails to restart.
+	OnFailure func(err error)
+}
+
+// PluginGenerator rolls with an external process, such as a certified
+// hardware RNG library that cannot be linked into the server. Requests are
+// length-prefixed PluginRequest and PluginResponse protobuf messages, one
+// at a time. A plugin that crashes, times out or breaks the protocol is
+// stopped and started again on the next request or health check, with
+// exponential backoff between failed starts. Values are checked against
+// the requested count and range, so a faulty plugin cannot produce
+// impossible games.
+type PluginGenerator struct {
+	cfg PluginConfig
+
+	mu       sync.Mutex
+	cmd      *exec.Cmd
+	exited   chan struct{}
+	conn     pluginConn
+	nextID   uint64
+	healthy  bool
+	restarts int
+	failures int
+	retryAt  time.Time
+	closed   bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type pluginConn interface {
+	io.ReadWriteCloser
+	SetDeadline(t time.Time) error
+}
+
+func NewPluginGenerator(cfg PluginConfig) *PluginGenerator {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultPluginTimeout
+	}
+	if cfg.StartTimeout <= 0 {
+		cfg.StartTimeout = DefaultPluginStartTimeout
+	}
+	if cfg.HealthInterval <= 0 {
+		cfg.HealthInterval = DefaultPluginHealthInterval
+	}
+	if cfg.Stderr == nil {
+		cfg.Stderr = os.Stderr
+	}
+
+	return &PluginGenerator{
+		cfg:  cfg,
+		stop: make(chan struct{}),
+	}
+}
+
+// Start launches the plugin and checks its health every HealthInterval
+// until Close, restarting it when it has crashed.
+func (g *PluginGenerator) Start() error {
+	if err := g.CheckHealth(); err != nil {
+		return err
+	}
+
+	go g.runHealthChecks()
+	return nil
+}
+
+// Close stops the plugin and its health checks.
+func (g *PluginGenerator) Close() error {
+	g.stopOnce.Do(func() { close(g.stop) })
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.closed = true
+	g.shutdown(pluginStopGracePeriod)
+	return nil
+}
+
+func (g *PluginGenerator) Generate(min, max int) (int, error) {
+	roll, err := g.Roll(RollRequest{Count: 1, Min: min, Max: max})
+	if err != nil {
+		return 0, err
+	}
+
+	return roll.Values[0], nil
+}
+
+func (g *PluginGenerator) Roll(req RollRequest) (*Roll, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	min, max := req.Min, req.Max
+	if min > max {
+		min, max = max, min
+	}
+
+	resp, err := g.call(&pb.PluginRequest{
+		Method: pb.PluginMethod_PLUGIN_METHOD_ROLL,
+		Count:  int32(req.Count),
+		Min:    int64(min),
+		Max:    int64(max),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Values) != req.Count {
+		return nil, fmt.Errorf("plugin %s returned %d values, want %d", g.cfg.Name, len(resp.Values), req.Count)
+	}
+
+	values := make([]int, req.Count)
+	for i, value := range resp.Values {
+		if value < int64(min) || value > int64(max) {
+			return nil, fmt.Errorf("plugin %s returned %d outside [%d, %d]", g.cfg.Name, value, min, max)
+		}
+		values[i] = int(value)
+	}
+
+	roll := &Roll{Values: values}
+	if req.RecordEntropy {
+		// The plugin hands over finished values; they are the raw
+		// material the server consumed.
+		roll.Entropy = make([]byte, 8*len(resp.Values))
+		for i, value := range resp.Values {
+			binary.BigEndian.PutUint64(roll.Entropy[8*i:], uint64(value))
+		}
+		roll.EntropyMapping = EntropyValues
+	}
+
+	return roll, nil
+}
+
+func (g *PluginGenerator) Name() string {
+	return g.cfg.Name
+}
+
+// CheckHealth asks the plugin whether it can roll, starting it first if it
+// is not running.
+func (g *PluginGenerator) CheckHealth() error {
+	_, err := g.call(&pb.PluginRequest{Method: pb.PluginMethod_PLUGIN_METHOD_HEALTH})
+	return err
+}
+
+// Healthy reports whether the last request to the plugin succeeded.
+func (g *PluginGenerator) Healthy() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.healthy
+}
+
+// Restarts is the number of times the plugin was started again after a
+// failure.
+func (g *PluginGenerator) Restarts() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.restarts
+}
+
+func (g *PluginGenerator) runHealthChecks() {
+	ticker := time.NewTicker(g.cfg.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stop:
+			return
+		case <-ticker.C:
+			// Failures are reported through OnFailure.
+			_ = g.CheckHealth()
+		}
+	}
+}
+
+// call sends req and returns the plugin's response. Transport failures
+// stop the plugin; an error reported by the plugin itself does not.
+func (g *PluginGenerator) call(req *pb.PluginRequest) (*pb.PluginResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return nil, ErrPluginClosed
+	}
+
+	if g.conn != nil && g.hasExited() {
+		g.fail(fmt.Errorf("plugin %s exited", g.cfg.Name))
+	}
+	if g.conn == nil {
+		if err := g.launch(); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := g.roundTrip(req, g.cfg.Timeout)
+	if err != nil {
+		err = fmt.Errorf("plugin %s failed: %w", g.cfg.Name, err)
+		g.fail(err)
+		return nil, err
+	}
+
+	g.healthy = true
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", g.cfg.Name, resp.Error)
+	}
+
+	return resp, nil
+}
+
+// launch starts the plugin and waits for its first health check, unless a
+// failed start is still backing off.
+func (g *PluginGenerator) launch() error {
+	if wait := time.Until(g.retryAt); wait > 0 {
+		return fmt.Errorf("plugin %s is down, next start in %s", g.cfg.Name, wait.Round(time.Millisecond))
+	}
+
+	if g.failures > 0 {
+		g.restarts++
+	}
+
+	err := g.connect()
+	if err == nil {
+		_, err = g.roundTrip(&pb.PluginRequest{Method: pb.PluginMethod_PLUGIN_METHOD_HEALTH}, g.cfg.StartTimeout)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to start plugin %s: %w", g.cfg.Name, err)
+		g.fail(err)
+		return err
+	}
+
+	g.failures = 0
+	g.retryAt = time.Time{}
+	return nil
+}
+
+func (g *PluginGenerator) connect() error {
+	if g.cfg.Socket == "" {
+		return g.startStdio()
+	}
+
+	if g.cfg.Command != "" {
+		if err := os.Remove(g.cfg.Socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		cmd := g.command()
+		cmd.Env = append(cmd.Env, PluginSocketEnv+"="+g.cfg.Socket)
+		cmd.Stdout = g.cfg.Stderr
+		if err := g.startProcess(cmd); err != nil {
+			return err
+		}
+	}
+
+	return g.dial()
+}
+
+func (g *PluginGenerator) startStdio() error {
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		stdinR.Close()
+		stdinW.Close()
+		return err
+	}
+
+	cmd := g.command()
+	cmd.Stdin = stdinR
+	cmd.Stdout = stdoutW
+	err = g.startProcess(cmd)
+
+	// The plugin holds its own copies of these ends.
+	stdinR.Close()
+	stdoutW.Close()
+
+	if err != nil {
+		stdinW.Close()
+		stdoutR.Close()
+		return err
+	}
+
+	g.conn = &stdioConn{r: stdoutR, w: stdinW}
+	return nil
+}
+
+// dial connects to the plugin's socket, retrying while the plugin starts
+// listening.
+func (g *PluginGenerator) dial() error {
+	deadline := time.Now().Add(g.cfg.StartTimeout)
+	for {
+		conn, err := net.DialTimeout("unix", g.cfg.Socket, time.Until(deadline))
+		if err == nil {
+			g.conn = conn
+			return nil
+		}
+
+		if g.cmd != nil && g.hasExited() {
+			return fmt.Errorf("plugin exited before listening on %s", g.cfg.Socket)
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func (g *PluginGenerator) command() *exec.Cmd {
+	cmd := exec.Command(g.cfg.Command, g.cfg.Args...)
+	cmd.Env = append(os.Environ(), g.cfg.Env...)
+	cmd.Stderr = g.cfg.Stderr
+	return cmd
+}
+
+func (g *PluginGenerator) startProcess(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(exited)
+	}()
+
+	g.cmd = cmd
+	g.exited = exited
+	return nil
+}
+
+func (g *PluginGenerator) hasExited() bool {
+	if g.exited == nil {
+		return false
+	}
+
+	select {
+	case <-g.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// fail stops the plugin after err and delays its next start.
+func (g *PluginGenerator) fail(err error) {
+	g.shutdown(0)
+	g.healthy = false
+
+	// A single crash is restarted at once; a plugin that keeps failing is
+	// retried less and less often.
+	var backoff time.Duration
+	switch {
+	case g.failures == 0:
+	case g.failures < 16:
+		backoff = min(pluginMinRestartBackoff<<(g.failures-1), pluginMaxRestartBackoff)
+	default:
+		backoff = pluginMaxRestartBackoff
+	}
+	g.failures++
+	g.retryAt = time.Now().Add(backoff)
+
+	if g.cfg.OnFailure != nil {
+		g.cfg.OnFailure(err)
+	}
+}
+
+// shutdown closes the connection and asks the plugin to exit: a plugin on
+// stdin/stdout sees EOF, a plugin on a socket gets SIGTERM. It is killed if
+// it does not exit within grace.
+func (g *PluginGenerator) shutdown(grace time.Duration) {
+	if g.conn != nil {
+		g.conn.Close()
+		g.conn = nil
+	}
+
+	if g.cmd != nil {
+		if g.cfg.Socket != "" {
+			g.cmd.Process.Signal(syscall.SIGTERM)
+		}
+
+		select {
+		case <-g.exited:
+		case <-time.After(grace):
+			g.cmd.Process.Kill()
+			<-g.exited
+		}
+		g.cmd = nil
+		g.exited = nil
+	}
+}
+
+func (g *PluginGenerator) roundTrip(req *pb.PluginRequest, timeout time.Duration) (*pb.PluginResponse, error) {
+	g.nextID++
+	req.Id = g.nextID
+
+	if err := g.conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if err := writePluginMessage(g.conn, req); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.PluginResponse{}
+	if err := readPluginMessage(g.conn, resp); err != nil {
+		return nil, err
+	}
+	if resp.Id != req.Id {
+		return nil, fmt.Errorf("response %d does not answer request %d", resp.Id, req.Id)
+	}
+
+	return resp, nil
+}
+
+// stdioConn talks to a plugin over its stdin and stdout pipes.
+type stdioConn struct {
+	r *os.File
+	w *os.File
+}
+
+func (c *stdioConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+func (c *stdioConn) Write(p []byte) (int, error) {
+	return c.w.Write(p)
+}
+
+func (c *stdioConn) Close() error {
+	return errors.Join(c.w.Close(), c.r.Close())
+}
+
+func (c *stdioConn) SetDeadline(t time.Time) error {
+	return errors.Join(c.w.SetDeadline(t), c.r.SetDeadline(t))
+}
+
+// ServePlugin implements the plugin side of the protocol on rw for plugins
+// written in Go: it answers health checks and rolls values with roll until
+// rw is closed.
+func ServePlugin(rw io.ReadWriter, roll func(count, min, max int) ([]int, error)) error {
+	for {
+		req := &pb.PluginRequest{}
+		if err := readPluginMessage(rw, req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		resp := &pb.PluginResponse{Id: req.Id}
+		switch req.Method {
+		case pb.PluginMethod_PLUGIN_METHOD_HEALTH:
+		case pb.PluginMethod_PLUGIN_METHOD_ROLL:
+			values, err := roll(int(req.Count), int(req.Min), int(req.Max))
+			if err != nil {
+				resp.Error = err.Error()
+				break
+			}
+			for _, value := range values {
+				resp.Values = append(resp.Values, int64(value))
+			}
+		default:
+			resp.Error = fmt.Sprintf("unknown method %s", req.Method)
+		}
+
+		if err := writePluginMessage(rw, resp); err != nil {
+			return err
+		}
+	}
+}
+
+func writePluginMessage(w io.Writer, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(body) > pluginMaxMessageSize {
+		return fmt.Errorf("plugin message of %d bytes exceeds %d", len(body), pluginMaxMessageSize)
+	}
+
+	frame := make([]byte, 4+len(body))
+	binary.BigEndian.PutUint32(frame, uint32(len(body)))
+	copy(frame[4:], body)
+
+	_, err = w.Write(frame)
+	return err
+}
+
+func readPluginMessage(r io.Reader, msg proto.Message) error {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > pluginMaxMessageSize {
+		return fmt.Errorf("plugin message of %d bytes exceeds %d", size, pluginMaxMessageSize)
+	}
+
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(body, msg)
+}
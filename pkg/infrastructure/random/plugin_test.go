@@ -0,0 +1,224 @@
+package random
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pluginHelperEnv = "DICE_TEST_PLUGIN_MODE"
+
+// TestPluginHelperProcess is not a real test: the plugin tests start the
+// test binary again with pluginHelperEnv set to act as a plugin.
+func TestPluginHelperProcess(t *testing.T) {
+	mode := os.Getenv(pluginHelperEnv)
+	if mode == "" {
+		t.Skip("only runs as a plugin helper process")
+	}
+
+	roll := func(count, min, max int) ([]int, error) {
+		switch mode {
+		case "crash":
+			os.Exit(3)
+		case "hang":
+			select {}
+		case "out_of_range":
+			return []int{max + 1}, nil
+		case "error":
+			return nil, errors.New("device busy")
+		}
+
+		values := make([]int, count)
+		for i := range values {
+			values[i] = min + i%(max-min+1)
+		}
+		return values, nil
+	}
+
+	var err error
+	if socket := os.Getenv(PluginSocketEnv); socket != "" {
+		err = servePluginSocket(socket, roll)
+	} else {
+		err = ServePlugin(&stdio{}, roll)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+type stdio struct{}
+
+func (stdio) Read(p []byte) (int, error)  { return os.Stdin.Read(p) }
+func (stdio) Write(p []byte) (int, error) { return os.Stdout.Write(p) }
+
+func servePluginSocket(socket string, roll func(count, min, max int) ([]int, error)) error {
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		err = ServePlugin(conn, roll)
+		conn.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func newTestPlugin(t *testing.T, mode string, cfg PluginConfig) *PluginGenerator {
+	cfg.Name = "hardware"
+	cfg.Command = os.Args[0]
+	cfg.Args = []string{"-test.run=^TestPluginHelperProcess$"}
+	cfg.Env = []string{pluginHelperEnv + "=" + mode}
+
+	plugin := NewPluginGenerator(cfg)
+	t.Cleanup(func() { plugin.Close() })
+
+	return plugin
+}
+
+func TestPluginGenerator_Stdio(t *testing.T) {
+	// Arrange
+	plugin := newTestPlugin(t, "roll", PluginConfig{})
+	require.NoError(t, plugin.Start())
+
+	// Act
+	roll, err := plugin.Roll(RollRequest{Count: 4, Min: 1, Max: 6})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, roll.Values)
+	assert.Empty(t, roll.Proof)
+	assert.Equal(t, "hardware", plugin.Name())
+	assert.True(t, plugin.Healthy())
+}
+
+func TestPluginGenerator_RecordsValuesAsEntropy(t *testing.T) {
+	// Arrange
+	plugin := newTestPlugin(t, "roll", PluginConfig{})
+	require.NoError(t, plugin.Start())
+
+	// Act
+	roll, err := plugin.Roll(RollRequest{Count: 3, Min: 1, Max: 6, RecordEntropy: true})
+	require.NoError(t, err)
+	values, mapErr := ValuesFromEntropy(roll.EntropyMapping, roll.Entropy, 3, 1, 6)
+
+	// Assert
+	require.NoError(t, mapErr)
+	assert.Equal(t, EntropyValues, roll.EntropyMapping)
+	assert.Equal(t, roll.Values, values)
+}
+
+func TestPluginGenerator_Socket(t *testing.T) {
+	// Arrange
+	socket := filepath.Join(t.TempDir(), "plugin.sock")
+	plugin := newTestPlugin(t, "roll", PluginConfig{Socket: socket})
+	require.NoError(t, plugin.Start())
+
+	// Act
+	value, err := plugin.Generate(6, 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+}
+
+func TestPluginGenerator_RestartsAfterCrash(t *testing.T) {
+	// Arrange
+	var failures []error
+	plugin := newTestPlugin(t, "crash", PluginConfig{
+		OnFailure: func(err error) { failures = append(failures, err) },
+	})
+	require.NoError(t, plugin.Start())
+
+	// Act
+	_, firstErr := plugin.Roll(RollRequest{Count: 1, Min: 1, Max: 6})
+	healthErr := plugin.CheckHealth()
+
+	// Assert
+	assert.Error(t, firstErr)
+	assert.NoError(t, healthErr)
+	assert.Equal(t, 1, plugin.Restarts())
+	assert.Len(t, failures, 1)
+	assert.True(t, plugin.Healthy())
+}
+
+func TestPluginGenerator_Timeout(t *testing.T) {
+	// Arrange
+	plugin := newTestPlugin(t, "hang", PluginConfig{Timeout: 50 * time.Millisecond})
+	require.NoError(t, plugin.Start())
+
+	// Act
+	start := time.Now()
+	_, err := plugin.Roll(RollRequest{Count: 1, Min: 1, Max: 6})
+
+	// Assert
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.False(t, plugin.Healthy())
+}
+
+func TestPluginGenerator_RejectsOutOfRangeValues(t *testing.T) {
+	// Arrange
+	plugin := newTestPlugin(t, "out_of_range", PluginConfig{})
+	require.NoError(t, plugin.Start())
+
+	// Act
+	_, err := plugin.Roll(RollRequest{Count: 1, Min: 1, Max: 6})
+
+	// Assert
+	assert.ErrorContains(t, err, "outside [1, 6]")
+}
+
+func TestPluginGenerator_PluginError(t *testing.T) {
+	// Arrange
+	plugin := newTestPlugin(t, "error", PluginConfig{})
+	require.NoError(t, plugin.Start())
+
+	// Act
+	_, err := plugin.Roll(RollRequest{Count: 1, Min: 1, Max: 6})
+
+	// Assert
+	assert.ErrorContains(t, err, "device busy")
+	assert.True(t, plugin.Healthy(), "a plugin reporting an error is still running")
+	assert.Equal(t, 0, plugin.Restarts())
+}
+
+func TestPluginGenerator_StartFails(t *testing.T) {
+	// Arrange
+	plugin := NewPluginGenerator(PluginConfig{Name: "hardware", Command: filepath.Join(t.TempDir(), "missing")})
+	defer plugin.Close()
+
+	// Act
+	err := plugin.Start()
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to start plugin hardware")
+	assert.False(t, plugin.Healthy())
+}
+
+func TestPluginGenerator_Closed(t *testing.T) {
+	// Arrange
+	plugin := newTestPlugin(t, "roll", PluginConfig{})
+	require.NoError(t, plugin.Start())
+	require.NoError(t, plugin.Close())
+
+	// Act
+	_, err := plugin.Roll(RollRequest{Count: 1, Min: 1, Max: 6})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrPluginClosed)
+}
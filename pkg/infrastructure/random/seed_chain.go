@@ -0,0 +1,70 @@
+package random
+
+import "fmt"
+
+// GenerateSeedChain builds a reverse hash chain of length server seeds. The
+// seeds are returned in the order games consume them: the first seed hashes
+// to the returned terminal hash and every later seed hashes to the one
+// before it, so publishing the terminal hash commits to the whole chain.
+func GenerateSeedChain(length int) ([]string, string, error) {
+	if length < 1 {
+		return nil, "", fmt.Errorf("seed chain length must be positive, got %d", length)
+	}
+
+	last, err := NewServerSeed()
+	if err != nil {
+		return nil, "", err
+	}
+
+	seeds := make([]string, length)
+	seeds[length-1] = last
+	for i := length - 2; i >= 0; i-- {
+		seeds[i] = HashServerSeed(seeds[i+1])
+	}
+
+	return seeds, HashServerSeed(seeds[0]), nil
+}
+
+// WalkSeedChain hashes seed steps times. The seed at 1-based position p of a
+// chain walks to the chain's terminal hash in p steps.
+func WalkSeedChain(seed string, steps int) string {
+	for i := 0; i < steps; i++ {
+		seed = HashServerSeed(seed)
+	}
+	return seed
+}
+
+// HashChainGenerator rolls provably fair games whose server seed is a link
+// of a pre-generated seed chain. Each game needs its own link, so rolls go
+// through RollWithServerSeed; Generate and Roll fail without one.
+type HashChainGenerator struct {
+	scheme Scheme
+}
+
+func NewHashChainGenerator(scheme Scheme) *HashChainGenerator {
+	return &HashChainGenerator{scheme: scheme}
+}
+
+func (g *HashChainGenerator) Generate(min, max int) (int, error) {
+	return 0, fmt.Errorf("hash chain generator needs a chain link for every roll")
+}
+
+func (g *HashChainGenerator) Roll(req RollRequest) (*Roll, error) {
+	return nil, fmt.Errorf("hash chain generator needs a chain link for every roll")
+}
+
+func (g *HashChainGenerator) RollWithServerSeed(serverSeed string, req RollRequest) (*Roll, error) {
+	return provablyFairRoll(g.scheme, serverSeed, req)
+}
+
+func (g *HashChainGenerator) Name() string {
+	return "hash_chain"
+}
+
+func (g *HashChainGenerator) Algorithm() string {
+	return g.scheme.Name()
+}
+
+func (g *HashChainGenerator) AlgorithmVersion() int {
+	return g.scheme.Version()
+}
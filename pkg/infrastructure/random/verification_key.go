@@ -0,0 +1,50 @@
+package random
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VerificationKey is the proof published with a provably fair roll, encoded
+// as "v<version>:seedHash:nonce:hash". Keys issued before versioning have no
+// version prefix and are read as version 1.
+type VerificationKey struct {
+	Version        int
+	ServerSeedHash string
+	Nonce          int64
+	Hash           string
+}
+
+func (k VerificationKey) String() string {
+	return fmt.Sprintf("v%d:%s:%d:%s", k.Version, k.ServerSeedHash, k.Nonce, k.Hash)
+}
+
+func ParseVerificationKey(key string) (*VerificationKey, error) {
+	parts := strings.Split(key, ":")
+
+	version := 1
+	switch {
+	case len(parts) == 4 && strings.HasPrefix(parts[0], "v"):
+		v, err := strconv.Atoi(parts[0][1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in verification data: %w", err)
+		}
+		version = v
+		parts = parts[1:]
+	case len(parts) != 3:
+		return nil, fmt.Errorf("invalid verification data format")
+	}
+
+	nonce, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce in verification data: %w", err)
+	}
+
+	return &VerificationKey{
+		Version:        version,
+		ServerSeedHash: parts[0],
+		Nonce:          nonce,
+		Hash:           parts[2],
+	}, nil
+}
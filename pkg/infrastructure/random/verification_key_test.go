@@ -0,0 +1,65 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationKey_String(t *testing.T) {
+	key := VerificationKey{Version: 2, ServerSeedHash: "abc", Nonce: 42, Hash: "def"}
+
+	parsed, err := ParseVerificationKey(key.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "v2:abc:42:def", key.String())
+	assert.Equal(t, &key, parsed)
+}
+
+func TestParseVerificationKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected *VerificationKey
+		wantErr  bool
+	}{
+		{
+			name:     "Versioned key",
+			key:      "v3:abc:42:def",
+			expected: &VerificationKey{Version: 3, ServerSeedHash: "abc", Nonce: 42, Hash: "def"},
+		},
+		{
+			name:     "Legacy key is version 1",
+			key:      "abc:42:def",
+			expected: &VerificationKey{Version: 1, ServerSeedHash: "abc", Nonce: 42, Hash: "def"},
+		},
+		{
+			name:    "Invalid version",
+			key:     "vx:abc:42:def",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid nonce",
+			key:     "v2:abc:x:def",
+			wantErr: true,
+		},
+		{
+			name:    "Wrong number of parts",
+			key:     "abc:def",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseVerificationKey(tt.key)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, key)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package random
+
+import (
+	"crypto/sha512"
+	"dice-game/pkg/infrastructure/vrf"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// VRFGenerator proves every roll with ECVRF-EDWARDS25519-SHA512-TAI. The
+// VRF input is "len(playerID):playerID:clientSeed:nonce", so players sending
+// the same client seed at the same nonce get different dice under the one
+// long-lived key; proofs of version 1 were made for "clientSeed:nonce" and
+// still verify. The dice are drawn from a HashStream
+// whose block 0 is the VRF output beta and block k > 0 is
+// SHA-512(beta || k) with k as a big-endian uint64. The proof can be checked
+// against the server's public key as soon as the game is played; there is no
+// seed to reveal.
+type VRFGenerator struct {
+	key *vrf.PrivateKey
+
+	mu    sync.Mutex
+	nonce int64
+}
+
+func NewVRFGenerator(seed []byte) (*VRFGenerator, error) {
+	key, err := vrf.NewKeyFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VRFGenerator{key: key}, nil
+}
+
+// Generate rolls with an empty client seed and the generator's own nonce.
+// Games use Roll so the player's seed and nonce are bound in.
+func (g *VRFGenerator) Generate(min, max int) (int, error) {
+	g.mu.Lock()
+	g.nonce++
+	nonce := g.nonce
+	g.mu.Unlock()
+
+	roll, err := g.Roll(RollRequest{Nonce: nonce, Count: 1, Min: min, Max: max})
+	if err != nil {
+		return 0, err
+	}
+
+	return roll.Values[0], nil
+}
+
+// Roll returns the values together with a VRFProof.
+func (g *VRFGenerator) Roll(req RollRequest) (*Roll, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	pi, err := g.key.Prove(vrfInput(LatestVRFProofVersion, req.PlayerID, req.ClientSeed, req.Nonce))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove vrf roll: %w", err)
+	}
+
+	beta, err := vrf.ProofToHash(pi)
+	if err != nil {
+		return nil, err
+	}
+
+	roll, err := streamRoll(req, vrfStream(beta))
+	if err != nil {
+		return nil, err
+	}
+
+	proof := VRFProof{
+		Version:   LatestVRFProofVersion,
+		PublicKey: hex.EncodeToString(g.key.Public()),
+		Nonce:     req.Nonce,
+		Proof:     hex.EncodeToString(pi),
+	}
+	roll.Proof = proof.String()
+
+	return roll, nil
+}
+
+func (g *VRFGenerator) Name() string {
+	return "vrf"
+}
+
+func (g *VRFGenerator) Algorithm() string {
+	return "ECVRF-EDWARDS25519-SHA512-TAI"
+}
+
+// AlgorithmVersion is 0: VRF games are not rolled with a versioned provably
+// fair scheme.
+func (g *VRFGenerator) AlgorithmVersion() int {
+	return 0
+}
+
+// PublicKey returns the encoded public key rolls are verified against.
+func (g *VRFGenerator) PublicKey() []byte {
+	return g.key.Public()
+}
+
+// LatestVRFProofVersion is the version of the VRF input new rolls are
+// proved for. Version 1 left the player out of the input.
+const LatestVRFProofVersion = 2
+
+// VRFProof is the proof published with a VRF roll, encoded as
+// "vrf:v2:publicKey:nonce:pi" with the key and pi in hex. Version 1 proofs
+// have no version part.
+type VRFProof struct {
+	Version   int
+	PublicKey string
+	Nonce     int64
+	Proof     string
+}
+
+func (p VRFProof) String() string {
+	if p.Version <= 1 {
+		return fmt.Sprintf("vrf:%s:%d:%s", p.PublicKey, p.Nonce, p.Proof)
+	}
+	return fmt.Sprintf("vrf:v%d:%s:%d:%s", p.Version, p.PublicKey, p.Nonce, p.Proof)
+}
+
+func ParseVRFProof(s string) (*VRFProof, error) {
+	parts := strings.Split(s, ":")
+
+	version := 1
+	if len(parts) == 5 && strings.HasPrefix(parts[1], "v") {
+		v, err := strconv.Atoi(parts[1][1:])
+		if err != nil || v < 2 || v > LatestVRFProofVersion {
+			return nil, fmt.Errorf("unsupported vrf proof version %q", parts[1])
+		}
+		version = v
+		parts = append(parts[:1], parts[2:]...)
+	}
+
+	if len(parts) != 4 || parts[0] != "vrf" {
+		return nil, fmt.Errorf("invalid vrf proof format")
+	}
+
+	nonce, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce in vrf proof: %w", err)
+	}
+
+	return &VRFProof{
+		Version:   version,
+		PublicKey: parts[1],
+		Nonce:     nonce,
+		Proof:     parts[3],
+	}, nil
+}
+
+// VerifyVRFValues checks the VRF proof of a game against the public key and
+// returns the count values in [min, max] it commits to. playerID is only
+// part of the input of version 2 proofs and later.
+func VerifyVRFValues(proof *VRFProof, playerID, clientSeed string, count, min, max int) ([]int, error) {
+	publicKey, err := hex.DecodeString(proof.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key in vrf proof: %w", err)
+	}
+
+	pi, err := hex.DecodeString(proof.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof in vrf proof: %w", err)
+	}
+
+	beta, err := vrf.Verify(publicKey, vrfInput(proof.Version, playerID, clientSeed, proof.Nonce), pi)
+	if err != nil {
+		return nil, err
+	}
+
+	return vrfValues(beta, count, min, max)
+}
+
+// vrfInput is alpha for a roll. From version 2 the player is prefixed with
+// its length, as the player-bound hash schemes do.
+func vrfInput(version int, playerID, clientSeed string, nonce int64) []byte {
+	if version <= 1 {
+		return []byte(fmt.Sprintf("%s:%d", clientSeed, nonce))
+	}
+	return []byte(fmt.Sprintf("%d:%s:%s:%d", len(playerID), playerID, clientSeed, nonce))
+}
+
+func vrfValues(beta []byte, count, min, max int) ([]int, error) {
+	return vrfStream(beta).Values(count, min, max)
+}
+
+func vrfStream(beta []byte) *HashStream {
+	return NewHashStream(func(cursor int) []byte {
+		if cursor == 0 {
+			return beta
+		}
+
+		var counter [8]byte
+		binary.BigEndian.PutUint64(counter[:], uint64(cursor))
+
+		digest := sha512.New()
+		digest.Write(beta)
+		digest.Write(counter[:])
+		return digest.Sum(nil)
+	})
+}
@@ -0,0 +1,121 @@
+package random
+
+import (
+	"crypto/ed25519"
+	"dice-game/pkg/infrastructure/vrf"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testVRFSeed = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewVRFGenerator(t *testing.T) {
+	g, err := NewVRFGenerator(testVRFSeed)
+	require.NoError(t, err)
+
+	assert.Equal(t, "vrf", g.Name())
+	assert.Equal(t, []byte(ed25519.NewKeyFromSeed(testVRFSeed).Public().(ed25519.PublicKey)), g.PublicKey())
+
+	_, err = NewVRFGenerator([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestVRFGenerator_Roll(t *testing.T) {
+	g, err := NewVRFGenerator(testVRFSeed)
+	require.NoError(t, err)
+
+	roll, err := g.Roll(RollRequest{PlayerID: "player-1", ClientSeed: "client", Nonce: 7, Count: 2, Min: 1, Max: 6})
+	require.NoError(t, err)
+	require.Len(t, roll.Values, 2)
+	assert.Zero(t, roll.AlgorithmVersion)
+
+	proof, err := ParseVRFProof(roll.Proof)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(g.PublicKey()), proof.PublicKey)
+	assert.Equal(t, int64(7), proof.Nonce)
+	assert.Equal(t, LatestVRFProofVersion, proof.Version)
+	assert.Equal(t, roll.Proof, proof.String())
+
+	values, err := VerifyVRFValues(proof, "player-1", "client", 2, 1, 6)
+	require.NoError(t, err)
+	assert.Equal(t, roll.Values, values)
+
+	again, err := g.Roll(RollRequest{PlayerID: "player-1", ClientSeed: "client", Nonce: 7, Count: 2, Min: 1, Max: 6})
+	require.NoError(t, err)
+	assert.Equal(t, roll, again, "a VRF roll is deterministic")
+
+	_, err = VerifyVRFValues(proof, "player-1", "other-client", 2, 1, 6)
+	assert.ErrorIs(t, err, vrf.ErrInvalidProof)
+
+	_, err = VerifyVRFValues(proof, "player-2", "client", 2, 1, 6)
+	assert.ErrorIs(t, err, vrf.ErrInvalidProof)
+
+	proof.Nonce = 8
+	_, err = VerifyVRFValues(proof, "player-1", "client", 2, 1, 6)
+	assert.ErrorIs(t, err, vrf.ErrInvalidProof)
+}
+
+func TestVRFGenerator_RollBindsPlayer(t *testing.T) {
+	g, err := NewVRFGenerator(testVRFSeed)
+	require.NoError(t, err)
+
+	alice, err := g.Roll(RollRequest{PlayerID: "alice", ClientSeed: "client", Nonce: 1, Count: 10, Min: 1, Max: 6})
+	require.NoError(t, err)
+	bob, err := g.Roll(RollRequest{PlayerID: "bob", ClientSeed: "client", Nonce: 1, Count: 10, Min: 1, Max: 6})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, alice.Values, bob.Values)
+	assert.NotEqual(t, alice.Proof, bob.Proof)
+}
+
+func TestVerifyVRFValues_Version1(t *testing.T) {
+	key, err := vrf.NewKeyFromSeed(testVRFSeed)
+	require.NoError(t, err)
+
+	pi, err := key.Prove([]byte("client:7"))
+	require.NoError(t, err)
+	encoded := VRFProof{Version: 1, PublicKey: hex.EncodeToString(key.Public()), Nonce: 7, Proof: hex.EncodeToString(pi)}.String()
+
+	proof, err := ParseVRFProof(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, 1, proof.Version)
+	assert.Equal(t, "vrf:", encoded[:4])
+	assert.Len(t, strings.Split(encoded, ":"), 4)
+
+	// The player was not part of version 1 inputs.
+	values, err := VerifyVRFValues(proof, "any-player", "client", 2, 1, 6)
+	require.NoError(t, err)
+	assert.Len(t, values, 2)
+}
+
+func TestVRFGenerator_Generate(t *testing.T) {
+	g, err := NewVRFGenerator(testVRFSeed)
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		val, err := g.Generate(1, 6)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, val, 1)
+		assert.LessOrEqual(t, val, 6)
+	}
+}
+
+func TestVRFValues_ExtendsBeyondBeta(t *testing.T) {
+	beta := make([]byte, vrf.OutputSize)
+
+	// A 64-byte beta holds 16 words, so 40 values need extra blocks.
+	values, err := vrfValues(beta, 40, 1, 6)
+	require.NoError(t, err)
+	assert.Len(t, values, 40)
+}
+
+func TestParseVRFProof_Invalid(t *testing.T) {
+	for _, s := range []string{"", "vrf:key:1", "v3:hash:1:hash", "vrf:key:nonce:pi", "vrf:v1:key:1:pi", "vrf:v9:key:1:pi"} {
+		_, err := ParseVRFProof(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,189 @@
+// Package rngtest certifies random.Generator output with a battery of NIST
+// SP 800-22 rev. 1a statistical tests and a chi-square test of dice faces,
+// and reports the p-values in a machine-readable form.
+package rngtest
+
+import (
+	"dice-game/pkg/infrastructure/random"
+	"fmt"
+	"math/bits"
+	"time"
+)
+
+const (
+	// DefaultAlpha is the significance level SP 800-22 recommends.
+	DefaultAlpha = 0.01
+
+	// collectBatch is the number of values drawn per Roll.
+	collectBatch = 4096
+	// collectClientSeed is the client seed of every roll, so seeded
+	// generators can be certified with the same requests they serve games
+	// with.
+	collectClientSeed = "rngtest"
+)
+
+// Sample is the output drawn from a generator: a bit sequence, one bit per
+// byte, and the faces of dice with Sides sides.
+type Sample struct {
+	Bits  []byte
+	Faces []int
+	Sides int
+}
+
+// Collect draws byteCount bytes, split into bits most significant first,
+// and rolls dice with sides sides from generator. Every Roll asks for up to
+// collectBatch values with the next nonce, so provably fair generators
+// never repeat a hash.
+func Collect(generator random.Generator, byteCount, rolls, sides int) (*Sample, error) {
+	if sides < 2 {
+		return nil, fmt.Errorf("dice need at least 2 sides, got %d", sides)
+	}
+
+	sample := &Sample{
+		Bits:  make([]byte, 0, 8*byteCount),
+		Faces: make([]int, 0, rolls),
+		Sides: sides,
+	}
+	nonce := int64(0)
+
+	draw := func(count, low, high int, add func(v int)) error {
+		for count > 0 {
+			nonce++
+			batch := min(count, collectBatch)
+			roll, err := generator.Roll(random.RollRequest{
+				ClientSeed: collectClientSeed,
+				Nonce:      nonce,
+				Count:      batch,
+				Min:        low,
+				Max:        high,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to roll %s: %w", generator.Name(), err)
+			}
+			if len(roll.Values) != batch {
+				return fmt.Errorf("generator %s returned %d values, expected %d", generator.Name(), len(roll.Values), batch)
+			}
+			for _, v := range roll.Values {
+				if v < low || v > high {
+					return fmt.Errorf("generator %s returned %d outside [%d, %d]", generator.Name(), v, low, high)
+				}
+				add(v)
+			}
+			count -= batch
+		}
+		return nil
+	}
+
+	err := draw(byteCount, 0, 255, func(v int) {
+		for shift := 7; shift >= 0; shift-- {
+			sample.Bits = append(sample.Bits, byte(v>>shift&1))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = draw(rolls, 1, sides, func(v int) {
+		sample.Faces = append(sample.Faces, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sample, nil
+}
+
+// Report is the outcome of a certification run. It is written as JSON;
+// field names are part of the format regulators archive.
+type Report struct {
+	Generator string    `json:"generator"`
+	StartedAt time.Time `json:"started_at"`
+	// DurationSeconds covers drawing the sample and running the tests.
+	DurationSeconds float64  `json:"duration_seconds"`
+	Bits            int      `json:"bits"`
+	DiceRolls       int      `json:"dice_rolls"`
+	DiceSides       int      `json:"dice_sides"`
+	Alpha           float64  `json:"alpha"`
+	Passed          bool     `json:"passed"`
+	Tests           []Result `json:"tests"`
+}
+
+// Result is the outcome of one test. A test passes when every p-value is
+// at least the report's alpha. Tests that cannot run on the sample, for
+// example because it is too short, fail with Error set.
+type Result struct {
+	Name       string         `json:"name"`
+	PValues    []float64      `json:"p_values"`
+	Passed     bool           `json:"passed"`
+	Parameters map[string]int `json:"parameters,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// Run runs every test on sample at significance level alpha. Block sizes
+// and pattern lengths follow the SP 800-22 recommendations for the length
+// of the sample and are recorded in each result's parameters.
+func Run(sample *Sample, alpha float64) *Report {
+	n := len(sample.Bits)
+	report := &Report{
+		Bits:      n,
+		DiceRolls: len(sample.Faces),
+		DiceSides: sample.Sides,
+		Alpha:     alpha,
+		Passed:    true,
+	}
+
+	add := func(name string, parameters map[string]int, err error, pValues ...float64) {
+		result := Result{Name: name, PValues: pValues, Parameters: parameters, Passed: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+			result.PValues = nil
+		}
+		for _, p := range result.PValues {
+			if p < alpha {
+				result.Passed = false
+			}
+		}
+		report.Passed = report.Passed && result.Passed
+		report.Tests = append(report.Tests, result)
+	}
+
+	p, err := Frequency(sample.Bits)
+	add("frequency", nil, err, p)
+
+	blockSize := max(20, n/100+1)
+	p, err = BlockFrequency(sample.Bits, blockSize)
+	add("block_frequency", map[string]int{"block_size": blockSize}, err, p)
+
+	p, err = Runs(sample.Bits)
+	add("runs", nil, err, p)
+
+	p, blockSize, err = LongestRun(sample.Bits)
+	add("longest_run", map[string]int{"block_size": blockSize}, err, p)
+
+	m := patternLength(n, 2, 16)
+	p1, p2, err := Serial(sample.Bits, m)
+	add("serial", map[string]int{"pattern_length": m}, err, p1, p2)
+
+	m = patternLength(n, 5, 10)
+	p, err = ApproximateEntropy(sample.Bits, m)
+	add("approximate_entropy", map[string]int{"pattern_length": m}, err, p)
+
+	counts := make([]int, sample.Sides)
+	for _, face := range sample.Faces {
+		counts[face-1]++
+	}
+	p, err = DiceChiSquare(counts)
+	if err == nil && len(sample.Faces) < 5*sample.Sides {
+		err = fmt.Errorf("dice chi-square test needs at least %d rolls, got %d", 5*sample.Sides, len(sample.Faces))
+	}
+	add("dice_chi_square", map[string]int{"sides": sample.Sides}, err, p)
+
+	return report
+}
+
+// patternLength is the longest pattern length m with m < floor(log2 n) -
+// margin, the bound SP 800-22 recommends, capped at limit and at least 2.
+func patternLength(n, margin, limit int) int {
+	log2 := bits.Len(uint(n)) - 1
+	return max(2, min(limit, log2-margin-1))
+}
@@ -0,0 +1,169 @@
+package rngtest
+
+import (
+	"dice-game/pkg/infrastructure/random"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The expected p-values are the worked examples of NIST SP 800-22 rev. 1a.
+const (
+	// example100 is the 100-bit sequence of sections 2.1.8, 2.2.8 and 2.3.8.
+	example100 = "1100100100001111110110101010001000100001011010001100001000110100110001001100011001100010100010111000"
+	// example128 is the 128-bit sequence of section 2.4.8.
+	example128 = "11001100000101010110110001001100111000000000001001001101010100010001001111010110100000001101011111001100111001101101100010110010"
+)
+
+func bitString(s string) []byte {
+	bits := make([]byte, len(s))
+	for i, c := range strings.TrimSpace(s) {
+		bits[i] = byte(c - '0')
+	}
+	return bits
+}
+
+func TestFrequency(t *testing.T) {
+	p, err := Frequency(bitString(example100))
+
+	require.NoError(t, err)
+	assert.InDelta(t, 0.109599, p, 1e-6)
+}
+
+func TestBlockFrequency(t *testing.T) {
+	p, err := BlockFrequency(bitString(example100), 10)
+
+	require.NoError(t, err)
+	assert.InDelta(t, 0.706438, p, 1e-6)
+}
+
+func TestRuns(t *testing.T) {
+	p, err := Runs(bitString(example100))
+
+	require.NoError(t, err)
+	assert.InDelta(t, 0.500798, p, 1e-6)
+}
+
+func TestRuns_NotApplicableWhenFrequencyFails(t *testing.T) {
+	bits := bitString(strings.Repeat("1", 80) + strings.Repeat("0", 20))
+
+	p, err := Runs(bits)
+
+	require.NoError(t, err)
+	assert.Zero(t, p)
+}
+
+func TestLongestRun(t *testing.T) {
+	p, blockSize, err := LongestRun(bitString(example128))
+
+	require.NoError(t, err)
+	assert.Equal(t, 8, blockSize)
+	assert.InDelta(t, 0.180609, p, 1e-6)
+}
+
+func TestSerial(t *testing.T) {
+	p1, p2, err := Serial(bitString("0011011101"), 3)
+
+	require.NoError(t, err)
+	assert.InDelta(t, 0.808792, p1, 1e-6)
+	assert.InDelta(t, 0.670320, p2, 1e-6)
+}
+
+func TestApproximateEntropy(t *testing.T) {
+	p, err := ApproximateEntropy(bitString("0100110101"), 3)
+
+	require.NoError(t, err)
+	assert.InDelta(t, 0.261961, p, 1e-6)
+}
+
+func TestDiceChiSquare(t *testing.T) {
+	uniform, err := DiceChiSquare([]int{100, 100, 100, 100, 100, 100})
+	require.NoError(t, err)
+	biased, err := DiceChiSquare([]int{150, 90, 90, 90, 90, 90})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 1, uniform, 1e-9)
+	assert.Less(t, biased, 0.001)
+}
+
+func TestIgamc(t *testing.T) {
+	// Q(1, x) = e^-x, and Q(a, x) for large a uses both branches.
+	assert.InDelta(t, 0.367879441, igamc(1, 1), 1e-9)
+	assert.InDelta(t, 0.5, igamc(16384, 16384-1.0/3), 1e-3)
+	assert.InDelta(t, 0.5, igamc(16384, 16384+2.0/3), 1e-2)
+}
+
+func TestRun_GoodGeneratorPasses(t *testing.T) {
+	// Arrange: a seeded generator, so the test cannot fail by chance.
+	sample, err := Collect(random.NewDeterministicGenerator("rngtest", 1), 125000, 60000, 6)
+	require.NoError(t, err)
+
+	// Act
+	report := Run(sample, DefaultAlpha)
+
+	// Assert
+	assert.Equal(t, 1000000, report.Bits)
+	assert.Equal(t, 60000, report.DiceRolls)
+	assert.Len(t, report.Tests, 7)
+	for _, result := range report.Tests {
+		assert.True(t, result.Passed, "%s: %v %s", result.Name, result.PValues, result.Error)
+	}
+	assert.True(t, report.Passed)
+}
+
+// stuckGenerator returns the same value for every roll.
+type stuckGenerator struct{}
+
+func (stuckGenerator) Generate(min, max int) (int, error) { return min, nil }
+func (stuckGenerator) Name() string                       { return "stuck" }
+func (stuckGenerator) Roll(req random.RollRequest) (*random.Roll, error) {
+	values := make([]int, req.Count)
+	for i := range values {
+		values[i] = req.Min
+	}
+	return &random.Roll{Values: values}, nil
+}
+
+func TestRun_StuckGeneratorFails(t *testing.T) {
+	// Arrange
+	sample, err := Collect(stuckGenerator{}, 10000, 1000, 6)
+	require.NoError(t, err)
+
+	// Act
+	report := Run(sample, DefaultAlpha)
+
+	// Assert
+	assert.False(t, report.Passed)
+	for _, result := range report.Tests {
+		assert.False(t, result.Passed, result.Name)
+	}
+}
+
+func TestRun_SampleTooShort(t *testing.T) {
+	// Arrange
+	sample := &Sample{Bits: bitString("0110"), Sides: 6}
+
+	// Act
+	report := Run(sample, DefaultAlpha)
+
+	// Assert
+	assert.False(t, report.Passed)
+	assert.Equal(t, "frequency", report.Tests[0].Name)
+	assert.Contains(t, report.Tests[0].Error, "at least 100 bits")
+	assert.Nil(t, report.Tests[0].PValues)
+}
+
+type failingGenerator struct{ stuckGenerator }
+
+func (failingGenerator) Roll(random.RollRequest) (*random.Roll, error) {
+	return nil, errors.New("device unplugged")
+}
+
+func TestCollect_GeneratorError(t *testing.T) {
+	_, err := Collect(failingGenerator{}, 10, 10, 6)
+
+	assert.ErrorContains(t, err, "device unplugged")
+}
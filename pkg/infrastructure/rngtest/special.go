@@ -0,0 +1,68 @@
+package rngtest
+
+import "math"
+
+const (
+	gammaEpsilon       = 1e-15
+	gammaMaxIterations = 1_000_000
+	gammaTiny          = 1e-300
+)
+
+// igamc is the regularized upper incomplete gamma function Q(a, x), the
+// igamc of the NIST SP 800-22 reference implementation. It uses the series
+// expansion of P(a, x) below a+1 and a continued fraction above it.
+func igamc(a, x float64) float64 {
+	if x <= 0 || a <= 0 {
+		return 1
+	}
+	if x < a+1 {
+		return 1 - gammaSeries(a, x)
+	}
+	return gammaContinuedFraction(a, x)
+}
+
+// gammaPrefix is x^a e^-x / Gamma(a), computed in logarithms.
+func gammaPrefix(a, x float64) float64 {
+	lgamma, _ := math.Lgamma(a)
+	return math.Exp(a*math.Log(x) - x - lgamma)
+}
+
+func gammaSeries(a, x float64) float64 {
+	term := 1 / a
+	sum := term
+	for n := 1; n < gammaMaxIterations; n++ {
+		term *= x / (a + float64(n))
+		sum += term
+		if math.Abs(term) < math.Abs(sum)*gammaEpsilon {
+			break
+		}
+	}
+	return sum * gammaPrefix(a, x)
+}
+
+// gammaContinuedFraction evaluates Q(a, x) with the modified Lentz method.
+func gammaContinuedFraction(a, x float64) float64 {
+	b := x + 1 - a
+	c := 1 / gammaTiny
+	d := 1 / b
+	h := d
+	for i := 1; i < gammaMaxIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < gammaTiny {
+			d = gammaTiny
+		}
+		c = b + an/c
+		if math.Abs(c) < gammaTiny {
+			c = gammaTiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < gammaEpsilon {
+			break
+		}
+	}
+	return h * gammaPrefix(a, x)
+}
@@ -0,0 +1,231 @@
+package rngtest
+
+import (
+	"dice-game/pkg/infrastructure/random"
+	"fmt"
+	"math"
+)
+
+// The tests below follow NIST SP 800-22 rev. 1a. Bits are passed one per
+// byte, each 0 or 1, and every test returns the p-values of its statistics:
+// a sequence fails a test when a p-value is below the chosen significance
+// level.
+
+// Frequency is the frequency (monobit) test of section 2.1: the proportion
+// of ones in the whole sequence.
+func Frequency(bits []byte) (float64, error) {
+	if len(bits) < 100 {
+		return 0, fmt.Errorf("frequency test needs at least 100 bits, got %d", len(bits))
+	}
+
+	sum := 0
+	for _, b := range bits {
+		sum += 2*int(b) - 1
+	}
+
+	observed := math.Abs(float64(sum)) / math.Sqrt(float64(len(bits)))
+	return math.Erfc(observed / math.Sqrt2), nil
+}
+
+// BlockFrequency is the frequency test within blocks of section 2.2: the
+// proportion of ones in each of the n/blockSize blocks.
+func BlockFrequency(bits []byte, blockSize int) (float64, error) {
+	blocks := 0
+	if blockSize > 0 {
+		blocks = len(bits) / blockSize
+	}
+	if blocks < 1 {
+		return 0, fmt.Errorf("block frequency test needs at least one block of %d bits, got %d bits", blockSize, len(bits))
+	}
+
+	chiSquare := 0.0
+	for i := 0; i < blocks; i++ {
+		ones := 0
+		for _, b := range bits[i*blockSize : (i+1)*blockSize] {
+			ones += int(b)
+		}
+		diff := float64(ones)/float64(blockSize) - 0.5
+		chiSquare += diff * diff
+	}
+	chiSquare *= 4 * float64(blockSize)
+
+	return igamc(float64(blocks)/2, chiSquare/2), nil
+}
+
+// Runs is the runs test of section 2.3: the number of uninterrupted runs
+// of equal bits. When the frequency of ones is already too far from one
+// half the test is not applicable and the p-value is 0.
+func Runs(bits []byte) (float64, error) {
+	n := len(bits)
+	if n < 100 {
+		return 0, fmt.Errorf("runs test needs at least 100 bits, got %d", n)
+	}
+
+	ones := 0
+	for _, b := range bits {
+		ones += int(b)
+	}
+	pi := float64(ones) / float64(n)
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(float64(n)) {
+		return 0, nil
+	}
+
+	runs := 1
+	for i := 1; i < n; i++ {
+		if bits[i] != bits[i-1] {
+			runs++
+		}
+	}
+
+	expected := 2 * float64(n) * pi * (1 - pi)
+	observed := math.Abs(float64(runs)-expected) / (2 * math.Sqrt(2*float64(n)) * pi * (1 - pi))
+	return math.Erfc(observed), nil
+}
+
+// longestRunTable holds the block size, the class bounds and the class
+// probabilities of the longest run test for a minimum sequence length. The
+// probabilities are those of the SP 800-22 reference implementation, which
+// are more precise than the ones printed in section 3.4.
+type longestRunTable struct {
+	minBits   int
+	blockSize int
+	// low and high are the longest runs that fall into the first and last
+	// class; every length in between has a class of its own.
+	low, high     int
+	probabilities []float64
+}
+
+var longestRunTables = []longestRunTable{
+	{minBits: 750000, blockSize: 10000, low: 10, high: 16,
+		probabilities: []float64{0.0882, 0.2092, 0.2483, 0.1933, 0.1208, 0.0675, 0.0727}},
+	{minBits: 6272, blockSize: 128, low: 4, high: 9,
+		probabilities: []float64{0.1174035788, 0.242955959, 0.249363483, 0.17517706, 0.102701071, 0.112398847}},
+	{minBits: 128, blockSize: 8, low: 1, high: 4,
+		probabilities: []float64{0.21484375, 0.3671875, 0.23046875, 0.1875}},
+}
+
+// LongestRun is the test for the longest run of ones in a block of section
+// 2.4. The block size depends on the length of the sequence and is
+// returned with the p-value.
+func LongestRun(bits []byte) (float64, int, error) {
+	var table *longestRunTable
+	for i := range longestRunTables {
+		if len(bits) >= longestRunTables[i].minBits {
+			table = &longestRunTables[i]
+			break
+		}
+	}
+	if table == nil {
+		return 0, 0, fmt.Errorf("longest run test needs at least 128 bits, got %d", len(bits))
+	}
+
+	blocks := len(bits) / table.blockSize
+	counts := make([]int, len(table.probabilities))
+	for i := 0; i < blocks; i++ {
+		longest, run := 0, 0
+		for _, b := range bits[i*table.blockSize : (i+1)*table.blockSize] {
+			if b == 1 {
+				run++
+				longest = max(longest, run)
+			} else {
+				run = 0
+			}
+		}
+		counts[min(max(longest, table.low), table.high)-table.low]++
+	}
+
+	chiSquare := 0.0
+	for i, p := range table.probabilities {
+		expected := float64(blocks) * p
+		diff := float64(counts[i]) - expected
+		chiSquare += diff * diff / expected
+	}
+
+	return igamc(float64(len(counts)-1)/2, chiSquare/2), table.blockSize, nil
+}
+
+// Serial is the serial test of section 2.11: the frequencies of all
+// overlapping patterns of length m. It returns both p-values of the test.
+func Serial(bits []byte, m int) (float64, float64, error) {
+	if m < 2 || m > 24 {
+		return 0, 0, fmt.Errorf("serial test pattern length must be between 2 and 24, got %d", m)
+	}
+	if len(bits) < m {
+		return 0, 0, fmt.Errorf("serial test needs at least %d bits, got %d", m, len(bits))
+	}
+
+	psi := func(m int) float64 {
+		if m <= 0 {
+			return 0
+		}
+		sum := 0.0
+		for _, c := range patternCounts(bits, m) {
+			sum += float64(c) * float64(c)
+		}
+		n := float64(len(bits))
+		return math.Ldexp(sum, m)/n - n
+	}
+
+	psiM, psiM1, psiM2 := psi(m), psi(m-1), psi(m-2)
+	delta1 := psiM - psiM1
+	delta2 := psiM - 2*psiM1 + psiM2
+
+	return igamc(math.Ldexp(1, m-2), delta1/2), igamc(math.Ldexp(1, m-3), delta2/2), nil
+}
+
+// ApproximateEntropy is the approximate entropy test of section 2.12: the
+// frequencies of overlapping patterns of lengths m and m+1.
+func ApproximateEntropy(bits []byte, m int) (float64, error) {
+	if m < 1 || m > 23 {
+		return 0, fmt.Errorf("approximate entropy test pattern length must be between 1 and 23, got %d", m)
+	}
+	if len(bits) <= m {
+		return 0, fmt.Errorf("approximate entropy test needs more than %d bits, got %d", m, len(bits))
+	}
+
+	n := float64(len(bits))
+	phi := func(m int) float64 {
+		sum := 0.0
+		for _, c := range patternCounts(bits, m) {
+			if c > 0 {
+				p := float64(c) / n
+				sum += p * math.Log(p)
+			}
+		}
+		return sum
+	}
+
+	apEn := phi(m) - phi(m+1)
+	chiSquare := 2 * n * (math.Ln2 - apEn)
+
+	return igamc(math.Ldexp(1, m-1), chiSquare/2), nil
+}
+
+// DiceChiSquare is Pearson's chi-square goodness-of-fit test of the faces
+// of a die against the uniform distribution. counts holds how often each
+// face was rolled.
+func DiceChiSquare(counts []int) (float64, error) {
+	if len(counts) < 2 {
+		return 0, fmt.Errorf("dice chi-square test needs at least 2 faces, got %d", len(counts))
+	}
+
+	return igamc(float64(len(counts)-1)/2, random.ChiSquare(counts)/2), nil
+}
+
+// patternCounts counts the overlapping m-bit patterns of bits, wrapping
+// around to the start of the sequence for the last m-1 patterns.
+func patternCounts(bits []byte, m int) []int {
+	counts := make([]int, 1<<m)
+	mask := 1<<m - 1
+
+	pattern := 0
+	for i := 0; i < m-1; i++ {
+		pattern = pattern<<1 | int(bits[i])
+	}
+	for i := range bits {
+		pattern = (pattern<<1 | int(bits[(i+m-1)%len(bits)])) & mask
+		counts[pattern]++
+	}
+
+	return counts
+}
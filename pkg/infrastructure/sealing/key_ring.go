@@ -0,0 +1,145 @@
+// Package sealing encrypts the raw entropy stored with games using AES-256-GCM
+// keys loaded from a local key file.
+package sealing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// KeySize is the size of an AES-256 key.
+const KeySize = 32
+
+// Key is a sealing key. Retired keys no longer seal but still open what
+// they sealed.
+type Key struct {
+	ID        string
+	RetiredAt *time.Time
+	aead      cipher.AEAD
+}
+
+// KeyRing holds the current sealing key and the retired keys whose sealed
+// data must still open.
+type KeyRing struct {
+	current *Key
+	keys    map[string]*Key
+}
+
+// keyFile is the on-disk format. key is the base64 encoded 32-byte AES key.
+// Exactly one key must be without retired_at; it is the current key.
+type keyFile struct {
+	Keys []struct {
+		ID        string     `json:"id"`
+		Key       string     `json:"key"`
+		RetiredAt *time.Time `json:"retired_at,omitempty"`
+	} `json:"keys"`
+}
+
+func LoadKeyFile(path string) (*KeyRing, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sealing key file: %w", err)
+	}
+
+	var file keyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse sealing key file: %w", err)
+	}
+
+	ring := &KeyRing{keys: make(map[string]*Key)}
+
+	for _, entry := range file.Keys {
+		if entry.ID == "" {
+			return nil, fmt.Errorf("sealing key without id")
+		}
+		if ring.keys[entry.ID] != nil {
+			return nil, fmt.Errorf("duplicate sealing key id %q", entry.ID)
+		}
+
+		key, err := parseKey(entry.ID, entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		key.RetiredAt = entry.RetiredAt
+
+		if key.RetiredAt == nil {
+			if ring.current != nil {
+				return nil, fmt.Errorf("sealing keys %q and %q are both current", ring.current.ID, key.ID)
+			}
+			ring.current = key
+		}
+
+		ring.keys[key.ID] = key
+	}
+
+	if ring.current == nil {
+		return nil, fmt.Errorf("sealing key file has no current key")
+	}
+
+	return ring, nil
+}
+
+func parseKey(id, encoded string) (*Key, error) {
+	secret, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealing key %q: %w", id, err)
+	}
+	if len(secret) != KeySize {
+		return nil, fmt.Errorf("sealing key %q must be %d bytes, got %d bytes", id, KeySize, len(secret))
+	}
+
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{ID: id, aead: aead}, nil
+}
+
+// Seal encrypts plaintext with the current key and returns the key's ID and
+// the random nonce followed by the ciphertext. gameID is authenticated with
+// it, so sealed data cannot be moved to another game.
+func (r *KeyRing) Seal(gameID string, plaintext []byte) (string, []byte, error) {
+	aead := r.current.aead
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return r.current.ID, aead.Seal(nonce, nonce, plaintext, []byte(gameID)), nil
+}
+
+// Open decrypts data sealed for gameID with the key keyID.
+func (r *KeyRing) Open(keyID, gameID string, sealed []byte) ([]byte, error) {
+	key := r.keys[keyID]
+	if key == nil {
+		return nil, fmt.Errorf("unknown sealing key %q", keyID)
+	}
+
+	nonceSize := key.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("sealed data is shorter than a nonce")
+	}
+
+	plaintext, err := key.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(gameID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sealed data with key %q: %w", keyID, err)
+	}
+
+	return plaintext, nil
+}
+
+func (r *KeyRing) CurrentKeyID() string {
+	return r.current.ID
+}
@@ -0,0 +1,97 @@
+package sealing
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	currentKey = []byte("0123456789abcdef0123456789abcdef")
+	retiredKey = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func b64(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func writeKeyFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "sealing.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadKeyFile_SealAndOpen(t *testing.T) {
+	path := writeKeyFile(t, `{"keys": [
+		{"id": "key-1", "key": "`+b64(retiredKey)+`", "retired_at": "2025-01-01T00:00:00Z"},
+		{"id": "key-2", "key": "`+b64(currentKey)+`"}
+	]}`)
+
+	ring, err := LoadKeyFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", ring.CurrentKeyID())
+
+	keyID, sealed, err := ring.Seal("game-1", []byte("raw entropy"))
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", keyID)
+	assert.NotContains(t, string(sealed), "raw entropy")
+
+	opened, err := ring.Open(keyID, "game-1", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw entropy"), opened)
+
+	_, err = ring.Open(keyID, "game-2", sealed)
+	assert.Error(t, err, "sealed data is bound to its game")
+
+	_, err = ring.Open("key-1", "game-1", sealed)
+	assert.Error(t, err)
+
+	_, err = ring.Open("key-3", "game-1", sealed)
+	assert.ErrorContains(t, err, `unknown sealing key "key-3"`)
+}
+
+func TestLoadKeyFile_RetiredKeyStillOpens(t *testing.T) {
+	oldRing, err := LoadKeyFile(writeKeyFile(t, `{"keys": [{"id": "key-1", "key": "`+b64(retiredKey)+`"}]}`))
+	require.NoError(t, err)
+	keyID, sealed, err := oldRing.Seal("game-1", []byte("raw entropy"))
+	require.NoError(t, err)
+
+	ring, err := LoadKeyFile(writeKeyFile(t, `{"keys": [
+		{"id": "key-1", "key": "`+b64(retiredKey)+`", "retired_at": "2025-01-01T00:00:00Z"},
+		{"id": "key-2", "key": "`+b64(currentKey)+`"}
+	]}`))
+	require.NoError(t, err)
+
+	opened, err := ring.Open(keyID, "game-1", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw entropy"), opened)
+}
+
+func TestLoadKeyFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"No current key", `{"keys": [{"id": "a", "key": "` + b64(currentKey) + `", "retired_at": "2025-01-01T00:00:00Z"}]}`, "no current key"},
+		{"Two current keys", `{"keys": [{"id": "a", "key": "` + b64(currentKey) + `"}, {"id": "b", "key": "` + b64(retiredKey) + `"}]}`, "both current"},
+		{"Duplicate id", `{"keys": [{"id": "a", "key": "` + b64(currentKey) + `"}, {"id": "a", "key": "` + b64(retiredKey) + `"}]}`, "duplicate sealing key id"},
+		{"Missing id", `{"keys": [{"key": "` + b64(currentKey) + `"}]}`, "without id"},
+		{"Short key", `{"keys": [{"id": "a", "key": "` + b64([]byte("short")) + `"}]}`, "must be 32 bytes"},
+		{"Invalid JSON", `{`, "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadKeyFile(writeKeyFile(t, tt.content))
+
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
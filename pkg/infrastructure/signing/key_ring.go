@@ -0,0 +1,154 @@
+// Package signing signs game receipts with Ed25519 keys loaded from a local
+// key file.
+package signing
+
+import (
+	"crypto/ed25519"
+	"dice-game/pkg/domain/model"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Key is a signing key. Private is nil for retired keys whose private half
+// is no longer kept.
+type Key struct {
+	ID        string
+	Public    ed25519.PublicKey
+	Private   ed25519.PrivateKey
+	RetiredAt *time.Time
+}
+
+// KeyRing holds the current signing key and the retired keys whose
+// signatures must still verify.
+type KeyRing struct {
+	current *Key
+	keys    []*Key
+}
+
+// keyFile is the on-disk format. Keys are base64: private_key is the 32-byte
+// Ed25519 seed, public_key the 32-byte public key. Exactly one key must be
+// without retired_at; it is the current key and needs its private_key.
+type keyFile struct {
+	Keys []struct {
+		ID         string     `json:"id"`
+		PrivateKey string     `json:"private_key,omitempty"`
+		PublicKey  string     `json:"public_key,omitempty"`
+		RetiredAt  *time.Time `json:"retired_at,omitempty"`
+	} `json:"keys"`
+}
+
+func LoadKeyFile(path string) (*KeyRing, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key file: %w", err)
+	}
+
+	var file keyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse signing key file: %w", err)
+	}
+
+	ring := &KeyRing{}
+	seen := make(map[string]bool)
+
+	for _, entry := range file.Keys {
+		if entry.ID == "" {
+			return nil, fmt.Errorf("signing key without id")
+		}
+		if seen[entry.ID] {
+			return nil, fmt.Errorf("duplicate signing key id %q", entry.ID)
+		}
+		seen[entry.ID] = true
+
+		key, err := parseKey(entry.ID, entry.PrivateKey, entry.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		key.RetiredAt = entry.RetiredAt
+
+		if key.RetiredAt == nil {
+			if ring.current != nil {
+				return nil, fmt.Errorf("signing keys %q and %q are both current", ring.current.ID, key.ID)
+			}
+			if key.Private == nil {
+				return nil, fmt.Errorf("current signing key %q has no private key", key.ID)
+			}
+			ring.current = key
+		}
+
+		ring.keys = append(ring.keys, key)
+	}
+
+	if ring.current == nil {
+		return nil, fmt.Errorf("signing key file has no current key")
+	}
+
+	return ring, nil
+}
+
+func parseKey(id, privateKey, publicKey string) (*Key, error) {
+	key := &Key{ID: id}
+
+	if privateKey != "" {
+		seed, err := base64.StdEncoding.DecodeString(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key %q: %w", id, err)
+		}
+		if len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("private key %q must be a %d-byte seed, got %d bytes", id, ed25519.SeedSize, len(seed))
+		}
+		key.Private = ed25519.NewKeyFromSeed(seed)
+		key.Public = key.Private.Public().(ed25519.PublicKey)
+	}
+
+	if publicKey != "" {
+		public, err := base64.StdEncoding.DecodeString(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %q: %w", id, err)
+		}
+		if len(public) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("public key %q must be %d bytes, got %d bytes", id, ed25519.PublicKeySize, len(public))
+		}
+		if key.Public != nil && !key.Public.Equal(ed25519.PublicKey(public)) {
+			return nil, fmt.Errorf("public key %q does not match its private key", id)
+		}
+		key.Public = public
+	}
+
+	if key.Public == nil {
+		return nil, fmt.Errorf("signing key %q has neither a private nor a public key", id)
+	}
+
+	return key, nil
+}
+
+// Sign signs message with the current key.
+func (r *KeyRing) Sign(message []byte) (string, []byte, error) {
+	return r.current.ID, ed25519.Sign(r.current.Private, message), nil
+}
+
+// Keys returns every key in file order, current and retired.
+func (r *KeyRing) Keys() []*Key {
+	return r.keys
+}
+
+// PublicKeys returns the public half of every key in file order.
+func (r *KeyRing) PublicKeys() []model.SigningKey {
+	keys := make([]model.SigningKey, len(r.keys))
+	for i, key := range r.keys {
+		keys[i] = model.SigningKey{
+			ID:        key.ID,
+			PublicKey: key.Public,
+			Current:   key == r.current,
+			RetiredAt: key.RetiredAt,
+		}
+	}
+	return keys
+}
+
+func (r *KeyRing) CurrentKeyID() string {
+	return r.current.ID
+}
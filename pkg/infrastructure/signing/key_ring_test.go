@@ -0,0 +1,94 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	currentSeed = []byte("0123456789abcdef0123456789abcdef")
+	retiredSeed = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func b64(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func writeKeyFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "signing.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	retiredPublic := ed25519.NewKeyFromSeed(retiredSeed).Public().(ed25519.PublicKey)
+	path := writeKeyFile(t, `{"keys": [
+		{"id": "key-1", "public_key": "`+b64(retiredPublic)+`", "retired_at": "2025-01-01T00:00:00Z"},
+		{"id": "key-2", "private_key": "`+b64(currentSeed)+`"}
+	]}`)
+
+	ring, err := LoadKeyFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", ring.CurrentKeyID())
+	require.Len(t, ring.Keys(), 2)
+	assert.Equal(t, retiredPublic, ring.Keys()[0].Public)
+	assert.Nil(t, ring.Keys()[0].Private)
+	assert.NotNil(t, ring.Keys()[0].RetiredAt)
+
+	keyID, signature, err := ring.Sign([]byte("receipt"))
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", keyID)
+	assert.True(t, ed25519.Verify(ring.Keys()[1].Public, []byte("receipt"), signature))
+	assert.False(t, ed25519.Verify(retiredPublic, []byte("receipt"), signature))
+
+	published := ring.PublicKeys()
+	require.Len(t, published, 2)
+	assert.Equal(t, "key-1", published[0].ID)
+	assert.False(t, published[0].Current)
+	assert.Equal(t, []byte(retiredPublic), published[0].PublicKey)
+	assert.Equal(t, "key-2", published[1].ID)
+	assert.True(t, published[1].Current)
+	assert.Nil(t, published[1].RetiredAt)
+}
+
+func TestLoadKeyFile_Invalid(t *testing.T) {
+	otherPublic := ed25519.NewKeyFromSeed(retiredSeed).Public().(ed25519.PublicKey)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "Malformed JSON", content: `{"keys": [`},
+		{name: "No current key", content: `{"keys": [{"id": "key-1", "private_key": "` + b64(currentSeed) + `", "retired_at": "2025-01-01T00:00:00Z"}]}`},
+		{name: "Two current keys", content: `{"keys": [{"id": "key-1", "private_key": "` + b64(currentSeed) + `"}, {"id": "key-2", "private_key": "` + b64(retiredSeed) + `"}]}`},
+		{name: "Current key without private key", content: `{"keys": [{"id": "key-1", "public_key": "` + b64(otherPublic) + `"}]}`},
+		{name: "Duplicate id", content: `{"keys": [{"id": "key-1", "private_key": "` + b64(currentSeed) + `"}, {"id": "key-1", "public_key": "` + b64(otherPublic) + `", "retired_at": "2025-01-01T00:00:00Z"}]}`},
+		{name: "Short seed", content: `{"keys": [{"id": "key-1", "private_key": "` + b64([]byte("short")) + `"}]}`},
+		{name: "Mismatched public key", content: `{"keys": [{"id": "key-1", "private_key": "` + b64(currentSeed) + `", "public_key": "` + b64(otherPublic) + `"}]}`},
+		{name: "Missing id", content: `{"keys": [{"private_key": "` + b64(currentSeed) + `"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring, err := LoadKeyFile(writeKeyFile(t, tt.content))
+
+			assert.Error(t, err)
+			assert.Nil(t, ring)
+		})
+	}
+}
+
+func TestLoadKeyFile_Missing(t *testing.T) {
+	_, err := LoadKeyFile(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+// Package vrf implements ECVRF-EDWARDS25519-SHA512-TAI from RFC 9381, a
+// verifiable random function over Ed25519 keys.
+//
+// The holder of a secret key turns an input alpha into a proof pi; anyone
+// with the public key can check pi and derive the same 64-byte output beta
+// from it, while nobody without the secret key can predict beta. Keys are
+// ordinary Ed25519 keys: a 32-byte seed yields the same public key as
+// crypto/ed25519.
+package vrf
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+)
+
+const (
+	// SeedSize is the size of a secret key seed.
+	SeedSize = 32
+	// PublicKeySize is the size of an encoded public key.
+	PublicKeySize = 32
+	// ProofSize is the size of pi: Gamma, c and s.
+	ProofSize = 80
+	// OutputSize is the size of beta.
+	OutputSize = 64
+
+	suiteString   = 0x03
+	challengeSize = 16
+)
+
+// ErrInvalidProof is returned by Verify when pi does not prove alpha under
+// the public key.
+var ErrInvalidProof = errors.New("invalid vrf proof")
+
+// PrivateKey holds the secret scalar and nonce prefix. Every operation on
+// them is constant time: the curve arithmetic is filippo.io/edwards25519,
+// the same as crypto/ed25519.
+type PrivateKey struct {
+	scalar *edwards25519.Scalar
+	prefix []byte
+	public []byte
+}
+
+// NewKeyFromSeed expands a 32-byte seed as RFC 8032 does for Ed25519.
+func NewKeyFromSeed(seed []byte) (*PrivateKey, error) {
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("vrf seed must be %d bytes, got %d", SeedSize, len(seed))
+	}
+
+	digest := sha512.Sum512(seed)
+	scalar, err := edwards25519.NewScalar().SetBytesWithClamping(digest[:32])
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{
+		scalar: scalar,
+		prefix: append([]byte(nil), digest[32:]...),
+		public: new(edwards25519.Point).ScalarBaseMult(scalar).Bytes(),
+	}, nil
+}
+
+// Public returns the encoded public key.
+func (k *PrivateKey) Public() []byte {
+	return append([]byte(nil), k.public...)
+}
+
+// Prove returns the proof pi for alpha.
+func (k *PrivateKey) Prove(alpha []byte) ([]byte, error) {
+	h, err := encodeToCurve(k.public, alpha)
+	if err != nil {
+		return nil, err
+	}
+	hString := h.Bytes()
+
+	gamma := new(edwards25519.Point).ScalarMult(k.scalar, h)
+
+	nonceDigest := sha512.New()
+	nonceDigest.Write(k.prefix)
+	nonceDigest.Write(hString)
+	nonce, err := edwards25519.NewScalar().SetUniformBytes(nonceDigest.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	c := challenge(k.public, hString, gamma,
+		new(edwards25519.Point).ScalarBaseMult(nonce),
+		new(edwards25519.Point).ScalarMult(nonce, h))
+
+	// s = c*x + k mod q
+	s := edwards25519.NewScalar().MultiplyAdd(challengeScalar(c), k.scalar, nonce)
+
+	pi := make([]byte, 0, ProofSize)
+	pi = append(pi, gamma.Bytes()...)
+	pi = append(pi, c...)
+	pi = append(pi, s.Bytes()...)
+	return pi, nil
+}
+
+// Verify checks pi against the public key and alpha and returns beta. It
+// only handles public values, so it uses variable-time arithmetic.
+func Verify(publicKey, alpha, pi []byte) ([]byte, error) {
+	y, ok := decodePoint(publicKey)
+	if !ok || new(edwards25519.Point).MultByCofactor(y).Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, fmt.Errorf("invalid vrf public key")
+	}
+
+	gamma, c, s, err := decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := encodeToCurve(publicKey, alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	// U = s*B - c*Y, V = s*H - c*Gamma
+	negC := edwards25519.NewScalar().Negate(challengeScalar(c))
+	u := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(negC, y, s)
+	v := new(edwards25519.Point).VarTimeMultiScalarMult([]*edwards25519.Scalar{s, negC}, []*edwards25519.Point{h, gamma})
+
+	if !bytes.Equal(challenge(publicKey, h.Bytes(), gamma, u, v), c) {
+		return nil, ErrInvalidProof
+	}
+
+	return gammaToHash(gamma), nil
+}
+
+// ProofToHash returns beta for pi without verifying it. Only use it on
+// proofs the caller produced or already verified.
+func ProofToHash(pi []byte) ([]byte, error) {
+	gamma, _, _, err := decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	return gammaToHash(gamma), nil
+}
+
+func decodeProof(pi []byte) (*edwards25519.Point, []byte, *edwards25519.Scalar, error) {
+	if len(pi) != ProofSize {
+		return nil, nil, nil, fmt.Errorf("vrf proof must be %d bytes, got %d", ProofSize, len(pi))
+	}
+
+	gamma, ok := decodePoint(pi[:32])
+	if !ok {
+		return nil, nil, nil, ErrInvalidProof
+	}
+
+	c := pi[32 : 32+challengeSize]
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(pi[32+challengeSize:])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidProof
+	}
+
+	return gamma, c, s, nil
+}
+
+// decodePoint parses an RFC 8032 point encoding. Unlike SetBytes alone it
+// rejects non-canonical encodings, as RFC 9381 requires.
+func decodePoint(s []byte) (*edwards25519.Point, bool) {
+	p, err := new(edwards25519.Point).SetBytes(s)
+	if err != nil || !bytes.Equal(p.Bytes(), s) {
+		return nil, false
+	}
+	return p, true
+}
+
+// encodeToCurve is ECVRF_encode_to_curve_try_and_increment: hash the public
+// key, alpha and a counter until the first 32 bytes decode to a point, then
+// clear the cofactor.
+func encodeToCurve(publicKey, alpha []byte) (*edwards25519.Point, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		digest := sha512.New()
+		digest.Write([]byte{suiteString, 0x01})
+		digest.Write(publicKey)
+		digest.Write(alpha)
+		digest.Write([]byte{byte(ctr), 0x00})
+
+		if h, ok := decodePoint(digest.Sum(nil)[:32]); ok {
+			return h.MultByCofactor(h), nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to hash vrf input to curve")
+}
+
+// challenge returns the 16-byte challenge c, little-endian.
+func challenge(publicKey, hString []byte, gamma, u, v *edwards25519.Point) []byte {
+	digest := sha512.New()
+	digest.Write([]byte{suiteString, 0x02})
+	digest.Write(publicKey)
+	digest.Write(hString)
+	digest.Write(gamma.Bytes())
+	digest.Write(u.Bytes())
+	digest.Write(v.Bytes())
+	digest.Write([]byte{0x00})
+
+	return digest.Sum(nil)[:challengeSize]
+}
+
+// challengeScalar widens c to a scalar; 16 bytes are always below the group
+// order.
+func challengeScalar(c []byte) *edwards25519.Scalar {
+	wide := make([]byte, 32)
+	copy(wide, c)
+
+	scalar, err := edwards25519.NewScalar().SetCanonicalBytes(wide)
+	if err != nil {
+		panic("vrf: challenge is not a canonical scalar")
+	}
+	return scalar
+}
+
+func gammaToHash(gamma *edwards25519.Point) []byte {
+	digest := sha512.New()
+	digest.Write([]byte{suiteString, 0x03})
+	digest.Write(new(edwards25519.Point).MultByCofactor(gamma).Bytes())
+	digest.Write([]byte{0x00})
+	return digest.Sum(nil)
+}
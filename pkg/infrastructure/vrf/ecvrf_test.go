@@ -0,0 +1,127 @@
+package vrf
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// Test vectors from RFC 9381, appendix B.3.
+func TestProve_RFC9381Vectors(t *testing.T) {
+	tests := []struct {
+		name  string
+		sk    string
+		pk    string
+		alpha string
+		pi    string
+		beta  string
+	}{
+		{
+			name:  "Example 16",
+			sk:    "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			pk:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			alpha: "",
+			pi:    "8657106690b5526245a92b003bb079ccd1a92130477671f6fc01ad16f26f723f26f8a57ccaed74ee1b190bed1f479d9727d2d0f9b005a6e456a35d4fb0daab1268a1b0db10836d9826a528ca76567805",
+			beta:  "90cf1df3b703cce59e2a35b925d411164068269d7b2d29f3301c03dd757876ff66b71dda49d2de59d03450451af026798e8f81cd2e333de5cdf4f3e140fdd8ae",
+		},
+		{
+			name:  "Example 17",
+			sk:    "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			pk:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			alpha: "72",
+			pi:    "f3141cd382dc42909d19ec5110469e4feae18300e94f304590abdced48aed5933bf0864a62558b3ed7f2fea45c92a465301b3bbf5e3e54ddf2d935be3b67926da3ef39226bbc355bdc9850112c8f4b02",
+			beta:  "eb4440665d3891d668e7e0fcaf587f1b4bd7fbfe99d0eb2211ccec90496310eb5e33821bc613efb94db5e5b54c70a848a0bef4553a41befc57663b56373a5031",
+		},
+		{
+			name:  "Example 18",
+			sk:    "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+			pk:    "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+			alpha: "af82",
+			pi:    "9bc0f79119cc5604bf02d23b4caede71393cedfbb191434dd016d30177ccbf8096bb474e53895c362d8628ee9f9ea3c0e52c7a5c691b6c18c9979866568add7a2d41b00b05081ed0f58ee5e31b3a970e",
+			beta:  "645427e5d00c62a23fb703732fa5d892940935942101e456ecca7bb217c61c452118fec1219202a0edcf038bb6373241578be7217ba85a2687f7a0310b2df19f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := NewKeyFromSeed(mustHex(t, tt.sk))
+			require.NoError(t, err)
+			assert.Equal(t, tt.pk, hex.EncodeToString(key.Public()))
+
+			pi, err := key.Prove(mustHex(t, tt.alpha))
+			require.NoError(t, err)
+			assert.Equal(t, tt.pi, hex.EncodeToString(pi))
+
+			beta, err := Verify(key.Public(), mustHex(t, tt.alpha), pi)
+			require.NoError(t, err)
+			assert.Equal(t, tt.beta, hex.EncodeToString(beta))
+
+			fromProof, err := ProofToHash(pi)
+			require.NoError(t, err)
+			assert.Equal(t, beta, fromProof)
+		})
+	}
+}
+
+func TestNewKeyFromSeed_MatchesEd25519(t *testing.T) {
+	seed := []byte("0123456789abcdef0123456789abcdef")
+
+	key, err := NewKeyFromSeed(seed)
+	require.NoError(t, err)
+
+	expected := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	assert.Equal(t, []byte(expected), key.Public())
+
+	_, err = NewKeyFromSeed(seed[:31])
+	assert.Error(t, err)
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	key, err := NewKeyFromSeed([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	other, err := NewKeyFromSeed([]byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err)
+
+	alpha := []byte("client-seed:1")
+	pi, err := key.Prove(alpha)
+	require.NoError(t, err)
+
+	t.Run("Other input", func(t *testing.T) {
+		_, err := Verify(key.Public(), []byte("client-seed:2"), pi)
+		assert.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("Other public key", func(t *testing.T) {
+		_, err := Verify(other.Public(), alpha, pi)
+		assert.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("Tampered proof", func(t *testing.T) {
+		tampered := append([]byte(nil), pi...)
+		tampered[40] ^= 0x01
+		_, err := Verify(key.Public(), alpha, tampered)
+		assert.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("Short proof", func(t *testing.T) {
+		_, err := Verify(key.Public(), alpha, pi[:ProofSize-1])
+		assert.Error(t, err)
+	})
+
+	t.Run("Small order public key", func(t *testing.T) {
+		_, err := Verify(edwards25519.NewIdentityPoint().Bytes(), alpha, pi)
+		assert.Error(t, err)
+	})
+}
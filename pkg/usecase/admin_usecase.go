@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/service"
+	"dice-game/pkg/infrastructure/random"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownGenerator is returned by a GeneratorFactory for names it cannot
+// build.
+var ErrUnknownGenerator = errors.New("unknown generator")
+
+// GeneratorFactory builds the generator called name for RegisterGenerator.
+type GeneratorFactory func(name string) (random.Generator, error)
+
+// AdminUseCase lets operators change the generators in rotation at runtime,
+// rotate the provably fair server seed and audit the entropy recorded for
+// games.
+type AdminUseCase struct {
+	randomService service.RandomServiceInterface
+	gameService   service.GameServiceInterface
+	factory       GeneratorFactory
+}
+
+func NewAdminUseCase(randomService service.RandomServiceInterface, gameService service.GameServiceInterface, factory GeneratorFactory) *AdminUseCase {
+	return &AdminUseCase{
+		randomService: randomService,
+		gameService:   gameService,
+		factory:       factory,
+	}
+}
+
+func (uc *AdminUseCase) ListGenerators() []model.GeneratorInfo {
+	return uc.randomService.ListGenerators()
+}
+
+func (uc *AdminUseCase) RegisterGenerator(name string) (*model.GeneratorInfo, error) {
+	generator, err := uc.factory(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.randomService.AddGenerator(generator); err != nil {
+		return nil, err
+	}
+
+	return uc.generatorInfo(name)
+}
+
+func (uc *AdminUseCase) UnregisterGenerator(name string) error {
+	return uc.randomService.RemoveGenerator(name)
+}
+
+// EnableGenerator puts a generator back in rotation. It overrides the
+// health monitor: a quarantine is lifted and the health tests start over, in
+// which case cleared is true.
+func (uc *AdminUseCase) EnableGenerator(name string) (info *model.GeneratorInfo, cleared bool, err error) {
+	if err := uc.randomService.EnableGenerator(name); err != nil {
+		return nil, false, err
+	}
+
+	cleared = uc.randomService.ResetGeneratorHealth(name)
+
+	info, err = uc.generatorInfo(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return info, cleared, nil
+}
+
+func (uc *AdminUseCase) DisableGenerator(name string) (*model.GeneratorInfo, error) {
+	if err := uc.randomService.DisableGenerator(name); err != nil {
+		return nil, err
+	}
+
+	return uc.generatorInfo(name)
+}
+
+// DrainGenerator waits until the generator's games in flight finish or ctx
+// is done; in both cases it returns the generator's current state.
+func (uc *AdminUseCase) DrainGenerator(ctx context.Context, name string) (*model.GeneratorInfo, error) {
+	drainErr := uc.randomService.DrainGenerator(ctx, name)
+
+	info, err := uc.generatorInfo(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, drainErr
+}
+
+// RotateSeed reveals the active provably fair server seed and commits to a
+// new one.
+func (uc *AdminUseCase) RotateSeed(ctx context.Context) (revealed *model.ServerSeed, next *model.ServerSeed, err error) {
+	return uc.gameService.RotateSeed(ctx)
+}
+
+func (uc *AdminUseCase) AuditGameEntropy(ctx context.Context, gameID string) (*model.EntropyAudit, error) {
+	return uc.gameService.AuditEntropy(ctx, gameID)
+}
+
+func (uc *AdminUseCase) generatorInfo(name string) (*model.GeneratorInfo, error) {
+	for _, info := range uc.randomService.ListGenerators() {
+		if info.Name == name {
+			return &info, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", service.ErrGeneratorNotFound, name)
+}
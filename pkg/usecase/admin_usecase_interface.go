@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+)
+
+type AdminUseCaseInterface interface {
+	ListGenerators() []model.GeneratorInfo
+	RegisterGenerator(name string) (*model.GeneratorInfo, error)
+	UnregisterGenerator(name string) error
+	EnableGenerator(name string) (info *model.GeneratorInfo, cleared bool, err error)
+	DisableGenerator(name string) (*model.GeneratorInfo, error)
+	DrainGenerator(ctx context.Context, name string) (*model.GeneratorInfo, error)
+	RotateSeed(ctx context.Context) (revealed *model.ServerSeed, next *model.ServerSeed, err error)
+	AuditGameEntropy(ctx context.Context, gameID string) (*model.EntropyAudit, error)
+}
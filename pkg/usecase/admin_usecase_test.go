@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"dice-game/pkg/domain/model"
+	"dice-game/pkg/domain/service"
+	"dice-game/pkg/infrastructure/random"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAdminUseCase() (*AdminUseCase, *service.RandomService) {
+	randomService := service.NewRandomService([]random.Generator{random.NewStandardGenerator()})
+	factory := func(name string) (random.Generator, error) {
+		if name == "crypto" {
+			return random.NewCryptoGenerator(), nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUnknownGenerator, name)
+	}
+
+	return NewAdminUseCase(randomService, new(MockGameService), factory), randomService
+}
+
+func TestAdminUseCase_RegisterGenerator(t *testing.T) {
+	// Arrange
+	uc, _ := newTestAdminUseCase()
+
+	// Act
+	info, err := uc.RegisterGenerator("crypto")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "crypto", info.Name)
+	assert.Equal(t, model.GeneratorEnabled, info.State)
+	assert.Len(t, uc.ListGenerators(), 2)
+}
+
+func TestAdminUseCase_RegisterGenerator_Unknown(t *testing.T) {
+	// Arrange
+	uc, _ := newTestAdminUseCase()
+
+	// Act
+	info, err := uc.RegisterGenerator("quantum")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownGenerator)
+	assert.Nil(t, info)
+}
+
+func TestAdminUseCase_RegisterGenerator_Duplicate(t *testing.T) {
+	// Arrange
+	uc, _ := newTestAdminUseCase()
+	_, err := uc.RegisterGenerator("crypto")
+	require.NoError(t, err)
+
+	// Act
+	_, err = uc.RegisterGenerator("crypto")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Len(t, uc.ListGenerators(), 2)
+}
+
+func TestAdminUseCase_DisableAndEnableGenerator(t *testing.T) {
+	// Arrange
+	uc, _ := newTestAdminUseCase()
+
+	// Act
+	disabled, disableErr := uc.DisableGenerator("standard")
+	enabled, cleared, enableErr := uc.EnableGenerator("standard")
+
+	// Assert
+	require.NoError(t, disableErr)
+	require.NoError(t, enableErr)
+	assert.Equal(t, model.GeneratorDisabled, disabled.State)
+	assert.Equal(t, model.GeneratorEnabled, enabled.State)
+	assert.False(t, cleared)
+}
+
+func TestAdminUseCase_EnableGenerator_ClearsQuarantine(t *testing.T) {
+	// Arrange
+	uc, randomService := newTestAdminUseCase()
+	randomService.UseHealthMonitor(service.NewHealthMonitor(120, nil))
+	require.Error(t, randomService.ObserveRoll("standard", 1, 6, make([]int, 20)))
+
+	// Act
+	info, cleared, err := uc.EnableGenerator("standard")
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, cleared)
+	assert.False(t, info.Quarantined)
+	_, _, err = randomService.SelectGenerator("player", "")
+	assert.NoError(t, err)
+}
+
+func TestAdminUseCase_EnableGenerator_NotFound(t *testing.T) {
+	// Arrange
+	uc, _ := newTestAdminUseCase()
+
+	// Act
+	_, _, err := uc.EnableGenerator("missing")
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrGeneratorNotFound)
+}
+
+func TestAdminUseCase_UnregisterGenerator(t *testing.T) {
+	// Arrange
+	uc, _ := newTestAdminUseCase()
+
+	// Act
+	err := uc.UnregisterGenerator("standard")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, uc.ListGenerators())
+}
+
+func TestAdminUseCase_DrainGenerator_Timeout(t *testing.T) {
+	// Arrange
+	uc, randomService := newTestAdminUseCase()
+	_, _, err := randomService.SelectGenerator("player-1", "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	info, err := uc.DrainGenerator(ctx, "standard")
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotNil(t, info)
+	assert.Equal(t, model.GeneratorDraining, info.State)
+	assert.Equal(t, 1, info.InFlight)
+}
+
+func TestAdminUseCase_DrainGenerator(t *testing.T) {
+	// Arrange
+	uc, randomService := newTestAdminUseCase()
+	generator, _, err := randomService.SelectGenerator("player-1", "")
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		randomService.ReleaseGenerator(generator.Name())
+	}()
+
+	// Act
+	info, err := uc.DrainGenerator(context.Background(), "standard")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.GeneratorDisabled, info.State)
+	assert.Equal(t, 0, info.InFlight)
+}
+
+func TestAdminUseCase_RotateSeed(t *testing.T) {
+	// Arrange
+	gameService := new(MockGameService)
+	uc := NewAdminUseCase(service.NewRandomService(nil), gameService, nil)
+	revealedAt := time.Now()
+	revealed := &model.ServerSeed{Seed: "old-seed", Hash: "old-hash", RevealedAt: &revealedAt}
+	next := &model.ServerSeed{Hash: "next-hash"}
+	gameService.On("RotateSeed", mock.Anything).Return(revealed, next, nil)
+
+	// Act
+	gotRevealed, gotNext, err := uc.RotateSeed(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, revealed, gotRevealed)
+	assert.Equal(t, next, gotNext)
+	gameService.AssertExpectations(t)
+}
+
+func TestAdminUseCase_AuditGameEntropy(t *testing.T) {
+	// Arrange
+	gameService := new(MockGameService)
+	uc := NewAdminUseCase(service.NewRandomService(nil), gameService, nil)
+	expected := &model.EntropyAudit{Values: []int{4, 2}, Valid: true}
+	gameService.On("AuditEntropy", mock.Anything, "test-game-id").Return(expected, nil)
+
+	// Act
+	audit, err := uc.AuditGameEntropy(context.Background(), "test-game-id")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, audit)
+	gameService.AssertExpectations(t)
+}
@@ -6,28 +6,126 @@ import (
 	"dice-game/pkg/domain/service"
 )
 
+const (
+	defaultVerificationsLimit = 50
+	maxVerificationsLimit     = 100
+)
+
 type GameUseCase struct {
-	gameService service.GameServiceInterface
+	gameService    service.GameServiceInterface
+	ledgerService  service.LedgerServiceInterface
+	receiptService service.ReceiptServiceInterface
+	matchService   service.MatchServiceInterface
 }
 
-func NewGameUseCase(gameService service.GameServiceInterface) *GameUseCase {
+func NewGameUseCase(
+	gameService service.GameServiceInterface,
+	ledgerService service.LedgerServiceInterface,
+	receiptService service.ReceiptServiceInterface,
+	matchService service.MatchServiceInterface,
+) *GameUseCase {
 	return &GameUseCase{
-		gameService: gameService,
+		gameService:    gameService,
+		ledgerService:  ledgerService,
+		receiptService: receiptService,
+		matchService:   matchService,
 	}
 }
 
-func (uc *GameUseCase) PlayGame(ctx context.Context, playerID string) (*model.GameResult, error) {
+func (uc *GameUseCase) PlayGame(ctx context.Context, playerID, clientSeed, generatorName, diceNotation, variantName string) (*model.GameResult, error) {
 	if playerID == "" {
 		playerID = "anonymous"
 	}
 
-	return uc.gameService.PlayGame(ctx, playerID)
+	return uc.gameService.PlayGame(ctx, playerID, clientSeed, generatorName, diceNotation, variantName)
+}
+
+func (uc *GameUseCase) ListGenerators() []model.GeneratorInfo {
+	return uc.gameService.ListGenerators()
+}
+
+func (uc *GameUseCase) ListDiceTables() []string {
+	return uc.gameService.ListDiceTables()
+}
+
+func (uc *GameUseCase) ListVariants() []model.GameVariant {
+	return uc.gameService.ListVariants()
+}
+
+func (uc *GameUseCase) VerifyGame(ctx context.Context, gameID, verificationData, requestedBy string) (bool, error) {
+	if requestedBy == "" {
+		requestedBy = "anonymous"
+	}
+
+	return uc.gameService.VerifyGame(ctx, gameID, verificationData, requestedBy)
 }
 
-func (uc *GameUseCase) VerifyGame(ctx context.Context, gameID, verificationData string) (bool, error) {
-	return uc.gameService.VerifyGame(ctx, gameID, verificationData)
+func (uc *GameUseCase) ListVerifications(ctx context.Context, gameID, playerID string, limit, offset int) ([]*model.VerificationRecord, error) {
+	if limit <= 0 {
+		limit = defaultVerificationsLimit
+	}
+	if limit > maxVerificationsLimit {
+		limit = maxVerificationsLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return uc.gameService.ListVerifications(ctx, gameID, playerID, limit, offset)
 }
 
 func (uc *GameUseCase) GetGameResult(ctx context.Context, gameID string) (*model.GameResult, error) {
 	return uc.gameService.GetGameResult(ctx, gameID)
 }
+
+func (uc *GameUseCase) GetSeedChain(ctx context.Context, gameID string) (*model.SeedChain, *model.SeedChainLink, error) {
+	return uc.gameService.GetSeedChain(ctx, gameID)
+}
+
+// GetGeneratorHealth returns the health test state of every generator, or
+// nil when health monitoring is disabled.
+func (uc *GameUseCase) GetGeneratorHealth() []model.GeneratorHealth {
+	return uc.gameService.GetGeneratorHealth()
+}
+
+func (uc *GameUseCase) GetInclusionProof(ctx context.Context, gameID string) (*model.InclusionProof, error) {
+	return uc.ledgerService.GetInclusionProof(ctx, gameID)
+}
+
+// SignGameResult returns the signed receipt of a game, or nil when receipt
+// signing is disabled.
+func (uc *GameUseCase) SignGameResult(result *model.GameResult) (*model.GameReceipt, error) {
+	return uc.receiptService.SignGameResult(result)
+}
+
+func (uc *GameUseCase) GetSigningKeys() []model.SigningKey {
+	return uc.receiptService.GetSigningKeys()
+}
+
+func (uc *GameUseCase) StartMatch(ctx context.Context, playerID string, bestOf int, generatorName, diceNotation, variantName string) (*model.Match, error) {
+	if playerID == "" {
+		playerID = "anonymous"
+	}
+
+	return uc.matchService.StartMatch(ctx, playerID, bestOf, generatorName, diceNotation, variantName)
+}
+
+func (uc *GameUseCase) PlayRound(ctx context.Context, matchID, clientSeed string) (*model.Match, *model.GameResult, error) {
+	return uc.matchService.PlayRound(ctx, matchID, clientSeed)
+}
+
+func (uc *GameUseCase) PlayMatch(ctx context.Context, playerID, clientSeed string, bestOf int, generatorName, diceNotation, variantName string) (*model.Match, error) {
+	if playerID == "" {
+		playerID = "anonymous"
+	}
+
+	return uc.matchService.PlayMatch(ctx, playerID, clientSeed, bestOf, generatorName, diceNotation, variantName)
+}
+
+func (uc *GameUseCase) GetMatch(ctx context.Context, matchID string) (*model.Match, error) {
+	return uc.matchService.GetMatch(ctx, matchID)
+}
+
+func (uc *GameUseCase) GetMatchStats(ctx context.Context, playerID string) (*model.MatchStats, error) {
+	return uc.matchService.GetMatchStats(ctx, playerID)
+}
@@ -6,7 +6,21 @@ import (
 )
 
 type GameUseCaseInterface interface {
-	PlayGame(ctx context.Context, playerID string) (*model.GameResult, error)
-	VerifyGame(ctx context.Context, gameID string, verificationData string) (bool, error)
+	PlayGame(ctx context.Context, playerID string, clientSeed string, generatorName string, diceNotation string, variantName string) (*model.GameResult, error)
+	ListGenerators() []model.GeneratorInfo
+	ListDiceTables() []string
+	ListVariants() []model.GameVariant
+	VerifyGame(ctx context.Context, gameID string, verificationData string, requestedBy string) (bool, error)
+	ListVerifications(ctx context.Context, gameID string, playerID string, limit, offset int) ([]*model.VerificationRecord, error)
 	GetGameResult(ctx context.Context, gameID string) (*model.GameResult, error)
+	GetSeedChain(ctx context.Context, gameID string) (*model.SeedChain, *model.SeedChainLink, error)
+	GetGeneratorHealth() []model.GeneratorHealth
+	GetInclusionProof(ctx context.Context, gameID string) (*model.InclusionProof, error)
+	SignGameResult(result *model.GameResult) (*model.GameReceipt, error)
+	GetSigningKeys() []model.SigningKey
+	StartMatch(ctx context.Context, playerID string, bestOf int, generatorName string, diceNotation string, variantName string) (*model.Match, error)
+	PlayRound(ctx context.Context, matchID string, clientSeed string) (*model.Match, *model.GameResult, error)
+	PlayMatch(ctx context.Context, playerID string, clientSeed string, bestOf int, generatorName string, diceNotation string, variantName string) (*model.Match, error)
+	GetMatch(ctx context.Context, matchID string) (*model.Match, error)
+	GetMatchStats(ctx context.Context, playerID string) (*model.MatchStats, error)
 }
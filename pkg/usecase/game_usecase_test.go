@@ -15,8 +15,8 @@ type MockGameService struct {
 	mock.Mock
 }
 
-func (m *MockGameService) PlayGame(ctx context.Context, playerID string) (*model.GameResult, error) {
-	args := m.Called(ctx, playerID)
+func (m *MockGameService) PlayGame(ctx context.Context, playerID, clientSeed, generatorName, diceNotation, variantName string) (*model.GameResult, error) {
+	args := m.Called(ctx, playerID, clientSeed, generatorName, diceNotation, variantName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -31,21 +31,186 @@ func (m *MockGameService) GetGameResult(ctx context.Context, gameID string) (*mo
 	return args.Get(0).(*model.GameResult), args.Error(1)
 }
 
-func (m *MockGameService) VerifyGame(ctx context.Context, gameID, clientSeed string) (bool, error) {
-	args := m.Called(ctx, gameID, clientSeed)
+func (m *MockGameService) VerifyGame(ctx context.Context, gameID, clientSeed, requestedBy string) (bool, error) {
+	args := m.Called(ctx, gameID, clientSeed, requestedBy)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockGameService) ListVerifications(ctx context.Context, gameID, playerID string, limit, offset int) ([]*model.VerificationRecord, error) {
+	args := m.Called(ctx, gameID, playerID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.VerificationRecord), args.Error(1)
+}
+
+func (m *MockGameService) RotateSeed(ctx context.Context) (*model.ServerSeed, *model.ServerSeed, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.ServerSeed), args.Get(1).(*model.ServerSeed), args.Error(2)
+}
+
+func (m *MockGameService) GetSeedChain(ctx context.Context, gameID string) (*model.SeedChain, *model.SeedChainLink, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	if args.Get(1) == nil {
+		return args.Get(0).(*model.SeedChain), nil, args.Error(2)
+	}
+	return args.Get(0).(*model.SeedChain), args.Get(1).(*model.SeedChainLink), args.Error(2)
+}
+
+func (m *MockGameService) ListGenerators() []model.GeneratorInfo {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]model.GeneratorInfo)
+}
+
+func (m *MockGameService) ListDiceTables() []string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]string)
+}
+
+func (m *MockGameService) AuditEntropy(ctx context.Context, gameID string) (*model.EntropyAudit, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.EntropyAudit), args.Error(1)
+}
+
+func (m *MockGameService) ListVariants() []model.GameVariant {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]model.GameVariant)
+}
+
+func (m *MockGameService) GetGeneratorHealth() []model.GeneratorHealth {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]model.GeneratorHealth)
+}
+
+type MockLedgerService struct {
+	mock.Mock
+}
+
+func (m *MockLedgerService) SealPendingDays(ctx context.Context, now time.Time) ([]*model.DailyMerkleRoot, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.DailyMerkleRoot), args.Error(1)
+}
+
+func (m *MockLedgerService) SealDay(ctx context.Context, day time.Time) (*model.DailyMerkleRoot, error) {
+	args := m.Called(ctx, day)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DailyMerkleRoot), args.Error(1)
+}
+
+func (m *MockLedgerService) GetInclusionProof(ctx context.Context, gameID string) (*model.InclusionProof, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InclusionProof), args.Error(1)
+}
+
+type MockMatchService struct {
+	mock.Mock
+}
+
+func (m *MockMatchService) StartMatch(ctx context.Context, playerID string, bestOf int, generatorName, diceNotation, variantName string) (*model.Match, error) {
+	args := m.Called(ctx, playerID, bestOf, generatorName, diceNotation, variantName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Match), args.Error(1)
+}
+
+func (m *MockMatchService) PlayRound(ctx context.Context, matchID, clientSeed string) (*model.Match, *model.GameResult, error) {
+	args := m.Called(ctx, matchID, clientSeed)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.Match), args.Get(1).(*model.GameResult), args.Error(2)
+}
+
+func (m *MockMatchService) PlayMatch(ctx context.Context, playerID, clientSeed string, bestOf int, generatorName, diceNotation, variantName string) (*model.Match, error) {
+	args := m.Called(ctx, playerID, clientSeed, bestOf, generatorName, diceNotation, variantName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Match), args.Error(1)
+}
+
+func (m *MockMatchService) GetMatch(ctx context.Context, matchID string) (*model.Match, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Match), args.Error(1)
+}
+
+func (m *MockMatchService) GetMatchStats(ctx context.Context, playerID string) (*model.MatchStats, error) {
+	args := m.Called(ctx, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MatchStats), args.Error(1)
+}
+
+type MockReceiptService struct {
+	mock.Mock
+}
+
+func (m *MockReceiptService) SignGameResult(result *model.GameResult) (*model.GameReceipt, error) {
+	args := m.Called(result)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.GameReceipt), args.Error(1)
+}
+
+func (m *MockReceiptService) GetSigningKeys() []model.SigningKey {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]model.SigningKey)
+}
+
 func TestNewGameUseCase(t *testing.T) {
 	// Arrange
 	mockService := new(MockGameService)
+	mockLedger := new(MockLedgerService)
+	mockReceipts := new(MockReceiptService)
+	mockMatches := new(MockMatchService)
 
 	// Act
-	usecase := NewGameUseCase(mockService)
+	usecase := NewGameUseCase(mockService, mockLedger, mockReceipts, mockMatches)
 
 	// Assert
 	assert.NotNil(t, usecase)
 	assert.Equal(t, mockService, usecase.gameService)
+	assert.Equal(t, mockLedger, usecase.ledgerService)
+	assert.Equal(t, mockReceipts, usecase.receiptService)
+	assert.Equal(t, mockMatches, usecase.matchService)
 }
 
 func TestGameUseCase_PlayGame(t *testing.T) {
@@ -61,11 +226,11 @@ func TestGameUseCase_PlayGame(t *testing.T) {
 			PlayedAt:   time.Now(),
 		}
 
-		mockService.On("PlayGame", mock.Anything, "test-player").Return(expectedResult, nil)
-		usecase := NewGameUseCase(mockService)
+		mockService.On("PlayGame", mock.Anything, "test-player", "client-seed", "", "", "").Return(expectedResult, nil)
+		usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 		// Act
-		result, err := usecase.PlayGame(context.Background(), "test-player")
+		result, err := usecase.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
 
 		// Assert
 		assert.NoError(t, err)
@@ -85,11 +250,11 @@ func TestGameUseCase_PlayGame(t *testing.T) {
 			PlayedAt:   time.Now(),
 		}
 
-		mockService.On("PlayGame", mock.Anything, "anonymous").Return(expectedResult, nil)
-		usecase := NewGameUseCase(mockService)
+		mockService.On("PlayGame", mock.Anything, "anonymous", "client-seed", "", "", "").Return(expectedResult, nil)
+		usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 		// Act
-		result, err := usecase.PlayGame(context.Background(), "")
+		result, err := usecase.PlayGame(context.Background(), "", "client-seed", "", "", "")
 
 		// Assert
 		assert.NoError(t, err)
@@ -102,11 +267,11 @@ func TestGameUseCase_PlayGame(t *testing.T) {
 		mockService := new(MockGameService)
 		expectedError := errors.New("service error")
 
-		mockService.On("PlayGame", mock.Anything, "test-player").Return(nil, expectedError)
-		usecase := NewGameUseCase(mockService)
+		mockService.On("PlayGame", mock.Anything, "test-player", "client-seed", "", "", "").Return(nil, expectedError)
+		usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 		// Act
-		result, err := usecase.PlayGame(context.Background(), "test-player")
+		result, err := usecase.PlayGame(context.Background(), "test-player", "client-seed", "", "", "")
 
 		// Assert
 		assert.Error(t, err)
@@ -130,7 +295,7 @@ func TestGameUseCase_GetGameResult(t *testing.T) {
 		}
 
 		mockService.On("GetGameResult", mock.Anything, "test-game-id").Return(expectedResult, nil)
-		usecase := NewGameUseCase(mockService)
+		usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 		// Act
 		result, err := usecase.GetGameResult(context.Background(), "test-game-id")
@@ -147,7 +312,7 @@ func TestGameUseCase_GetGameResult(t *testing.T) {
 		expectedError := errors.New("game not found")
 
 		mockService.On("GetGameResult", mock.Anything, "nonexistent-id").Return(nil, expectedError)
-		usecase := NewGameUseCase(mockService)
+		usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 		// Act
 		result, err := usecase.GetGameResult(context.Background(), "nonexistent-id")
@@ -167,11 +332,11 @@ func TestGameUseCase_VerifyGame(t *testing.T) {
 		gameID := "test-game-id"
 		clientSeed := "test-client-seed"
 
-		mockService.On("VerifyGame", mock.Anything, gameID, clientSeed).Return(true, nil)
-		usecase := NewGameUseCase(mockService)
+		mockService.On("VerifyGame", mock.Anything, gameID, clientSeed, "auditor").Return(true, nil)
+		usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 		// Act
-		isValid, err := usecase.VerifyGame(context.Background(), gameID, clientSeed)
+		isValid, err := usecase.VerifyGame(context.Background(), gameID, clientSeed, "auditor")
 
 		// Assert
 		assert.NoError(t, err)
@@ -185,11 +350,11 @@ func TestGameUseCase_VerifyGame(t *testing.T) {
 		gameID := "test-game-id"
 		clientSeed := "test-client-seed"
 
-		mockService.On("VerifyGame", mock.Anything, gameID, clientSeed).Return(false, nil)
-		usecase := NewGameUseCase(mockService)
+		mockService.On("VerifyGame", mock.Anything, gameID, clientSeed, "auditor").Return(false, nil)
+		usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 		// Act
-		isValid, err := usecase.VerifyGame(context.Background(), gameID, clientSeed)
+		isValid, err := usecase.VerifyGame(context.Background(), gameID, clientSeed, "auditor")
 
 		// Assert
 		assert.NoError(t, err)
@@ -204,11 +369,11 @@ func TestGameUseCase_VerifyGame(t *testing.T) {
 		clientSeed := "test-client-seed"
 		expectedError := errors.New("verification error")
 
-		mockService.On("VerifyGame", mock.Anything, gameID, clientSeed).Return(false, expectedError)
-		usecase := NewGameUseCase(mockService)
+		mockService.On("VerifyGame", mock.Anything, gameID, clientSeed, "auditor").Return(false, expectedError)
+		usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 		// Act
-		isValid, err := usecase.VerifyGame(context.Background(), gameID, clientSeed)
+		isValid, err := usecase.VerifyGame(context.Background(), gameID, clientSeed, "auditor")
 
 		// Assert
 		assert.Error(t, err)
@@ -218,6 +383,142 @@ func TestGameUseCase_VerifyGame(t *testing.T) {
 	})
 }
 
+func TestGameUseCase_VerifyGame_DefaultsRequester(t *testing.T) {
+	// Arrange
+	mockService := new(MockGameService)
+	mockService.On("VerifyGame", mock.Anything, "test-game-id", "", "anonymous").Return(true, nil)
+	usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
+
+	// Act
+	isValid, err := usecase.VerifyGame(context.Background(), "test-game-id", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, isValid)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameUseCase_ListVerifications(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          int
+		offset         int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{name: "Explicit page", limit: 10, offset: 20, expectedLimit: 10, expectedOffset: 20},
+		{name: "Default limit", limit: 0, offset: 0, expectedLimit: defaultVerificationsLimit, expectedOffset: 0},
+		{name: "Limit is capped", limit: 1000, offset: 0, expectedLimit: maxVerificationsLimit, expectedOffset: 0},
+		{name: "Negative offset", limit: 10, offset: -5, expectedLimit: 10, expectedOffset: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockGameService)
+			records := []*model.VerificationRecord{{ID: 1, GameID: "test-game-id"}}
+			mockService.On("ListVerifications", mock.Anything, "test-game-id", "", tt.expectedLimit, tt.expectedOffset).
+				Return(records, nil)
+			usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
+
+			// Act
+			result, err := usecase.ListVerifications(context.Background(), "test-game-id", "", tt.limit, tt.offset)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, records, result)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameUseCase_GetSeedChain(t *testing.T) {
+	// Arrange
+	mockService := new(MockGameService)
+	chain := &model.SeedChain{ID: 1, TerminalHash: "terminal", Length: 10}
+	link := &model.SeedChainLink{ChainID: 1, Position: 3, Seed: "seed"}
+
+	mockService.On("GetSeedChain", mock.Anything, "test-game-id").Return(chain, link, nil)
+	usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
+
+	// Act
+	gotChain, gotLink, err := usecase.GetSeedChain(context.Background(), "test-game-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, chain, gotChain)
+	assert.Equal(t, link, gotLink)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameUseCase_GetInclusionProof(t *testing.T) {
+	// Arrange
+	mockService := new(MockGameService)
+	mockLedger := new(MockLedgerService)
+	proof := &model.InclusionProof{GameID: "test-game-id", RootHash: "root"}
+
+	mockLedger.On("GetInclusionProof", mock.Anything, "test-game-id").Return(proof, nil)
+	usecase := NewGameUseCase(mockService, mockLedger, new(MockReceiptService), new(MockMatchService))
+
+	// Act
+	result, err := usecase.GetInclusionProof(context.Background(), "test-game-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, proof, result)
+	mockLedger.AssertExpectations(t)
+}
+
+func TestGameUseCase_SignGameResult(t *testing.T) {
+	// Arrange
+	mockReceipts := new(MockReceiptService)
+	result := &model.GameResult{GameID: "test-game-id"}
+	receipt := &model.GameReceipt{GameID: "test-game-id", KeyID: "key-1", Signature: []byte("sig")}
+
+	mockReceipts.On("SignGameResult", result).Return(receipt, nil)
+	usecase := NewGameUseCase(new(MockGameService), new(MockLedgerService), mockReceipts, new(MockMatchService))
+
+	// Act
+	got, err := usecase.SignGameResult(result)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, receipt, got)
+	mockReceipts.AssertExpectations(t)
+}
+
+func TestGameUseCase_GetGeneratorHealth(t *testing.T) {
+	// Arrange
+	mockService := new(MockGameService)
+	health := []model.GeneratorHealth{{Generator: "crypto", Status: model.HealthHealthy}}
+
+	mockService.On("GetGeneratorHealth").Return(health)
+	usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
+
+	// Act
+	result := usecase.GetGeneratorHealth()
+
+	// Assert
+	assert.Equal(t, health, result)
+	mockService.AssertExpectations(t)
+}
+
+func TestGameUseCase_ListGenerators(t *testing.T) {
+	// Arrange
+	mockService := new(MockGameService)
+	generators := []model.GeneratorInfo{{Name: "provably_fair", Verifiable: true, AlgorithmVersion: 3, Selectable: true}}
+
+	mockService.On("ListGenerators").Return(generators)
+	usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
+
+	// Act
+	result := usecase.ListGenerators()
+
+	// Assert
+	assert.Equal(t, generators, result)
+	mockService.AssertExpectations(t)
+}
+
 func TestGameUseCase_ContextPropagation(t *testing.T) {
 	type ctxKey string
 	var testKey ctxKey = "test-key"
@@ -227,14 +528,69 @@ func TestGameUseCase_ContextPropagation(t *testing.T) {
 	mockService := new(MockGameService)
 	mockService.On("PlayGame", mock.MatchedBy(func(c context.Context) bool {
 		return c.Value(testKey) == testValue
-	}), "test-player").Return(&model.GameResult{}, nil)
+	}), "test-player", "client-seed", "", "", "").Return(&model.GameResult{}, nil)
 
-	usecase := NewGameUseCase(mockService)
+	usecase := NewGameUseCase(mockService, new(MockLedgerService), new(MockReceiptService), new(MockMatchService))
 
 	// Act
-	_, err := usecase.PlayGame(ctx, "test-player")
+	_, err := usecase.PlayGame(ctx, "test-player", "client-seed", "", "", "")
 
 	// Assert
 	assert.NoError(t, err)
 	mockService.AssertExpectations(t)
 }
+
+func TestGameUseCase_PlayMatch(t *testing.T) {
+	t.Run("Success with valid player ID", func(t *testing.T) {
+		// Arrange
+		mockMatches := new(MockMatchService)
+		expected := &model.Match{MatchID: "match-1", PlayerID: "test-player", BestOf: 3, Winner: model.WinnerServer}
+
+		mockMatches.On("PlayMatch", mock.Anything, "test-player", "client-seed", 3, "", "", "lowest-wins").Return(expected, nil)
+		usecase := NewGameUseCase(new(MockGameService), new(MockLedgerService), new(MockReceiptService), mockMatches)
+
+		// Act
+		match, err := usecase.PlayMatch(context.Background(), "test-player", "client-seed", 3, "", "", "lowest-wins")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, match)
+		mockMatches.AssertExpectations(t)
+	})
+
+	t.Run("Success with empty player ID (anonymous)", func(t *testing.T) {
+		// Arrange
+		mockMatches := new(MockMatchService)
+		expected := &model.Match{MatchID: "match-1", PlayerID: "anonymous", BestOf: 5}
+
+		mockMatches.On("StartMatch", mock.Anything, "anonymous", 5, "", "", "").Return(expected, nil)
+		usecase := NewGameUseCase(new(MockGameService), new(MockLedgerService), new(MockReceiptService), mockMatches)
+
+		// Act
+		match, err := usecase.StartMatch(context.Background(), "", 5, "", "", "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, match)
+		mockMatches.AssertExpectations(t)
+	})
+}
+
+func TestGameUseCase_PlayRound(t *testing.T) {
+	// Arrange
+	mockMatches := new(MockMatchService)
+	match := &model.Match{MatchID: "match-1", BestOf: 3}
+	game := &model.GameResult{GameID: "game-1", MatchID: "match-1", MatchRound: 1}
+
+	mockMatches.On("PlayRound", mock.Anything, "match-1", "client-seed").Return(match, game, nil)
+	usecase := NewGameUseCase(new(MockGameService), new(MockLedgerService), new(MockReceiptService), mockMatches)
+
+	// Act
+	playedMatch, playedGame, err := usecase.PlayRound(context.Background(), "match-1", "client-seed")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, match, playedMatch)
+	assert.Equal(t, game, playedGame)
+	mockMatches.AssertExpectations(t)
+}